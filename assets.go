@@ -0,0 +1,84 @@
+// assets.go
+// Render boyunca include/component edilen dosyaların script/style bloklarını toplar
+package hipoengine
+
+import "strings"
+
+// Asset marker'ları; render bittikten sonra toplanan tag'larla değiştirilir.
+const (
+	stylesMarker  = "\x00hipo:styles\x00"
+	scriptsMarker = "\x00hipo:scripts\x00"
+)
+
+// assetCollector, bir render boyunca görülen script/style bloklarını tekrarsız ve ilk görülme
+// sırasıyla tutar.
+type assetCollector struct {
+	scripts []*SFCBlock
+	styles  []*SFCBlock
+	seen    map[string]bool
+}
+
+func newAssetCollector() *assetCollector {
+	return &assetCollector{seen: make(map[string]bool)}
+}
+
+// add, blokları tipine göre ekler; aynı attribute ve içeriğe sahip bloklar bir kez eklenir.
+func (c *assetCollector) add(blocks ...*SFCBlock) {
+	for _, b := range blocks {
+		key := b.Type + b.AttrString() + "\x00" + strings.TrimSpace(b.Content)
+		if c.seen[key] {
+			continue
+		}
+		c.seen[key] = true
+		switch b.Type {
+		case "script":
+			c.scripts = append(c.scripts, b)
+		case "style":
+			c.styles = append(c.styles, b)
+		}
+	}
+}
+
+// apply, render çıktısındaki {{ styles }} / {{ scripts }} marker'larını toplanan tag'larla
+// değiştirir. Marker yoksa script'ler çıktının başına, style'lar sonuna eklenir.
+func (c *assetCollector) apply(html string) string {
+	scripts := assetTags("script", c.scripts)
+	styles := assetTags("style", c.styles)
+	if strings.Contains(html, scriptsMarker) {
+		html = strings.Replace(html, scriptsMarker, scripts, 1)
+		html = strings.ReplaceAll(html, scriptsMarker, "")
+	} else if scripts != "" {
+		html = scripts + "\n" + html
+	}
+	if strings.Contains(html, stylesMarker) {
+		html = strings.Replace(html, stylesMarker, styles, 1)
+		html = strings.ReplaceAll(html, stylesMarker, "")
+	} else if styles != "" {
+		html += "\n" + styles
+	}
+	return html
+}
+
+// AssetsNode, {{ styles }} veya {{ scripts }} tag'ıdır; toplanan asset'lerin yerini belirler.
+// Context'te aynı isimde bir değişken varsa tag o değişkeni yazdırır; böylece styles/scripts
+// isimli değişkenleri kullanan eski template'ler bozulmaz.
+type AssetsNode struct {
+	Kind string // "styles" veya "scripts"
+}
+
+func (n *AssetsNode) Execute(ctx *Context) (string, error) {
+	if val, ok := ctx.lookup(n.Kind); ok && val != nil {
+		return (&VariableNode{Name: n.Kind}).Execute(ctx)
+	}
+	if ctx.assets == nil {
+		return "", nil
+	}
+	if n.Kind == "scripts" {
+		return scriptsMarker, nil
+	}
+	return stylesMarker, nil
+}
+
+func (n *AssetsNode) ExecuteRaw(ctx *Context) (interface{}, error) {
+	return n.Execute(ctx)
+}
@@ -0,0 +1,65 @@
+package hipoengine
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLayoutAssetMarkers(t *testing.T) {
+	e := NewEngine()
+	e.AddTemplatePath("testdata/assets")
+	out, err := e.RenderWithLayout("page.hipo", "layout.hipo", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("RenderWithLayout error: %v", err)
+	}
+	if n := strings.Count(out, `console.log("button");`); n != 1 {
+		t.Errorf("Include edilen script bir kez eklenmeliydi, %d kez eklendi:\n%s", n, out)
+	}
+	if n := strings.Count(out, "button { color: red; }"); n != 1 {
+		t.Errorf("Include edilen style bir kez eklenmeliydi, %d kez eklendi:\n%s", n, out)
+	}
+	head := out[strings.Index(out, "<head>"):strings.Index(out, "</head>")]
+	if !strings.Contains(head, "body { margin: 0; }") || !strings.Contains(head, "button { color: red; }") {
+		t.Errorf("Style'lar {{ styles }} konumunda olmalıydı:\n%s", out)
+	}
+	body := out[strings.Index(out, "<body>"):strings.Index(out, "</body>")]
+	if !strings.Contains(body, `<script type="module">`) || !strings.Contains(body, `console.log("button");`) {
+		t.Errorf("Script'ler {{ scripts }} konumunda olmalıydı:\n%s", out)
+	}
+	if strings.Index(out, "<button>") > strings.Index(out, `console.log("button");`) {
+		t.Errorf("Script'ler içerikten sonra gelmeliydi:\n%s", out)
+	}
+}
+
+func TestRenderWithoutMarkersKeepsAssetPlacement(t *testing.T) {
+	e := NewEngine()
+	e.AddTemplatePath("testdata/assets")
+	out, err := e.Render(`<div>{{ include "partials/button.hipo" }}{{ include "partials/button.hipo" }}</div>`, nil)
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if !strings.HasPrefix(out, "<script>") || !strings.HasSuffix(out, "</style>") {
+		t.Errorf("Marker yokken script başa, style sona eklenmeli:\n%s", out)
+	}
+	if n := strings.Count(out, "<script>"); n != 1 {
+		t.Errorf("Script bir kez eklenmeliydi, %d kez eklendi:\n%s", n, out)
+	}
+}
+
+func TestAssetTagsFallBackToVariables(t *testing.T) {
+	e := NewEngine()
+	out, err := e.Render(`{{ styles }}|{{ scripts }}`, map[string]interface{}{"styles": "<b>", "scripts": "app.js"})
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if out != "&lt;b&gt;|app.js" {
+		t.Errorf("Değişkenler yazdırılmalıydı, gerçek: %q", out)
+	}
+	out, err = e.Render(`{{ styles }}|{{ scripts }}`, map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if out != "|" {
+		t.Errorf("Değişken ve asset yokken boş çıktı beklendi, gerçek: %q", out)
+	}
+}
@@ -7,9 +7,30 @@ import (
 
 // ParseBlocks, template içerisindeki tüm {{ block name }}...{{ endblock }} bloklarını parse eder
 func ParseBlocks(tpl string) (map[string]ASTNode, error) {
+	return NewParser("").parseBlocks(tpl, -1)
+}
+
+// parseBlocks, ParseBlocks gibi çalışır ancak blok gövdelerini parser'ın ayarlarıyla parse eder.
+// base, tpl'in parser'ın template'i içindeki offset'idir; bu durumda gövdeler konum bilgisini
+// koruyan alt parser'larla parse edilir, hatalar kaydedilir ve hatalı bloktan sonra devam edilir.
+// tpl template'in parçası değilse base -1'dir ve ilk hata döner.
+func (p *Parser) parseBlocks(tpl string, base int) (map[string]ASTNode, error) {
 	blocks := make(map[string]ASTNode)
+	skip := func(n int) {
+		tpl = tpl[n:]
+		if base >= 0 {
+			base += n
+		}
+	}
+	fail := func(start, end int, format string, args ...interface{}) error {
+		if base < 0 {
+			return fmt.Errorf(format, args...)
+		}
+		p.fail(base+start, base+end, format, args...)
+		return nil
+	}
 	for {
-		tpl = strings.TrimLeft(tpl, " \t\r\n") // baştaki boşlukları ve satır sonlarını atla
+		skip(len(tpl) - len(strings.TrimLeft(tpl, " \t\r\n"))) // baştaki boşlukları ve satır sonlarını atla
 		if len(tpl) == 0 {
 			break
 		}
@@ -19,27 +40,32 @@ func ParseBlocks(tpl string) (map[string]ASTNode, error) {
 		}
 		if start > 0 {
 			// Block'tan önce kalan gereksiz içeriği atla
-			tpl = tpl[start:]
+			skip(start)
 			start = 0
 		}
 		endBlockName := strings.Index(tpl[start:], "}}")
 		if endBlockName == -1 {
-			return nil, fmt.Errorf("unclosed block tag")
+			return blocks, fail(start, len(tpl), "unclosed block tag")
 		}
 		name := strings.TrimSpace(tpl[start+len("{{ block ") : start+endBlockName])
 		after := tpl[start+endBlockName+2:]
 		endBlock := "{{ endblock }}"
 		endIdx := strings.Index(after, endBlock)
 		if endIdx == -1 {
-			return nil, fmt.Errorf("unclosed endblock for block: %s", name)
+			return blocks, fail(start, start+endBlockName+2, "unclosed endblock for block: %s", name)
 		}
-		blockBody := after[:endIdx]
-		ast, err := NewParser(blockBody).Parse()
-		if err != nil {
-			return nil, err
+		bodyStart := start + endBlockName + 2
+		if base >= 0 {
+			ast := p.sub(base+bodyStart, base+bodyStart+endIdx).parse()
+			blocks[name] = p.cover(ast, "block", base+start, base+bodyStart, base+bodyStart+endIdx)
+		} else {
+			ast, err := p.derive(after[:endIdx]).Parse()
+			if err != nil {
+				return nil, err
+			}
+			blocks[name] = ast
 		}
-		blocks[name] = ast
-		tpl = after[endIdx+len(endBlock):]
+		skip(bodyStart + endIdx + len(endBlock))
 	}
 	return blocks, nil
 }
@@ -0,0 +1,457 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"hipoengine"
+	"hipoengine/internal/data"
+)
+
+func init() {
+	commands = append(commands, command{name: "build", usage: "kaynak dizinindeki sayfaları statik siteye derler", run: runBuild})
+}
+
+// manifestFile, çıktı dizininde önceki build'in durumunu tutan dosyadır.
+const manifestFile = ".hipo-build.json"
+
+// buildManifest, artımlı build için kaynak dosyaların özetlerini ve ürettikleri çıktıları tutar.
+type buildManifest struct {
+	Options string              `json:"options"`
+	Files   map[string]string   `json:"files"`   // kaynak dosya -> sha256
+	Outputs map[string][]string `json:"outputs"` // sayfa veya statik dosya -> çıktı dosyaları
+}
+
+// builder, bir src dizinini out dizinine derler. Adı "_" veya "." ile başlayan dosya ve dizinler
+// (layout, partial, veri) sayfa veya statik dosya sayılmaz.
+type builder struct {
+	engine  *hipoengine.Engine
+	src     string
+	out     string
+	i18n    string
+	locales []string
+	full    bool
+	options string
+
+	pages   []string          // src'ye göre göreli .hipo sayfaları
+	static  []string          // kopyalanacak dosyalar
+	data    map[string]string // sayfa -> yanındaki veri dosyası
+	layouts map[string]string // sayfa -> front matter'daki layout
+	hashes  map[string]string // kaynak dosyaların (src, i18n ve grafiğin çözdüğü template'ler) özetleri
+
+	graph    *hipoengine.DependencyGraph
+	graphErr error // grafik oluşturulamadıysa (ör: parse hatası) tüm sayfalar yeniden denenir
+}
+
+// runBuild, render hatasında 1, kullanım veya okuma hatasında 2 döndürür.
+func runBuild(args []string) int {
+	fs := flag.NewFlagSet("build", flag.ExitOnError)
+	locales := fs.String("locales", "", "her biri için ayrı çıktı üretilecek diller (virgülle ayrılmış, ör: tr,en; -i18n verilip boş bırakılırsa çeviri dosyalarındaki diller)")
+	i18n := fs.String("i18n", "", "çeviri JSON dosyalarının dizini (SetTranslationsFromDir)")
+	full := fs.Bool("full", false, "önceki build'i yok say, her şeyi yeniden üret")
+	minify := fs.Bool("minify", true, "çıktıyı MinifyHTML ile sadeleştir")
+	var ef engineFlags
+	ef.register(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Kullanım: hipo build [-locales tr,en] [-i18n dizin] [-full] [-minify=false] [-path dizin]... [-alias ad=yol]... src/ out/")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return 2
+	}
+	engine, err := ef.engine()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	engine.SetMinifyMode(*minify)
+	b := &builder{
+		engine:  engine,
+		src:     filepath.Clean(fs.Arg(0)),
+		out:     filepath.Clean(fs.Arg(1)),
+		i18n:    *i18n,
+		locales: splitNames(*locales),
+		full:    *full,
+	}
+	// Sayfalardaki include/extends/component yolları src'ye göre çözülür
+	engine.AddTemplatePath(b.src)
+	if b.i18n != "" {
+		if err := engine.SetTranslationsFromDir(b.i18n); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		if len(b.locales) == 0 {
+			if b.locales, err = catalogLocales(b.i18n); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				return 2
+			}
+		}
+	}
+	b.options = fmt.Sprint(*minify, *i18n, b.locales, ef.paths, ef.aliases)
+	return b.run()
+}
+
+func (b *builder) run() int {
+	if err := b.scan(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	b.dependencies()
+	prev := b.loadManifest()
+	full := b.full || prev.Options != b.options
+	changed := make(map[string]bool)
+	for file, sum := range b.hashes {
+		if prev.Files[file] != sum {
+			changed[file] = true
+		}
+	}
+	for file := range prev.Files {
+		if _, ok := b.hashes[file]; !ok {
+			changed[file] = true
+		}
+	}
+	for file := range changed {
+		if b.i18n != "" && strings.HasPrefix(file, filepath.Clean(b.i18n)+string(filepath.Separator)) {
+			full = true // çeviriler tüm sayfaları etkiler
+		}
+	}
+	affected := b.affected(changed, full)
+
+	next := buildManifest{Options: b.options, Files: b.hashes, Outputs: make(map[string][]string)}
+	rendered, copied, skipped, failed := 0, 0, 0, 0
+	for _, page := range b.pages {
+		outputs := b.pageOutputs(page)
+		next.Outputs[page] = outputs
+		if !full && !affected[page] && exists(outputs...) {
+			skipped++
+			continue
+		}
+		if err := b.renderPage(page, outputs); err != nil {
+			printError(b.engine, fmt.Errorf("%s: %w", filepath.Join(b.src, page), err))
+			// Hatalı sayfa bir sonraki build'de yeniden denenir
+			delete(next.Files, filepath.Join(b.src, page))
+			failed++
+			continue
+		}
+		rendered++
+	}
+	for _, file := range b.static {
+		target := filepath.Join(b.out, file)
+		next.Outputs[file] = []string{target}
+		if !full && !changed[filepath.Join(b.src, file)] && exists(target) {
+			skipped++
+			continue
+		}
+		if err := copyFile(filepath.Join(b.src, file), target); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			delete(next.Files, filepath.Join(b.src, file))
+			failed++
+			continue
+		}
+		copied++
+	}
+	// Kaynağı silinen sayfa ve dosyaların çıktıları temizlenir
+	for source, outputs := range prev.Outputs {
+		if _, ok := next.Outputs[source]; !ok {
+			for _, out := range outputs {
+				os.Remove(out)
+			}
+		}
+	}
+	if err := b.saveManifest(next); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	fmt.Fprintf(os.Stderr, "hipo build: %d sayfa render edildi, %d dosya kopyalandı, %d güncel", rendered, copied, skipped)
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, ", %d hata\n", failed)
+		return 1
+	}
+	fmt.Fprintln(os.Stderr)
+	return 0
+}
+
+// scan, src dizinini sayfa, veri ve statik dosyalara ayırır ve tüm kaynakların özetini çıkarır.
+func (b *builder) scan() error {
+	b.pages, b.static = nil, nil
+	b.data = make(map[string]string)
+	b.hashes = make(map[string]string)
+	var files []string
+	err := filepath.WalkDir(b.src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == b.src {
+			return nil
+		}
+		rel, _ := filepath.Rel(b.src, path)
+		if d.IsDir() {
+			if filepath.Clean(path) == b.out {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		sum, err := fileHash(path)
+		if err != nil {
+			return err
+		}
+		b.hashes[path] = sum
+		if !hidden(rel) {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if b.i18n != "" {
+		matches, _ := filepath.Glob(filepath.Join(b.i18n, "*.json"))
+		for _, path := range matches {
+			if b.hashes[path], err = fileHash(path); err != nil {
+				return err
+			}
+		}
+	}
+	isPage := make(map[string]bool)
+	for _, rel := range files {
+		if strings.HasSuffix(rel, ".hipo") {
+			b.pages = append(b.pages, rel)
+			isPage[strings.TrimSuffix(rel, ".hipo")] = true
+		}
+	}
+	for _, rel := range files {
+		if strings.HasSuffix(rel, ".hipo") {
+			continue
+		}
+		base := strings.TrimSuffix(rel, filepath.Ext(rel))
+		if data.Format(rel) != "" && isPage[base] {
+			b.data[base+".hipo"] = rel
+			continue
+		}
+		b.static = append(b.static, rel)
+	}
+	sort.Strings(b.pages)
+	sort.Strings(b.static)
+	return nil
+}
+
+// hidden, göreli yolun bir parçası "_" veya "." ile başlıyorsa true döner.
+func hidden(rel string) bool {
+	for _, part := range strings.Split(filepath.ToSlash(rel), "/") {
+		if strings.HasPrefix(part, "_") || strings.HasPrefix(part, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// dependencies, sayfalar ve front matter layout'larından bağımlılık grafiğini oluşturur. Grafiğin
+// çözdüğü ve scan'in görmediği template'lerin (ör: -path dizinlerindeki partial'lar) özetleri de
+// eklenir; böylece onlardaki değişiklikler de yeniden build'i tetikler.
+func (b *builder) dependencies() {
+	roots := make([]string, 0, len(b.pages))
+	for _, page := range b.pages {
+		roots = append(roots, filepath.Join(b.src, page))
+	}
+	b.layouts = make(map[string]string)
+	for _, page := range b.pages {
+		if fm, err := b.engine.FrontMatter(filepath.Join(b.src, page)); err == nil {
+			if layout, ok := fm["layout"].(string); ok {
+				b.layouts[page] = filepath.Join(b.src, layout)
+				roots = append(roots, b.layouts[page])
+			}
+		}
+	}
+	b.graph, b.graphErr = b.engine.DependencyGraph(roots...)
+	if b.graphErr != nil {
+		return
+	}
+	for _, file := range b.graph.Files() {
+		path := b.key(file)
+		if _, ok := b.hashes[path]; ok {
+			continue
+		}
+		// Bulunamayan bağımlılıklar özetlenmez; önceki build'de varlarsa değişmiş sayılırlar
+		if sum, err := fileHash(path); err == nil {
+			b.hashes[path] = sum
+		}
+	}
+}
+
+// affected, değişen dosyalardan etkilenen sayfaları döndürür: sayfanın kendisi, veri dosyası,
+// front matter layout'u veya bağımlılık grafiğinde (extends/include/component) sayfanın ya da
+// layout'unun bağlı olduğu bir template değişmişse sayfa yeniden render edilir.
+func (b *builder) affected(changed map[string]bool, full bool) map[string]bool {
+	affected := make(map[string]bool)
+	if full {
+		return affected
+	}
+	graph, err := b.graph, b.graphErr
+	layouts := b.layouts
+	dependents := make(map[string]bool)
+	for file := range changed {
+		if !strings.HasSuffix(file, ".hipo") {
+			continue
+		}
+		dependents[b.key(file)] = true
+		if err != nil {
+			continue
+		}
+		for _, d := range graph.Dependents(file) {
+			dependents[b.key(d)] = true
+		}
+	}
+	for _, page := range b.pages {
+		path := filepath.Join(b.src, page)
+		switch {
+		case err != nil:
+			// Grafik oluşturulamadıysa (ör: parse hatası) tüm sayfalar yeniden denenir
+			affected[page] = true
+		case dependents[b.key(path)]:
+			affected[page] = true
+		case b.data[page] != "" && changed[filepath.Join(b.src, b.data[page])]:
+			affected[page] = true
+		case layouts[page] != "" && dependents[b.key(layouts[page])]:
+			affected[page] = true
+		}
+	}
+	return affected
+}
+
+// key, template yolunu engine'in çözdüğü dosya yoluna çevirir.
+func (b *builder) key(name string) string {
+	if resolved, err := b.engine.ResolveTemplatePath(name); err == nil {
+		return filepath.Clean(resolved)
+	}
+	return filepath.Clean(name)
+}
+
+// pageOutputs, sayfanın her dil için çıktı dosyalarını döndürür (ör: out/tr/blog/post.html).
+func (b *builder) pageOutputs(page string) []string {
+	html := strings.TrimSuffix(page, ".hipo") + ".html"
+	if len(b.locales) == 0 {
+		return []string{filepath.Join(b.out, html)}
+	}
+	outputs := make([]string, len(b.locales))
+	for i, locale := range b.locales {
+		outputs[i] = filepath.Join(b.out, locale, html)
+	}
+	return outputs
+}
+
+// renderPage, sayfayı her dil için render edip çıktı dosyalarına yazar.
+func (b *builder) renderPage(page string, outputs []string) error {
+	locales := b.locales
+	if len(locales) == 0 {
+		locales = []string{""}
+	}
+	for i, locale := range locales {
+		rel, _ := filepath.Rel(b.out, outputs[i])
+		out, err := b.render(page, locale, "/"+filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+		if err := writeFile(outputs[i], []byte(out)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// render, sayfayı yanındaki veri dosyası ve front matter ile (front matter öncelikli) render eder.
+// Front matter'da layout verilmişse sayfa RenderWithLayout ile render edilir. Context'e sayfa
+// bilgisi "page" olarak eklenir: page.path, page.url, page.locale.
+func (b *builder) render(page, locale, url string) (string, error) {
+	path := filepath.Join(b.src, page)
+	ctx := map[string]interface{}{}
+	if file := b.data[page]; file != "" {
+		loaded, err := data.Load(filepath.Join(b.src, file))
+		if err != nil {
+			return "", err
+		}
+		ctx = loaded
+	}
+	fm, err := b.engine.FrontMatter(path)
+	if err != nil {
+		return "", err
+	}
+	for k, v := range fm {
+		ctx[k] = v
+	}
+	ctx["page"] = map[string]interface{}{
+		"path":   filepath.ToSlash(page),
+		"url":    url,
+		"locale": locale,
+	}
+	// Boş locale engine'in varsayılan diline döner; önceki render'ın dili taşınmaz
+	b.engine.SetLocale(locale)
+	if layout, _ := fm["layout"].(string); layout != "" {
+		return b.engine.RenderWithLayout(path, filepath.Join(b.src, layout), ctx)
+	}
+	return b.engine.RenderFile(path, ctx)
+}
+
+func (b *builder) loadManifest() buildManifest {
+	var m buildManifest
+	if content, err := os.ReadFile(filepath.Join(b.out, manifestFile)); err == nil {
+		json.Unmarshal(content, &m)
+	}
+	return m
+}
+
+func (b *builder) saveManifest(m buildManifest) error {
+	content, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(b.out, manifestFile), content)
+}
+
+func fileHash(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func exists(paths ...string) bool {
+	for _, p := range paths {
+		if _, err := os.Stat(p); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// writeFile, gerekirse dizinleri oluşturarak dosyayı yazar.
+func writeFile(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0644)
+}
+
+func copyFile(src, dst string) error {
+	content, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return writeFile(dst, content)
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		if err := writeFile(filepath.Join(dir, name), []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestBuildIncremental(t *testing.T) {
+	dir := t.TempDir()
+	src, out, shared := filepath.Join(dir, "src"), filepath.Join(dir, "out"), filepath.Join(dir, "shared")
+	writeFiles(t, dir, map[string]string{
+		"src/index.hipo":        `<template>{{ include "_header.hipo" }}{{ title }}</template>`,
+		"src/index.json":        `{"title": "Ana"}`,
+		"src/about.hipo":        "---\nlayout: _layout.hipo\n---\n<template>{{ block content }}Hakkında{{ endblock }}</template>",
+		"src/card.hipo":         `<template>{{ include "card_body.hipo" }}</template>`,
+		"src/_header.hipo":      `<template><h1>Başlık</h1></template>`,
+		"src/_layout.hipo":      `<template><main>{{ block content }}{{ endblock }}</main></template>`,
+		"shared/card_body.hipo": `<template><div>kart</div></template>`,
+	})
+	pages := []string{"index", "about", "card"}
+	build := func() {
+		t.Helper()
+		if code := runBuild([]string{"-path", shared, src, out}); code != 0 {
+			t.Fatalf("hipo build çıkış kodu: %d", code)
+		}
+	}
+	// stale, çıktıları işaretler; bir sonraki build'de render edilmeyen sayfalar işaretli kalır
+	stale := func() {
+		t.Helper()
+		for _, page := range pages {
+			writeFiles(t, out, map[string]string{page + ".html": "STALE"})
+		}
+	}
+	rebuilt := func(edit, content string, want ...string) {
+		t.Helper()
+		stale()
+		writeFiles(t, dir, map[string]string{edit: content})
+		build()
+		for _, page := range pages {
+			got, err := os.ReadFile(filepath.Join(out, page+".html"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := false
+			for _, w := range want {
+				expected = expected || w == page
+			}
+			if rendered := string(got) != "STALE"; rendered != expected {
+				t.Errorf("%s düzenlendi: %s render edildi mi = %v, beklenen %v", edit, page, rendered, expected)
+			}
+		}
+	}
+
+	build()
+	if got, _ := os.ReadFile(filepath.Join(out, "index.html")); !strings.Contains(string(got), "Ana") {
+		t.Fatalf("index.html veri dosyasını kullanmalı: %q", got)
+	}
+	rebuilt("src/_header.hipo", `<template><h1>Yeni</h1></template>`, "index")
+	rebuilt("src/index.json", `{"title": "Yeni"}`, "index")
+	rebuilt("src/_layout.hipo", `<template><section>{{ block content }}{{ endblock }}</section></template>`, "about")
+	rebuilt("shared/card_body.hipo", `<template><div>yeni kart</div></template>`, "card")
+	if got, _ := os.ReadFile(filepath.Join(out, "card.html")); !strings.Contains(string(got), "yeni kart") {
+		t.Errorf("card.html güncellenmedi: %q", got)
+	}
+	rebuilt("src/about.hipo", "---\nlayout: _layout.hipo\n---\n<template>{{ block content }}Biz{{ endblock }}</template>", "about")
+
+	stale()
+	build()
+	if got, _ := os.ReadFile(filepath.Join(out, "index.html")); string(got) != "STALE" {
+		t.Errorf("Değişiklik yokken sayfa yeniden render edilmemeli: %q", got)
+	}
+
+	os.Remove(filepath.Join(src, "about.hipo"))
+	build()
+	if exists(filepath.Join(out, "about.html")) {
+		t.Error("Kaynağı silinen sayfanın çıktısı temizlenmeli")
+	}
+}
+
+func TestBuildLocalesFromCatalogs(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"src/index.hipo": `<template>{{ page.locale }}</template>`,
+		"locale/tr.json": `{"hello": "Merhaba"}`,
+		"locale/en.json": `{"hello": "Hello"}`,
+	})
+	out := filepath.Join(dir, "out")
+	if code := runBuild([]string{"-i18n", filepath.Join(dir, "locale"), filepath.Join(dir, "src"), out}); code != 0 {
+		t.Fatalf("hipo build çıkış kodu: %d", code)
+	}
+	for _, locale := range []string{"en", "tr"} {
+		got, err := os.ReadFile(filepath.Join(out, locale, "index.html"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.TrimSpace(string(got)) != locale {
+			t.Errorf("%s/index.html: %q", locale, got)
+		}
+	}
+	if exists(filepath.Join(out, "index.html")) {
+		t.Error("-i18n verildiğinde dilsiz çıktı üretilmemeli")
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"hipoengine"
+)
+
+func init() {
+	commands = append(commands, command{name: "compile", usage: "template'leri Go kaynak koduna derler", run: runCompile})
+}
+
+func runCompile(args []string) int {
+	fs := flag.NewFlagSet("compile", flag.ExitOnError)
+	pkg := fs.String("pkg", "templates", "üretilecek Go paketinin adı")
+	importPath := fs.String("import", "hipoengine", "hipoengine paketinin import yolu")
+	out := fs.String("o", "", "çıktı dosyası (boşsa stdout)")
+	var ef engineFlags
+	ef.register(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Kullanım: hipo compile [-pkg ad] [-import yol] [-o dosya] [-path dizin]... [-alias ad=yol]... dosya.hipo...")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	engine, err := ef.engine()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	src, err := engine.CompileGo(fs.Args(), hipoengine.CompileOptions{Package: *pkg, ImportPath: *importPath})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if *out == "" {
+		os.Stdout.Write(src)
+		return 0
+	}
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"hipoengine"
+)
+
+func init() {
+	commands = append(commands, command{name: "fmt", usage: "template'leri kanonik biçimde formatlar", run: runFmt})
+}
+
+// runFmt, -check ile formatlanmamış dosya varsa 1, parse veya okuma hatasında 2 döndürür.
+func runFmt(args []string) int {
+	fs := flag.NewFlagSet("fmt", flag.ExitOnError)
+	write := fs.Bool("w", false, "sonucu dosyaya yaz")
+	list := fs.Bool("l", false, "formatı farklı olan dosyaları listele")
+	check := fs.Bool("check", false, "formatlanmamış dosya varsa listele ve 1 ile çık")
+	indent := fs.String("indent", "    ", "blok gövdeleri için girinti birimi")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Kullanım: hipo fmt [-w] [-l] [-check] [-indent str] dosya.hipo|dizin...")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	files, err := templateFiles(fs.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	engine := hipoengine.NewEngine()
+	code := 0
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		out, err := engine.Format(string(src), file, hipoengine.FormatOptions{Indent: *indent})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			code = 2
+			continue
+		}
+		changed := out != string(src)
+		switch {
+		case *check || *list:
+			if changed {
+				fmt.Println(file)
+				if *check && code == 0 {
+					code = 1
+				}
+			}
+		case *write:
+			if changed {
+				if err := os.WriteFile(file, []byte(out), 0644); err != nil {
+					fmt.Fprintln(os.Stderr, err)
+					return 2
+				}
+			}
+		default:
+			fmt.Print(out)
+		}
+	}
+	return code
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"hipoengine"
+	"hipoengine/internal/data"
+)
+
+func init() {
+	commands = append(commands, command{name: "i18n", usage: "çeviri anahtarlarını toplar ve katalogları kontrol eder (extract)", run: runI18n})
+}
+
+func runI18n(args []string) int {
+	if len(args) == 0 || args[0] != "extract" {
+		fmt.Fprintln(os.Stderr, "Kullanım: hipo i18n extract [flag'ler] dosya.hipo|dizin...")
+		return 2
+	}
+	return runI18nExtract(args[1:])
+}
+
+// runI18nExtract, eksik çeviri veya çoğul form bulunursa 1 (-strict ile kullanılmayan
+// anahtarlarda da 1), kullanım veya okuma hatasında 2 döndürür. -write ile eksikler yazıldıktan
+// sonra yalnızca kullanılmayan anahtarlar kalabilir.
+func runI18nExtract(args []string) int {
+	fs := flag.NewFlagSet("i18n extract", flag.ExitOnError)
+	dir := fs.String("i18n", "", "çeviri JSON dosyalarının dizini (SetTranslationsFromDir); verilmezse yalnızca anahtarlar listelenir")
+	locales := fs.String("locales", "", "dizinde dosyası olmasa da kontrol edilecek diller (virgülle ayrılmış)")
+	write := fs.Bool("write", false, "eksik anahtarlar ve çoğul formlar için kataloglara yer tutucu yaz")
+	asJSON := fs.Bool("json", false, "sonuçları JSON olarak yaz")
+	strict := fs.Bool("strict", false, "kullanılmayan anahtarlarda da sıfırdan farklı çıkış kodu döndür")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Kullanım: hipo i18n extract [-i18n dizin] [-locales tr,en] [-write] [-json] [-strict] dosya.hipo|dizin...")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() == 0 || (*write && *dir == "") {
+		fs.Usage()
+		return 2
+	}
+	files, err := templateFiles(fs.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	engine := hipoengine.NewEngine()
+	keys := []hipoengine.TransKey{}
+	for _, file := range files {
+		found, err := engine.ExtractTransKeys(file)
+		if err != nil {
+			printError(engine, err)
+			return 2
+		}
+		keys = append(keys, found...)
+	}
+	if *dir == "" {
+		if *asJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			enc.Encode(keys)
+			return 0
+		}
+		for _, k := range keys {
+			plural := ""
+			if k.Plural {
+				plural = " (çoğul)"
+			}
+			fmt.Printf("%s:%d:%d: %s%s\n", k.File, k.Line, k.Column, k.Key, plural)
+		}
+		return 0
+	}
+	translations, err := loadCatalogs(*dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	for _, lang := range splitNames(*locales) {
+		if _, ok := translations[lang]; !ok {
+			translations[lang] = map[string]interface{}{}
+		}
+	}
+	issues := hipoengine.CheckTranslations(keys, translations)
+	if *write {
+		for _, lang := range hipoengine.StubTranslations(translations, issues) {
+			file := filepath.Join(*dir, lang+".json")
+			if err := writeCatalog(file, translations[lang]); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				return 2
+			}
+			fmt.Fprintf(os.Stderr, "%s güncellendi\n", file)
+		}
+		issues = hipoengine.CheckTranslations(keys, translations)
+	}
+	if issues == nil {
+		issues = []hipoengine.TransIssue{}
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(issues)
+	} else {
+		for _, issue := range issues {
+			fmt.Println(issue)
+		}
+	}
+	for _, issue := range issues {
+		if issue.Kind != hipoengine.TransUnused || *strict {
+			return 1
+		}
+	}
+	return 0
+}
+
+// loadCatalogs, dizindeki <dil>.json dosyalarını SetTranslationsFromDir ile aynı biçimde okur.
+func loadCatalogs(dir string) (map[string]interface{}, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	translations := map[string]interface{}{}
+	for _, file := range files {
+		catalog, err := data.Load(file)
+		if err != nil {
+			return nil, err
+		}
+		translations[strings.TrimSuffix(filepath.Base(file), ".json")] = catalog
+	}
+	return translations, nil
+}
+
+// catalogLocales, dizindeki <dil>.json çeviri dosyalarının dillerini sıralı döndürür.
+func catalogLocales(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	locales := make([]string, 0, len(files))
+	for _, file := range files {
+		locales = append(locales, strings.TrimSuffix(filepath.Base(file), ".json"))
+	}
+	sort.Strings(locales)
+	return locales, nil
+}
+
+// writeCatalog, kataloğu anahtarları sıralı, iki boşluk girintili JSON olarak yazar. HTML
+// karakterleri (<, >, &) kaçırılmaz; çevirilerdeki {{ ... }} ifadeleri olduğu gibi kalır.
+func writeCatalog(file string, catalog interface{}) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(catalog); err != nil {
+		return err
+	}
+	return writeFile(file, buf.Bytes())
+}
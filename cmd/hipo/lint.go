@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"hipoengine"
+)
+
+func init() {
+	commands = append(commands, command{name: "lint", usage: "template'leri statik olarak kontrol eder", run: runLint})
+}
+
+// runLint, hata bulunursa 1 (-strict ile uyarılarda da 1), kullanım veya okuma hatasında 2 döndürür.
+func runLint(args []string) int {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "sonuçları JSON olarak yaz")
+	strict := fs.Bool("strict", false, "uyarılarda da sıfırdan farklı çıkış kodu döndür")
+	funcs := fs.String("funcs", "", "uygulamanın kaydettiği ek fonksiyon adları (virgülle ayrılmış)")
+	filters := fs.String("filters", "", "uygulamanın kaydettiği ek filtre adları (virgülle ayrılmış)")
+	var ef engineFlags
+	ef.register(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Kullanım: hipo lint [-json] [-strict] [-funcs a,b] [-filters a,b] [-path dizin]... [-alias ad=yol]... dosya.hipo|dizin...")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	engine, err := ef.engine()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	for _, name := range splitNames(*funcs) {
+		engine.RegisterFunction(name, func(args ...interface{}) interface{} { return nil })
+	}
+	for _, name := range splitNames(*filters) {
+		engine.RegisterFilter(name, func(val interface{}, args ...interface{}) interface{} { return val })
+	}
+	files, err := templateFiles(fs.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	issues := []hipoengine.LintIssue{}
+	for _, file := range files {
+		found, err := engine.Lint(file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		issues = append(issues, found...)
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(issues)
+	} else {
+		for _, issue := range issues {
+			fmt.Println(issue)
+		}
+	}
+	for _, issue := range issues {
+		if issue.Severity == hipoengine.SeverityError || *strict {
+			return 1
+		}
+	}
+	return 0
+}
+
+func splitNames(s string) []string {
+	var names []string
+	for _, n := range strings.Split(s, ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			names = append(names, n)
+		}
+	}
+	return names
+}
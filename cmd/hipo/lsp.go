@@ -0,0 +1,35 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"hipoengine/lsp"
+)
+
+func init() {
+	commands = append(commands, command{name: "lsp", usage: "editörler için dil sunucusunu (stdio) başlatır", run: runLSP})
+}
+
+// runLSP, istemci shutdown ile kapattığında 0, bağlantı veya protokol hatasında 1 döndürür.
+func runLSP(args []string) int {
+	fs := flag.NewFlagSet("lsp", flag.ExitOnError)
+	var ef engineFlags
+	ef.register(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Kullanım: hipo lsp [-path dizin]... [-alias ad=yol]...")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	engine, err := ef.engine()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	if err := lsp.NewServer(engine).Serve(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,179 @@
+// hipo, HipoEngine komut satırı aracıdır.
+//
+//	hipo compile -pkg views -o views/templates_gen.go -path templates index.hipo
+//	hipo render -path templates -locale tr -o out/index.html index.hipo data.yaml
+//	hipo build -locales tr,en -i18n locale src/ public/
+//	hipo serve -addr :3000 -i18n locale src/
+//	hipo lint -path templates -json templates/
+//	hipo test -i18n locale -path templates templates/
+//	hipo test -cover -coverhtml coverage.html templates/
+//	hipo i18n extract -i18n locale -write templates/
+//	hipo lsp -path templates
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"hipoengine"
+)
+
+// command, bir hipo alt komutudur.
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) int
+}
+
+var commands []command
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] == "-h" || os.Args[1] == "help" {
+		usage()
+		os.Exit(2)
+	}
+	for _, cmd := range commands {
+		if cmd.name == os.Args[1] {
+			os.Exit(cmd.run(os.Args[2:]))
+		}
+	}
+	fmt.Fprintf(os.Stderr, "hipo: bilinmeyen komut %q\n\n", os.Args[1])
+	usage()
+	os.Exit(2)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Kullanım: hipo <komut> [argümanlar]")
+	fmt.Fprintln(os.Stderr, "\nKomutlar:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.name, cmd.usage)
+	}
+}
+
+// stringList, tekrarlanabilen string flag'idir (ör: -path a -path b).
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringList) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+// engineFlags, template arama yolu ve alias'larını ayarlayan ortak flag'lerdir.
+type engineFlags struct {
+	paths   stringList
+	aliases stringList
+}
+
+func (f *engineFlags) register(fs *flag.FlagSet) {
+	fs.Var(&f.paths, "path", "template arama yolu (tekrarlanabilir)")
+	fs.Var(&f.aliases, "alias", "template alias'ı, ad=yol (tekrarlanabilir)")
+}
+
+// engine, flag'lere göre yapılandırılmış yeni bir engine döndürür.
+func (f *engineFlags) engine() (*hipoengine.Engine, error) {
+	engine := hipoengine.NewEngine()
+	for _, p := range f.paths {
+		engine.AddTemplatePath(p)
+	}
+	for _, a := range f.aliases {
+		eq := strings.Index(a, "=")
+		if eq <= 0 {
+			return nil, fmt.Errorf("geçersiz alias %q, ad=yol bekleniyordu", a)
+		}
+		engine.SetTemplateAlias(a[:eq], a[eq+1:])
+	}
+	return engine, nil
+}
+
+// templateFiles, argümanlardaki dizinleri içlerindeki .hipo dosyalarıyla değiştirir.
+func templateFiles(args []string) ([]string, error) {
+	var files []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+		err = filepath.WalkDir(arg, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && strings.HasSuffix(path, ".hipo") {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// printError, hatayı stderr'e yazar. Template hataları için her tanılama dosya:satır:sütun
+// biçiminde ve hatalı satırın çevresiyle gösterilir.
+func printError(engine *hipoengine.Engine, err error) {
+	errs := hipoengine.TemplateErrors(err)
+	if len(errs) == 0 || errs[0].Line == 0 {
+		fmt.Fprintln(os.Stderr, "hata:", err)
+		return
+	}
+	for _, te := range errs {
+		fmt.Fprintf(os.Stderr, "%s:%d:%d: %s\n", te.File, te.Line, te.Column, te.Message)
+		fmt.Fprint(os.Stderr, errorSnippet(engine, te))
+	}
+}
+
+// errorSnippet, hatalı satırı bir önceki ve sonraki satırla birlikte, sütunu işaretleyerek döndürür.
+// Kaynak okunamazsa boş string döner.
+func errorSnippet(engine *hipoengine.Engine, te *hipoengine.TemplateError) string {
+	path, err := engine.ResolveTemplatePath(te.File)
+	if err != nil {
+		return ""
+	}
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	lines := strings.Split(string(src), "\n")
+	if te.Line > len(lines) {
+		return ""
+	}
+	var sb strings.Builder
+	for n := te.Line - 1; n <= te.Line+1; n++ {
+		if n < 1 || n > len(lines) {
+			continue
+		}
+		marker := " "
+		if n == te.Line {
+			marker = ">"
+		}
+		line := strings.TrimRight(lines[n-1], "\r")
+		fmt.Fprintf(&sb, "%s %4d | %s\n", marker, n, line)
+		if n == te.Line && te.Column > 0 {
+			// Tab'lar korunur ki işaret doğru sütunun altına gelsin
+			pad := []rune(line)
+			if te.Column-1 < len(pad) {
+				pad = pad[:te.Column-1]
+			}
+			for i, r := range pad {
+				if r != '\t' {
+					pad[i] = ' '
+				}
+			}
+			fmt.Fprintf(&sb, "       | %s^\n", string(pad))
+		}
+	}
+	return sb.String()
+}
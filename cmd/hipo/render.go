@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"hipoengine"
+	"hipoengine/internal/data"
+)
+
+func init() {
+	commands = append(commands, command{name: "render", usage: "template'i bir context dosyasıyla render eder", run: runRender})
+}
+
+// runRender, render veya (-strict ile) lint hatasında 1, kullanım veya okuma hatasında 2 döndürür.
+func runRender(args []string) int {
+	fs := flag.NewFlagSet("render", flag.ExitOnError)
+	output := fs.String("o", "", "çıktı dosyası (varsayılan: stdout)")
+	locale := fs.String("locale", "", "aktif dil (ör: tr)")
+	i18n := fs.String("i18n", "", "çeviri JSON dosyalarının dizini (SetTranslationsFromDir)")
+	strict := fs.Bool("strict", false, "strict mode; render öncesi lint hatalarında dur")
+	safe := fs.Bool("safe", false, "safe mode (sandbox)")
+	minify := fs.Bool("minify", true, "çıktıyı MinifyHTML ile sadeleştir")
+	var ef engineFlags
+	ef.register(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Kullanım: hipo render [-o dosya] [-locale dil] [-i18n dizin] [-strict] [-safe] [-minify=false] [-path dizin]... [-alias ad=yol]... template.hipo [context.json|.yaml|.toml]")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		fs.Usage()
+		return 2
+	}
+	engine, err := ef.engine()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	engine.SetStrictMode(*strict)
+	engine.SetSafeMode(*safe)
+	engine.SetMinifyMode(*minify)
+	if *i18n != "" {
+		if err := engine.SetTranslationsFromDir(*i18n); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+	}
+	if *locale != "" {
+		engine.SetLocale(*locale)
+	}
+	ctx := map[string]interface{}{}
+	if fs.NArg() == 2 {
+		if ctx, err = data.Load(fs.Arg(1)); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+	}
+
+	file := fs.Arg(0)
+	if *strict {
+		issues, err := engine.Lint(file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		failed := false
+		for _, issue := range issues {
+			if issue.Severity == hipoengine.SeverityError {
+				fmt.Fprintln(os.Stderr, issue)
+				failed = true
+			}
+		}
+		if failed {
+			return 1
+		}
+	}
+	out, err := engine.RenderFile(file, ctx)
+	if err != nil {
+		printError(engine, err)
+		return 1
+	}
+	if *output == "" {
+		fmt.Print(out)
+		return 0
+	}
+	if err := writeFile(*output, []byte(out)); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	return 0
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"html"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"hipoengine"
+)
+
+func init() {
+	commands = append(commands, command{name: "serve", usage: "dizindeki sayfaları canlı yenileme ile HTTP üzerinden sunar", run: runServe})
+}
+
+// reloadPath, canlı yenileme script'inin bağlandığı server-sent events adresidir.
+const reloadPath = "/__hipo/reload"
+
+const reloadScript = `<script>(function(){var es=new EventSource("` + reloadPath + `");es.onmessage=function(){location.reload()};})();</script>`
+
+// devServer, bir dizindeki sayfaları her istekte hipo build ile aynı kurallarla (veri dosyası,
+// front matter, layout) render eder ve dosya değişikliklerinde tarayıcıları yeniler.
+type devServer struct {
+	b      *builder
+	locale string
+	debug  bool
+	watch  []string // değişiklikleri izlenen dizinler
+
+	mu sync.Mutex // render, cache temizleme ve tarama sırayla yapılır (engine locale'i paylaşılır)
+
+	clientsMu sync.Mutex
+	clients   map[chan struct{}]bool
+}
+
+// runServe, sunucu başlatılamazsa 1, kullanım veya okuma hatasında 2 döndürür.
+func runServe(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:8080", "dinlenecek adres")
+	locale := fs.String("locale", "", "varsayılan dil (istekte ?locale=en ile değiştirilebilir)")
+	i18n := fs.String("i18n", "", "çeviri JSON dosyalarının dizini (SetTranslationsFromDir)")
+	interval := fs.Duration("interval", 500*time.Millisecond, "dosya değişikliklerini kontrol etme aralığı")
+	debug := fs.Bool("debug", true, "debug mode; sayfalara canlı yenileme script'i eklenir")
+	var ef engineFlags
+	ef.register(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Kullanım: hipo serve [-addr host:port] [-locale dil] [-i18n dizin] [-interval süre] [-debug=false] [-path dizin]... [-alias ad=yol]... dizin")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	engine, err := ef.engine()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	engine.SetDebugMode(*debug)
+	b := &builder{engine: engine, src: filepath.Clean(fs.Arg(0)), i18n: *i18n}
+	engine.AddTemplatePath(b.src)
+	if b.i18n != "" {
+		if err := engine.SetTranslationsFromDir(b.i18n); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+	}
+	if err := b.scan(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	s := &devServer{
+		b:       b,
+		locale:  *locale,
+		debug:   *debug,
+		watch:   append([]string{b.src, b.i18n}, ef.paths...),
+		clients: make(map[chan struct{}]bool),
+	}
+	go s.watchFiles(*interval)
+	fmt.Fprintf(os.Stderr, "hipo serve: %s dizini http://%s adresinde sunuluyor\n", b.src, *addr)
+	if err := http.ListenAndServe(*addr, s); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+func (s *devServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == reloadPath {
+		s.events(w, r)
+		return
+	}
+	rel := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	if hidden(rel) {
+		http.NotFound(w, r)
+		return
+	}
+	if page, ok := s.page(rel); ok {
+		s.renderPage(w, r, page)
+		return
+	}
+	file := filepath.Join(s.b.src, filepath.FromSlash(rel))
+	s.mu.Lock()
+	source := s.isSource(file)
+	s.mu.Unlock()
+	if info, err := os.Stat(file); err == nil && !info.IsDir() && !source {
+		http.ServeFile(w, r, file)
+		return
+	}
+	http.NotFound(w, r)
+}
+
+// page, URL yolunu sayfaya çevirir: "" → index.hipo, "about" veya "about.html" → about.hipo,
+// "blog" → blog.hipo veya blog/index.hipo.
+func (s *devServer) page(rel string) (string, bool) {
+	var candidates []string
+	switch {
+	case rel == "":
+		candidates = []string{"index.hipo"}
+	case strings.HasSuffix(rel, ".html"):
+		candidates = []string{strings.TrimSuffix(rel, ".html") + ".hipo"}
+	case path.Ext(rel) == "":
+		candidates = []string{rel + ".hipo", rel + "/index.hipo"}
+	}
+	for _, c := range candidates {
+		page := filepath.FromSlash(c)
+		if info, err := os.Stat(filepath.Join(s.b.src, page)); err == nil && !info.IsDir() {
+			return page, true
+		}
+	}
+	return "", false
+}
+
+// isSource, dosyanın sayfa veya sayfa veri dosyası olup olmadığını döndürür (bunlar ham sunulmaz).
+func (s *devServer) isSource(file string) bool {
+	if strings.HasSuffix(file, ".hipo") {
+		return true
+	}
+	for _, data := range s.b.data {
+		if filepath.Join(s.b.src, data) == file {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *devServer) renderPage(w http.ResponseWriter, r *http.Request, page string) {
+	locale := s.locale
+	if l := r.URL.Query().Get("locale"); l != "" {
+		locale = l
+	}
+	s.mu.Lock()
+	out, err := s.b.render(page, locale, r.URL.Path)
+	s.mu.Unlock()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	if err != nil {
+		err = fmt.Errorf("%s: %w", filepath.Join(s.b.src, page), err)
+		printError(s.b.engine, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		out = s.errorPage(err)
+	}
+	if s.debug {
+		out = injectReload(out)
+	}
+	io.WriteString(w, out)
+}
+
+// errorPage, render hatasını dosya konumu ve kaynak satırlarıyla gösteren bir HTML sayfası döndürür.
+func (s *devServer) errorPage(err error) string {
+	var sb strings.Builder
+	sb.WriteString(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>hipo: render hatası</title>
+<style>
+body { margin: 0; background: #1e1e1e; color: #eee; font: 14px/1.5 ui-monospace, Menlo, Consolas, monospace; }
+.hipo-error { max-width: 960px; margin: 40px auto; padding: 24px; border-top: 4px solid #e5534b; background: #2b2b2b; }
+.hipo-error h1 { margin: 0 0 8px; font-size: 18px; color: #e5534b; }
+.hipo-error .location { color: #9cdcfe; }
+.hipo-error pre { white-space: pre-wrap; margin: 16px 0 0; padding: 12px; background: #1e1e1e; overflow-x: auto; }
+</style></head>
+<body><div class="hipo-error">
+<h1>Render hatası</h1>
+`)
+	if errs := hipoengine.TemplateErrors(err); len(errs) > 0 && errs[0].Line > 0 {
+		for _, te := range errs {
+			fmt.Fprintf(&sb, "<div class=\"location\">%s:%d:%d</div>\n<pre>%s</pre>\n", html.EscapeString(te.File), te.Line, te.Column, html.EscapeString(te.Message))
+			if snippet := errorSnippet(s.b.engine, te); snippet != "" {
+				fmt.Fprintf(&sb, "<pre>%s</pre>\n", html.EscapeString(snippet))
+			}
+		}
+	} else {
+		fmt.Fprintf(&sb, "<pre>%s</pre>\n", html.EscapeString(err.Error()))
+	}
+	sb.WriteString("</div></body></html>\n")
+	return sb.String()
+}
+
+// injectReload, canlı yenileme script'ini </body>'den önce, yoksa sayfanın sonuna ekler.
+func injectReload(page string) string {
+	if i := strings.LastIndex(strings.ToLower(page), "</body>"); i != -1 {
+		return page[:i] + reloadScript + page[i:]
+	}
+	return page + reloadScript
+}
+
+// events, tarayıcıya dosya değişikliklerini server-sent events ile bildirir.
+func (s *devServer) events(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming desteklenmiyor", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	ch := make(chan struct{}, 1)
+	s.clientsMu.Lock()
+	s.clients[ch] = true
+	s.clientsMu.Unlock()
+	defer func() {
+		s.clientsMu.Lock()
+		delete(s.clients, ch)
+		s.clientsMu.Unlock()
+	}()
+	io.WriteString(w, ": hipo\n\n")
+	flusher.Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ch:
+			io.WriteString(w, "data: reload\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+func (s *devServer) notify() {
+	s.clientsMu.Lock()
+	defer s.clientsMu.Unlock()
+	for ch := range s.clients {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// watchFiles, izlenen dizinleri aralıklarla tarar; bir dosya eklenir, silinir veya değişirse
+// engine cache'lerini temizler, çevirileri yeniden yükler ve tarayıcıları yeniler.
+func (s *devServer) watchFiles(interval time.Duration) {
+	prev := s.snapshot()
+	for range time.Tick(interval) {
+		cur := s.snapshot()
+		if sameSnapshot(prev, cur) {
+			continue
+		}
+		prev = cur
+		s.mu.Lock()
+		s.b.engine.ClearCache()
+		if s.b.i18n != "" {
+			s.b.engine.SetTranslations(nil)
+			if err := s.b.engine.SetTranslationsFromDir(s.b.i18n); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+		}
+		if err := s.b.scan(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		s.mu.Unlock()
+		fmt.Fprintln(os.Stderr, "hipo serve: değişiklik algılandı, sayfalar yenileniyor")
+		s.notify()
+	}
+}
+
+// snapshot, izlenen dosyaların değişiklik zamanı ve boyutunu döndürür.
+func (s *devServer) snapshot() map[string]string {
+	files := make(map[string]string)
+	for _, dir := range s.watch {
+		if dir == "" {
+			continue
+		}
+		filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			if info, err := d.Info(); err == nil {
+				files[p] = fmt.Sprint(info.ModTime().UnixNano(), info.Size())
+			}
+			return nil
+		})
+	}
+	return files
+}
+
+func sameSnapshot(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"hipoengine"
+)
+
+func newTestServer(t *testing.T, files map[string]string) *devServer {
+	t.Helper()
+	dir := t.TempDir()
+	writeFiles(t, dir, files)
+	engine := hipoengine.NewEngine()
+	b := &builder{engine: engine, src: filepath.Join(dir, "src"), i18n: filepath.Join(dir, "locale")}
+	engine.AddTemplatePath(b.src)
+	if err := engine.SetTranslationsFromDir(b.i18n); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.scan(); err != nil {
+		t.Fatal(err)
+	}
+	return &devServer{b: b}
+}
+
+func TestServePage(t *testing.T) {
+	s := newTestServer(t, map[string]string{
+		"src/index.hipo":      `<template>ana</template>`,
+		"src/about.hipo":      `<template>hakkında</template>`,
+		"src/blog/index.hipo": `<template>blog</template>`,
+		"src/blog/post.hipo":  `<template>yazı</template>`,
+		"src/style.css":       `body {}`,
+	})
+	tests := map[string]string{
+		"":               "index.hipo",
+		"about":          "about.hipo",
+		"about.html":     "about.hipo",
+		"blog":           filepath.Join("blog", "index.hipo"),
+		"blog/post":      filepath.Join("blog", "post.hipo"),
+		"blog/post.html": filepath.Join("blog", "post.hipo"),
+	}
+	for rel, want := range tests {
+		if got, ok := s.page(rel); !ok || got != want {
+			t.Errorf("page(%q) = %q, %v; beklenen %q", rel, got, ok, want)
+		}
+	}
+	for _, rel := range []string{"missing", "style.css", "about.hipo", "blog/missing.html"} {
+		if got, ok := s.page(rel); ok {
+			t.Errorf("page(%q) sayfa bulmamalı, gelen %q", rel, got)
+		}
+	}
+}
+
+func TestInjectReload(t *testing.T) {
+	got := injectReload("<html><BODY>x</BODY></html>")
+	if want := "<html><BODY>x" + reloadScript + "</BODY></html>"; got != want {
+		t.Errorf("Script </body>'den önce eklenmeli: %q", got)
+	}
+	if got := injectReload("x"); got != "x"+reloadScript {
+		t.Errorf("</body> yoksa script sona eklenmeli: %q", got)
+	}
+}
+
+func TestServeResetsLocale(t *testing.T) {
+	s := newTestServer(t, map[string]string{
+		"src/index.hipo": `<template>{{ trans("hello") }}</template>`,
+		"locale/tr.json": `{"hello": "Merhaba"}`,
+		"locale/en.json": `{"hello": "Hello"}`,
+	})
+	get := func(url string) string {
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, httptest.NewRequest("GET", url, nil))
+		return strings.TrimSpace(rec.Body.String())
+	}
+	want := get("/")
+	if got := get("/?locale=tr"); got != "Merhaba" {
+		t.Errorf("?locale=tr: %q", got)
+	}
+	if got := get("/"); got != want {
+		t.Errorf("locale parametresi olmayan istek varsayılan dile dönmeli: %q, beklenen %q", got, want)
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"hipoengine"
+)
+
+func init() {
+	commands = append(commands, command{name: "test", usage: "template spec dosyalarını (*.test.yaml) çalıştırır", run: runTest})
+}
+
+// runTest, başarısız senaryo varsa 1, kullanım veya spec okuma hatasında 2 döndürür.
+func runTest(args []string) int {
+	fs := flag.NewFlagSet("test", flag.ExitOnError)
+	update := fs.Bool("update", false, "golden dosyalarını gerçek çıktıyla yeniden yaz")
+	run := fs.String("run", "", "yalnızca \"spec/senaryo\" adı bu regexp ile eşleşen senaryoları çalıştır")
+	verbose := fs.Bool("v", false, "başarılı senaryoları da listele")
+	asJSON := fs.Bool("json", false, "sonuçları JSON olarak yaz")
+	i18n := fs.String("i18n", "", "çeviri JSON dosyalarının dizini (SetTranslationsFromDir)")
+	cover := fs.Bool("cover", false, "template coverage'ını toplayıp sonuçlardan sonra metin raporu olarak yaz")
+	coverHTML := fs.String("coverhtml", "", "template coverage'ının HTML raporunu bu dosyaya yaz")
+	var ef engineFlags
+	ef.register(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Kullanım: hipo test [-update] [-run regexp] [-v] [-json] [-cover] [-coverhtml dosya] [-i18n dizin] [-path dizin]... [-alias ad=yol]... [spec|dizin...]")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	var filter *regexp.Regexp
+	if *run != "" {
+		var err error
+		if filter, err = regexp.Compile(*run); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+	}
+	engine, err := ef.engine()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	if *i18n != "" {
+		if err := engine.SetTranslationsFromDir(*i18n); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+	}
+	var coverage *hipoengine.Coverage
+	if *cover || *coverHTML != "" {
+		coverage = engine.EnableCoverage()
+	}
+	paths := fs.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	files, err := hipoengine.FindTestSpecs(paths...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	results := []hipoengine.TestResult{}
+	for _, file := range files {
+		spec, err := hipoengine.LoadTestSpec(file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		if filter != nil {
+			cases := spec.Cases[:0]
+			for _, c := range spec.Cases {
+				if filter.MatchString(file + "/" + c.Name) {
+					cases = append(cases, c)
+				}
+			}
+			spec.Cases = cases
+		}
+		results = append(results, engine.RunTestSpec(spec, hipoengine.TestOptions{Update: *update})...)
+	}
+	failed := 0
+	for _, r := range results {
+		if !r.Passed {
+			failed++
+		}
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(results)
+	} else {
+		for _, r := range results {
+			switch {
+			case !r.Passed:
+				fmt.Printf("--- FAIL: %s/%s\n    %s\n", r.Spec, r.Case, strings.ReplaceAll(r.Message, "\n", "\n    "))
+			case r.Updated:
+				fmt.Printf("--- UPDATE: %s/%s\n", r.Spec, r.Case)
+			case *verbose:
+				fmt.Printf("--- PASS: %s/%s\n", r.Spec, r.Case)
+			}
+		}
+		if failed > 0 {
+			fmt.Printf("FAIL: %d/%d senaryo başarısız\n", failed, len(results))
+		} else {
+			fmt.Printf("ok: %d senaryo, %d spec dosyası\n", len(results), len(files))
+		}
+		if *cover {
+			fmt.Println()
+			coverage.WriteText(os.Stdout)
+		}
+	}
+	if *coverHTML != "" {
+		var buf bytes.Buffer
+		coverage.WriteHTML(&buf)
+		if err := writeFile(*coverHTML, buf.Bytes()); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+	}
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
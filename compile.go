@@ -0,0 +1,800 @@
+// compile.go
+// Template'leri Go kaynak koduna derleyen kod üreteci (hipo compile)
+package hipoengine
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// CompileOptions, CompileGo ile üretilecek Go dosyasının ayarlarıdır.
+type CompileOptions struct {
+	Package    string // üretilen dosyanın paket adı (varsayılan: templates)
+	ImportPath string // hipoengine paketinin import yolu (varsayılan: hipoengine)
+}
+
+// CompileGo, verilen template dosyalarını tek bir Go kaynak dosyasına derler. Her dosya için
+// Engine.RenderFile ile aynı çıktıyı üreten bir Render<Ad>(e, data) fonksiyonu oluşturulur.
+// Metin, değişkenler ve filtre zincirleri, if/elif koşulları, for döngüleri (break/continue ve
+// inline filtre dahil), set, with, switch, block ve macro'lar doğrudan Go koduna çevrilir; değişken
+// path'leri, koşullar ve filtre argümanları derleme anında çözülür. Filtre ve fonksiyonlar çalışma
+// anında verilen engine'in registry'lerinden çözülür, include hedefleri de çalışma anında render
+// edilir. extends, component, slot, props, styles/scripts, recursive for ve özel tag'lar
+// derlenemez; bu durumda hangi yapının desteklenmediğini belirten bir hata döner.
+func (e *Engine) CompileGo(files []string, opts CompileOptions) ([]byte, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("derlenecek template dosyası verilmedi")
+	}
+	if opts.Package == "" {
+		opts.Package = "templates"
+	}
+	if opts.ImportPath == "" {
+		opts.ImportPath = "hipoengine"
+	}
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by hipo compile. DO NOT EDIT.\n\npackage %s\n\n", opts.Package)
+	fmt.Fprintf(&out, "import (\n\t\"strings\"\n\n\thipoengine %q\n)\n\n", opts.ImportPath)
+
+	names := make(map[string]string)
+	var code bytes.Buffer
+	for _, file := range files {
+		ident := compiledIdent(file)
+		if prev, ok := names[ident]; ok {
+			return nil, fmt.Errorf("'%s' ve '%s' aynı fonksiyon adını (Render%s) üretiyor", prev, file, ident)
+		}
+		names[ident] = file
+		src, err := e.compileFile(file, ident)
+		if err != nil {
+			return nil, err
+		}
+		code.WriteString(src)
+	}
+
+	out.WriteString("// Templates, derlenen template dosya adlarını render fonksiyonlarına eşler.\n")
+	out.WriteString("var Templates = map[string]func(e *hipoengine.Engine, data map[string]interface{}) (string, error){\n")
+	idents := make([]string, 0, len(names))
+	for ident := range names {
+		idents = append(idents, ident)
+	}
+	sort.Strings(idents)
+	for _, ident := range idents {
+		fmt.Fprintf(&out, "\t%q: Render%s,\n", names[ident], ident)
+	}
+	out.WriteString("}\n\n")
+	out.Write(code.Bytes())
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("üretilen kod formatlanamadı: %w", err)
+	}
+	return formatted, nil
+}
+
+// compileFile, tek bir template dosyasının Render fonksiyonunu ve derlenmiş gövdesini üretir.
+func (e *Engine) compileFile(file, ident string) (string, error) {
+	content, err := e.ReadFileCached(file)
+	if err != nil {
+		return "", err
+	}
+	desc, err := ParseSFC(content)
+	if err != nil {
+		return "", withFile(err, file)
+	}
+	ast, err := e.templateParser(desc, file, false).Parse()
+	if err != nil {
+		return "", err
+	}
+	c := &goCompiler{body: &strings.Builder{}, ret: `"", err`}
+	c.line("var sb strings.Builder")
+	if err := c.stmt(ast); err != nil {
+		return "", fmt.Errorf("%s derlenemedi: %w", file, err)
+	}
+	c.line("return sb.String(), nil")
+
+	var assets []string
+	for _, b := range fileAssets(desc, file) {
+		lit, err := literal(reflect.ValueOf(&SFCBlock{Type: b.Type, Attrs: b.Attrs, Content: b.Content}))
+		if err != nil {
+			return "", err
+		}
+		assets = append(assets, elideType(lit, reflect.TypeOf(b)))
+	}
+
+	prefix := lowerFirst(ident)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "// Render%s, %q template'inin derlenmiş halidir (Engine.RenderFile ile aynı çıktı).\n", ident, file)
+	fmt.Fprintf(&sb, "func Render%s(e *hipoengine.Engine, data map[string]interface{}) (string, error) {\n", ident)
+	fmt.Fprintf(&sb, "\treturn e.RenderCompiled(%q, data, %sAssets, render%s)\n}\n\n", file, prefix, ident)
+	fmt.Fprintf(&sb, "var %sAssets = []*hipoengine.SFCBlock{%s}\n\n", prefix, strings.Join(assets, ", "))
+	fmt.Fprintf(&sb, "func render%s(ctx *hipoengine.Context) (string, error) {\n", ident)
+	sb.WriteString(c.body.String())
+	sb.WriteString("}\n\n")
+	return sb.String(), nil
+}
+
+// goCompiler, bir template'in gövdesini Go ifadelerine çevirir. Üretilen kodda çıktı sb'ye
+// yazılır, aktif context ctx'tir.
+type goCompiler struct {
+	body    *strings.Builder
+	depth   int
+	ret     string // hata durumunda return edilecek değerler (ör: `"", err`)
+	loops   int    // içinde bulunulan derlenmiş döngü sayısı (break/continue için)
+	usedCtx bool   // üretilen kod ctx'e başvurdu mu
+}
+
+func (c *goCompiler) line(format string, args ...interface{}) {
+	c.body.WriteString(strings.Repeat("\t", c.depth+1))
+	fmt.Fprintf(c.body, format, args...)
+	c.body.WriteString("\n")
+}
+
+// ctx, ctx'e başvuran bir Go ifadesi üretir ve bunu kaydeder.
+func (c *goCompiler) ctx(format string, args ...interface{}) string {
+	c.usedCtx = true
+	return fmt.Sprintf("ctx."+format, args...)
+}
+
+// returnErr, err'i bulunulan fonksiyonun dönüş değerleriyle döndüren satırları üretir.
+func (c *goCompiler) returnErr() {
+	c.line("if err != nil {")
+	c.line("\treturn %s", c.ret)
+	c.line("}")
+}
+
+// stmt, node'u Go koduna çevirir; derlenemeyen node'lar için hata döner.
+func (c *goCompiler) stmt(node ASTNode) error {
+	switch n := node.(type) {
+	case *ListNode:
+		for _, child := range n.Nodes {
+			if err := c.stmt(child); err != nil {
+				return err
+			}
+			if terminates(child) {
+				// break/continue'dan sonraki node'lar hiç çalışmaz
+				return nil
+			}
+		}
+	case *TextNode:
+		c.line("sb.WriteString(%s)", strconv.Quote(n.Text))
+	case *VariableNode:
+		if n.Name == "" && n.Value != nil && len(n.Filters) == 0 {
+			if s, ok := n.Value.(string); !ok || !strings.Contains(s, "(") {
+				// Sabit literal: derleme anında render edilir
+				out, _ := n.Execute(nil)
+				c.line("sb.WriteString(%s)", strconv.Quote(out))
+				return nil
+			}
+		}
+		safe := n.safe()
+		return c.value(n, false, func(expr string) {
+			c.line("sb.WriteString(hipoengine.FormatValue(%s, %t))", expr, safe)
+		})
+	case *IfNode:
+		for i, branch := range n.Branches {
+			cond, err := c.cond(branch.Condition)
+			if err != nil {
+				return err
+			}
+			if i == 0 {
+				c.line("if %s {", cond)
+			} else {
+				c.line("} else if %s {", cond)
+			}
+			if err := c.nested(branch.Body); err != nil {
+				return err
+			}
+		}
+		if n.ElseBody != nil {
+			c.line("} else {")
+			if err := c.nested(n.ElseBody); err != nil {
+				return err
+			}
+		}
+		c.line("}")
+	case *ForNode:
+		return c.loop(n)
+	case *BreakNode, *ContinueNode:
+		if c.loops == 0 {
+			return fmt.Errorf("for döngüsü dışında break/continue derlenemez")
+		}
+		_, brk := n.(*BreakNode)
+		c.line("return sb.String(), %t, %t, nil", brk, !brk)
+	case *SetNode:
+		switch v := n.Value.(type) {
+		case *VariableNode:
+			return c.value(v, true, func(expr string) {
+				c.line("%s", c.ctx("Set(%q, %s)", n.VarName, expr))
+			})
+		case *TextNode:
+			val, _ := v.ExecuteRaw(nil)
+			lit, err := valueLiteral(val)
+			if err != nil {
+				return err
+			}
+			c.line("%s", c.ctx("Set(%q, %s)", n.VarName, lit))
+		default:
+			return fmt.Errorf("{{ set %s }} ifadesi (%T) Go koduna derlenemez", n.VarName, n.Value)
+		}
+	case *WithNode:
+		val := "nil"
+		if n.Expr != "" {
+			val = c.path(splitPathWithBrackets(n.Expr))
+		}
+		return c.scoped(fmt.Sprintf("ctx := ctx.NewChild(map[string]interface{}{%q: %s})", n.Alias, val), n.Body)
+	case *BlockNode:
+		return c.scoped("ctx := ctx.NewChild(map[string]interface{}{})", n.Body)
+	case *SwitchNode:
+		return c.switchStmt(n)
+	case *IncludeNode:
+		pos, err := literal(reflect.ValueOf(n.Pos))
+		if err != nil {
+			return err
+		}
+		c.line("if out, err := %s; err != nil {", c.ctx("Include(%q, %s)", n.File, pos))
+		c.line("\treturn %s", c.ret)
+		c.line("} else {")
+		c.line("\tsb.WriteString(out)")
+		c.line("}")
+	case *MacroNode:
+		return c.macro(n)
+	default:
+		return fmt.Errorf("%s Go koduna derlenemez; bu template Engine.RenderFile ile render edilmeli", unsupportedNode(node))
+	}
+	return nil
+}
+
+// unsupportedNode, Go koduna derlenemeyen node'u hata mesajı için adlandırır.
+func unsupportedNode(node ASTNode) string {
+	switch n := node.(type) {
+	case *ForNode:
+		return "{{ for ... recursive }}"
+	case *ExtendsNode:
+		return "{{ extends }}"
+	case *ComponentNode:
+		return "{{ component }}"
+	case *SlotNode:
+		return "{{ slot }}"
+	case *PropsNode:
+		return "{{ props }}"
+	case *AssetsNode:
+		return "{{ " + n.Kind + " }}"
+	}
+	return fmt.Sprintf("%T node'u (özel tag'lar desteklenmez)", node)
+}
+
+// terminates, node'un her durumda break/continue ile bittiğini (üretilen kodda return ile
+// sonlandığını) bildirir; ardından gelen kod üretilmez.
+func terminates(node ASTNode) bool {
+	switch n := node.(type) {
+	case *BreakNode, *ContinueNode:
+		return true
+	case *ListNode:
+		for _, child := range n.Nodes {
+			if terminates(child) {
+				return true
+			}
+		}
+	case *BlockNode:
+		return terminates(n.Body)
+	case *WithNode:
+		return terminates(n.Body)
+	case *IfNode:
+		if n.ElseBody == nil || !terminates(n.ElseBody) {
+			return false
+		}
+		for _, b := range n.Branches {
+			if !terminates(b.Body) {
+				return false
+			}
+		}
+		return true
+	case *SwitchNode:
+		if n.Default == nil || !terminates(n.Default) {
+			return false
+		}
+		for _, cs := range n.Cases {
+			if !terminates(cs.Body) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func (c *goCompiler) nested(node ASTNode) error {
+	c.depth++
+	err := c.stmt(node)
+	c.depth--
+	return err
+}
+
+// scoped, gövdeyi yeni bir Go bloğunda derler. decl, gövdenin kullanacağı child context'i
+// tanımlar; gövde ctx'e başvurmuyorsa tanım atlanır.
+func (c *goCompiler) scoped(decl string, body ASTNode) error {
+	outer, used := c.body, c.usedCtx
+	c.body, c.usedCtx = &strings.Builder{}, false
+	c.depth++
+	err := c.stmt(body)
+	c.depth--
+	inner, innerUsed := c.body.String(), c.usedCtx
+	c.body, c.usedCtx = outer, used
+	if err != nil {
+		return err
+	}
+	c.line("{")
+	if innerUsed {
+		c.usedCtx = true
+		c.line("\t%s", decl)
+	}
+	c.body.WriteString(inner)
+	c.line("}")
+	return nil
+}
+
+// value, VariableNode'un ham değerini (filtreler uygulanmış) üreten kodu oluşturur ve use ile
+// kullanır. Fonksiyon çağrılarında değer bir if bloğu içinde üretilir; fonksiyon bulunamazsa
+// değer "" olur ve missing false ise use hiç çağrılmaz.
+func (c *goCompiler) value(n *VariableNode, missing bool, use func(expr string)) error {
+	name, args, ok := parseCall(n.Name)
+	if !ok {
+		var base string
+		switch {
+		case n.Value != nil:
+			if s, isStr := n.Value.(string); isStr && strings.Contains(s, "(") {
+				base = c.path(splitPathWithBrackets(s))
+			} else {
+				lit, err := valueLiteral(n.Value)
+				if err != nil {
+					return err
+				}
+				base = lit
+			}
+		case n.Name == "":
+			base = "nil"
+		default:
+			base = c.path(splitPathWithBrackets(n.Name))
+		}
+		expr, err := c.filters(n, base)
+		if err != nil {
+			return err
+		}
+		use(expr)
+		return nil
+	}
+	call := []string{strconv.Quote(name)}
+	for _, a := range args {
+		expr, err := c.operand(a)
+		if err != nil {
+			return err
+		}
+		call = append(call, expr)
+	}
+	expr, err := c.filters(n, "val")
+	if err != nil {
+		return err
+	}
+	c.line("if val, ok, err := %s; err != nil {", c.ctx("Call(%s)", strings.Join(call, ", ")))
+	c.line("\treturn %s", c.ret)
+	c.line("} else if ok {")
+	c.depth++
+	use(expr)
+	c.depth--
+	if missing {
+		c.line("} else {")
+		c.depth++
+		use(`""`)
+		c.depth--
+	}
+	c.line("}")
+	return nil
+}
+
+// filters, base ifadesine filtre zincirini uygulayan Go ifadesini üretir.
+func (c *goCompiler) filters(n *VariableNode, base string) (string, error) {
+	expr := base
+	for _, f := range n.Filters {
+		args := []string{strconv.Quote(f.Name), expr}
+		for _, a := range parseFilterArgs(f.Args) {
+			lit, err := valueLiteral(a)
+			if err != nil {
+				return "", err
+			}
+			args = append(args, lit)
+		}
+		expr = c.ctx("ApplyFilter(%s)", strings.Join(args, ", "))
+	}
+	return expr, nil
+}
+
+// path, önceden bölünmüş path'i context'te çözen Go ifadesini üretir.
+func (c *goCompiler) path(parts []string) string {
+	quoted := make([]string, len(parts))
+	for i, p := range parts {
+		quoted[i] = strconv.Quote(p)
+	}
+	return c.ctx("ResolvePath(%s)", strings.Join(quoted, ", "))
+}
+
+// operand, koşul/argüman operandının değerini üreten Go ifadesini döndürür.
+func (c *goCompiler) operand(o Operand) (string, error) {
+	if o.Path == nil {
+		return valueLiteral(o.Value)
+	}
+	return c.path(o.Path), nil
+}
+
+// cond, koşul ifadesini derleme anında parçalayıp Go bool ifadesine çevirir. Literal operandlar
+// derleme anında dönüştürülür; iki tarafı da literal olan karşılaştırmalar sabite katlanır.
+func (c *goCompiler) cond(expr string) (string, error) {
+	cd := compileCondition(expr)
+	switch cd.op {
+	case "is":
+		args := []string{strconv.Quote(cd.test), strconv.FormatBool(cd.negate)}
+		for _, o := range append([]Operand{cd.left}, cd.args...) {
+			lit, err := literal(reflect.ValueOf(o))
+			if err != nil {
+				return "", err
+			}
+			args = append(args, lit)
+		}
+		return c.ctx("Test(%s)", strings.Join(args, ", ")), nil
+	case "":
+		if cd.left.Path == nil {
+			return strconv.FormatBool(cd.eval(nil)), nil
+		}
+		return "hipoengine.Truthy(" + c.path(cd.left.Path) + ")", nil
+	}
+	if cd.left.Path == nil && cd.right.Path == nil {
+		return strconv.FormatBool(cd.eval(nil)), nil
+	}
+	side := func(o Operand) string {
+		if cd.op == "==" || cd.op == "!=" {
+			if o.Path == nil {
+				return strconv.Quote(AsString(o.Value))
+			}
+			return "hipoengine.AsString(" + c.path(o.Path) + ")"
+		}
+		if o.Path == nil {
+			return strconv.Itoa(AsInt(o.Value))
+		}
+		return "hipoengine.AsInt(" + c.path(o.Path) + ")"
+	}
+	return side(cd.left) + " " + cd.op + " " + side(cd.right), nil
+}
+
+// loop, recursive olmayan for döngüsünü üretir. Her eleman kendi context'inde bir closure ile
+// render edilir; closure break ve continue'yu ayrı bool değerlerle bildirir. Son eleman hariç
+// her elemandan sonra satır sonu eklenir; continue ile çıktı üretmeden kesilen eleman eklemez.
+func (c *goCompiler) loop(n *ForNode) error {
+	if n.Recursive {
+		return fmt.Errorf("%s Go koduna derlenemez; bu template Engine.RenderFile ile render edilmeli", unsupportedNode(n))
+	}
+	coll := splitPathWithBrackets(n.Collection)
+	quoted := []string{strconv.Quote(n.Collection)}
+	for _, p := range coll {
+		quoted = append(quoted, strconv.Quote(p))
+	}
+	c.line("{")
+	c.depth++
+	c.line("items, err := %s", c.ctx("LoopItems(%s)", strings.Join(quoted, ", ")))
+	c.returnErr()
+	if n.Filter != "" {
+		c.usedCtx = false
+		cond, err := c.cond(n.Filter)
+		if err != nil {
+			return err
+		}
+		c.line("kept := items[:0]")
+		c.line("for _, item := range items {")
+		if c.usedCtx {
+			c.line("\tctx := ctx.NewChild(map[string]interface{}{%q: item})", n.VarName)
+		}
+		c.line("\tif %s {", cond)
+		c.line("\t\tkept = append(kept, item)")
+		c.line("\t}")
+		c.line("}")
+		c.line("items = kept")
+	}
+	c.line("for i, item := range items {")
+	c.depth++
+	c.line("out, brk, cont, err := func(ctx *hipoengine.Context) (string, bool, bool, error) {")
+	ret, loops, used := c.ret, c.loops, c.usedCtx
+	c.ret, c.loops = `"", false, false, err`, c.loops+1
+	c.depth++
+	c.line("var sb strings.Builder")
+	err := c.stmt(n.Body)
+	if !terminates(n.Body) {
+		c.line("return sb.String(), false, false, nil")
+	}
+	c.depth--
+	c.ret, c.loops, c.usedCtx = ret, loops, used
+	if err != nil {
+		return err
+	}
+	c.line("}(ctx.NewChild(map[string]interface{}{%q: item, \"loop\": hipoengine.LoopInfo(i, len(items))}))", n.VarName)
+	c.returnErr()
+	c.line("sb.WriteString(out)")
+	c.line("if brk {")
+	c.line("\tbreak")
+	c.line("}")
+	c.line("if i != len(items)-1 && !(cont && out == \"\") {")
+	c.line("\tsb.WriteString(\"\\n\")")
+	c.line("}")
+	c.depth--
+	c.line("}")
+	c.depth--
+	c.line("}")
+	return nil
+}
+
+// switchStmt, switch değerini bir kez hesaplayıp case'leri sırayla Equal ile karşılaştıran kodu üretir.
+func (c *goCompiler) switchStmt(n *SwitchNode) error {
+	c.line("{")
+	c.depth++
+	c.line("var subject interface{}")
+	if err := c.value(parseVariable(n.Subject), true, func(expr string) {
+		c.line("subject = %s", expr)
+	}); err != nil {
+		return err
+	}
+	for i, cs := range n.Cases {
+		var conds []string
+		for _, v := range cs.Values {
+			expr, err := c.operand(compileOperand(v))
+			if err != nil {
+				return err
+			}
+			conds = append(conds, "hipoengine.Equal(subject, "+expr+")")
+		}
+		if len(conds) == 0 {
+			conds = []string{"false"}
+		}
+		if i == 0 {
+			c.line("if %s {", strings.Join(conds, " || "))
+		} else {
+			c.line("} else if %s {", strings.Join(conds, " || "))
+		}
+		if err := c.nested(cs.Body); err != nil {
+			return err
+		}
+	}
+	switch {
+	case len(n.Cases) == 0 && n.Default != nil:
+		c.line("_ = subject")
+		if err := c.stmt(n.Default); err != nil {
+			return err
+		}
+	case len(n.Cases) == 0:
+		c.line("_ = subject")
+	case n.Default != nil:
+		c.line("} else {")
+		if err := c.nested(n.Default); err != nil {
+			return err
+		}
+		c.line("}")
+	default:
+		c.line("}")
+	}
+	c.depth--
+	c.line("}")
+	return nil
+}
+
+// macro, macro tanımını gövdesi Go closure'ı olan bir DefineMacro çağrısına çevirir.
+func (c *goCompiler) macro(n *MacroNode) error {
+	params := make([]string, len(n.Params))
+	defaults := map[string]Operand{}
+	for i, p := range n.Params {
+		params[i] = p.Name
+		if p.Default != "" {
+			defaults[p.Name] = compileOperand(p.Default)
+		}
+	}
+	paramsLit, err := literal(reflect.ValueOf(params))
+	if err != nil {
+		return err
+	}
+	defaultsLit := "nil"
+	if len(defaults) > 0 {
+		if defaultsLit, err = literal(reflect.ValueOf(defaults)); err != nil {
+			return err
+		}
+	}
+	c.line("%s", c.ctx("DefineMacro(%q, %s, %s, func(ctx *hipoengine.Context) (string, error) {", n.Name, paramsLit, defaultsLit))
+	ret, loops, used := c.ret, c.loops, c.usedCtx
+	c.ret, c.loops = `"", err`, 0
+	c.depth++
+	c.line("var sb strings.Builder")
+	err = c.stmt(n.Body)
+	c.line("return sb.String(), nil")
+	c.depth--
+	c.ret, c.loops, c.usedCtx = ret, loops, used
+	if err != nil {
+		return err
+	}
+	c.line("})")
+	return nil
+}
+
+// valueLiteral, literal değeri (string, sayı, bool veya nil) Go ifadesine çevirir.
+func valueLiteral(val interface{}) (string, error) {
+	if val == nil {
+		return "nil", nil
+	}
+	return literal(reflect.ValueOf(val))
+}
+
+// pkgPath, hipoengine paketinin reflect import yoludur.
+var pkgPath = reflect.TypeOf(TextNode{}).PkgPath()
+
+// literal, node ağacını (veya alanlarını) Go composite literal'ine çevirir.
+func literal(v reflect.Value) (string, error) {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return "nil", nil
+		}
+		return literal(v.Elem())
+	case reflect.Ptr:
+		if v.IsNil() {
+			return "nil", nil
+		}
+		inner, err := literal(v.Elem())
+		if err != nil {
+			return "", err
+		}
+		return "&" + inner, nil
+	case reflect.Struct:
+		t := v.Type()
+		if t.PkgPath() != pkgPath {
+			return "", fmt.Errorf("%s tipi Go koduna derlenemez (özel tag node'ları desteklenmez)", t)
+		}
+		var fields []string
+		for i := 0; i < t.NumField(); i++ {
+			f := v.Field(i)
+			if f.IsZero() {
+				continue
+			}
+			if t.Field(i).PkgPath != "" {
+				return "", fmt.Errorf("%s.%s alanı Go koduna derlenemez", t.Name(), t.Field(i).Name)
+			}
+			lit, err := literal(f)
+			if err != nil {
+				return "", err
+			}
+			fields = append(fields, t.Field(i).Name+": "+lit)
+		}
+		return "hipoengine." + t.Name() + "{" + strings.Join(fields, ", ") + "}", nil
+	case reflect.Slice:
+		if v.IsNil() {
+			return "nil", nil
+		}
+		typ, err := typeName(v.Type())
+		if err != nil {
+			return "", err
+		}
+		items := make([]string, v.Len())
+		for i := range items {
+			if items[i], err = literal(v.Index(i)); err != nil {
+				return "", err
+			}
+			items[i] = elideType(items[i], v.Type().Elem())
+		}
+		return typ + "{" + strings.Join(items, ", ") + "}", nil
+	case reflect.Map:
+		if v.IsNil() {
+			return "nil", nil
+		}
+		typ, err := typeName(v.Type())
+		if err != nil {
+			return "", err
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		items := make([]string, len(keys))
+		for i, k := range keys {
+			kl, err := literal(k)
+			if err != nil {
+				return "", err
+			}
+			vl, err := literal(v.MapIndex(k))
+			if err != nil {
+				return "", err
+			}
+			items[i] = kl + ": " + elideType(vl, v.Type().Elem())
+		}
+		return typ + "{" + strings.Join(items, ", ") + "}", nil
+	case reflect.String:
+		if v.Type().PkgPath() != "" {
+			return "hipoengine." + v.Type().Name() + "(" + strconv.Quote(v.String()) + ")", nil
+		}
+		return strconv.Quote(v.String()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Float64:
+		return "float64(" + strconv.FormatFloat(v.Float(), 'g', -1, 64) + ")", nil
+	}
+	return "", fmt.Errorf("%s tipi Go koduna derlenemez", v.Type())
+}
+
+// elideType, slice/map elemanlarında gereksiz tip adını atar ([]T{T{...}} => []T{{...}}).
+func elideType(lit string, elem reflect.Type) string {
+	switch {
+	case elem.Kind() == reflect.Struct:
+		return strings.TrimPrefix(lit, "hipoengine."+elem.Name())
+	case elem.Kind() == reflect.Ptr && elem.Elem().Kind() == reflect.Struct:
+		return strings.TrimPrefix(lit, "&hipoengine."+elem.Elem().Name())
+	}
+	return lit
+}
+
+// typeName, üretilen kodda kullanılacak tip adını döndürür.
+func typeName(t reflect.Type) (string, error) {
+	switch t.Kind() {
+	case reflect.Ptr:
+		inner, err := typeName(t.Elem())
+		return "*" + inner, err
+	case reflect.Slice:
+		inner, err := typeName(t.Elem())
+		return "[]" + inner, err
+	case reflect.Map:
+		k, err := typeName(t.Key())
+		if err != nil {
+			return "", err
+		}
+		v, err := typeName(t.Elem())
+		return "map[" + k + "]" + v, err
+	case reflect.Interface:
+		if t.Name() == "" {
+			return "interface{}", nil
+		}
+	}
+	if t.PkgPath() == pkgPath {
+		return "hipoengine." + t.Name(), nil
+	}
+	if t.PkgPath() == "" && t.Name() != "" {
+		return t.Name(), nil
+	}
+	return "", fmt.Errorf("%s tipi Go koduna derlenemez", t)
+}
+
+// compiledIdent, dosya adından exported fonksiyon adı üretir: "partials/footer.hipo" => "PartialsFooter".
+func compiledIdent(file string) string {
+	name := strings.TrimSuffix(filepath.ToSlash(file), filepath.Ext(file))
+	var sb strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	ident := sb.String()
+	if ident == "" || unicode.IsDigit(rune(ident[0])) {
+		ident = "T" + ident
+	}
+	return ident
+}
+
+func lowerFirst(s string) string {
+	r := []rune(s)
+	if len(r) > 0 {
+		r[0] = unicode.ToLower(r[0])
+	}
+	return string(r)
+}
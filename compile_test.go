@@ -0,0 +1,81 @@
+package hipoengine
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompiledIdent(t *testing.T) {
+	cases := map[string]string{
+		"index.hipo":            "Index",
+		"partials/footer.hipo":  "PartialsFooter",
+		"blog/post-detail.hipo": "BlogPostDetail",
+		"404.hipo":              "T404",
+	}
+	for file, want := range cases {
+		if got := compiledIdent(file); got != want {
+			t.Errorf("compiledIdent(%s): beklenen %s, gerçek %s", file, want, got)
+		}
+	}
+}
+
+func TestCompileGo(t *testing.T) {
+	e := NewEngine()
+	e.AddTemplatePath("testdata/compile")
+	src, err := e.CompileGo([]string{"page.hipo", "partials/footer.hipo"}, CompileOptions{Package: "views"})
+	if err != nil {
+		t.Fatalf("CompileGo error: %v", err)
+	}
+	for _, want := range []string{
+		"package views", "func RenderPage(", "func RenderPartialsFooter(",
+		`if hipoengine.AsInt(ctx.ResolvePath("user", "age")) >= 18 {`,
+		`ctx.ApplyFilter("default", ctx.ResolvePath("user", "name"), "Anonim")`,
+		`items, err := ctx.LoopItems("items", "items")`,
+		`if hipoengine.Equal(subject, "editor") || hipoengine.Equal(subject, "author") {`,
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("Üretilen kodda '%s' bekleniyordu", want)
+		}
+	}
+	// Gövdeler Go koduna çevrilir; node'lar gömülüp çalışma anında yorumlanmaz
+	for _, unwanted := range []string{"hipoengine.ASTNode", ".Execute(ctx)", "EvalBool"} {
+		if strings.Contains(string(src), unwanted) {
+			t.Errorf("Üretilen kodda '%s' olmamalıydı", unwanted)
+		}
+	}
+
+	e.RegisterTag("feature", "", func(tp *TagParser) (ASTNode, error) {
+		return NodeFunc(func(ctx *Context) (string, error) { return "", nil }), nil
+	})
+	if _, err := e.CompileGo([]string{"custom.hipo"}, CompileOptions{}); err == nil || !strings.Contains(err.Error(), "custom.hipo derlenemedi") {
+		t.Errorf("Özel tag node'u içeren template derlenememeliydi, hata: %v", err)
+	}
+}
+
+func TestCompileGoUnsupportedNodes(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"extends.hipo":   `{{ extends "base.hipo" }}{{ block a }}x{{ endblock }}`,
+		"recursive.hipo": `{{ for n in tree recursive }}{{ loop(n.children) }}{{ endfor }}`,
+		"assets.hipo":    `<head>{{ styles }}</head>`,
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	e := NewEngine()
+	e.AddTemplatePath(dir)
+	for name, want := range map[string]string{
+		"extends.hipo":   "{{ extends }} Go koduna derlenemez",
+		"recursive.hipo": "{{ for ... recursive }} Go koduna derlenemez",
+		"assets.hipo":    "{{ styles }} Go koduna derlenemez",
+	} {
+		_, err := e.CompileGo([]string{name}, CompileOptions{})
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: '%s' hatası bekleniyordu, gerçek: %v", name, want, err)
+		}
+	}
+}
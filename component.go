@@ -0,0 +1,140 @@
+// component.go
+// {{ component }} tag'ı, named slot'lar ve component render işlemleri
+package hipoengine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// defaultSlot, isimsiz {{ slot }} placeholder'ının ve component gövdesinin slot adıdır.
+const defaultSlot = "default"
+
+// ComponentProp, component çağrısındaki tek bir name=value özelliğidir (value bir ifadedir).
+type ComponentProp struct {
+	Name string
+	Expr string
+}
+
+// ComponentNode, bir component dosyasını verilen prop ve slot içerikleriyle render eder.
+type ComponentNode struct {
+	File  string
+	Props []ComponentProp
+	Slots map[string]ASTNode // named slot içerikleri, gövdenin kalanı "default" slot'udur
+	Pos   Position
+}
+
+// SlotNode, component dosyasındaki slot placeholder'ıdır. Çağıran slot içeriği vermezse Fallback render edilir.
+type SlotNode struct {
+	Name     string
+	Fallback ASTNode
+}
+
+// slotContent, slot içeriğini ve render edileceği (çağıranın) context'ini tutar.
+type slotContent struct {
+	node ASTNode
+	ctx  *Context
+}
+
+// parseComponent, component açılış tag'ı ile endcomponent arasını ComponentNode'a dönüştürür.
+// Hatalar kaydedilir; hatalı özellikler atlanır.
+func (p *Parser) parseComponent(open, end tagToken) *ComponentNode {
+	fields := splitFields(open.args())
+	node := &ComponentNode{Slots: make(map[string]ASTNode)}
+	if len(fields) == 0 {
+		p.fail(open.start, open.end, "component ifadesinde dosya adı eksik")
+	} else {
+		node.File = strings.Trim(fields[0], `"'`)
+		for _, f := range fields[1:] {
+			eq := strings.Index(f, "=")
+			if eq <= 0 {
+				p.fail(open.start, open.end, "geçersiz component özelliği: %s", f)
+				continue
+			}
+			node.Props = append(node.Props, ComponentProp{Name: f[:eq], Expr: f[eq+1:]})
+		}
+	}
+
+	// Gövdedeki üst seviye {{ slot name }}...{{ endslot }} bloklarını ayır, kalan içerik default slot olur
+	var defaults []ASTNode
+	hasDefault := false
+	addDefault := func(start, stop int) {
+		if strings.TrimSpace(p.template[start:stop]) == "" {
+			return
+		}
+		hasDefault = true
+		defaults = append(defaults, p.sub(start, stop).parse())
+	}
+	pos := open.end
+	for {
+		t, ok := findBlockTag(p.template, pos, "component", "endcomponent", "slot")
+		if !ok || t.start >= end.start {
+			break
+		}
+		addDefault(pos, t.start)
+		name := t.args()
+		if name == "" {
+			name = defaultSlot
+		}
+		endslot, ok := findBlockTag(p.template, t.end, "slot", "endslot")
+		if !ok || endslot.start > end.start {
+			p.fail(t.start, t.end, "unclosed slot block")
+			node.Slots[name] = p.sub(t.end, end.start).parse()
+			pos = end.start
+			break
+		}
+		node.Slots[name] = p.sub(t.end, endslot.start).parse()
+		pos = endslot.end
+	}
+	addDefault(pos, end.start)
+	if _, ok := node.Slots[defaultSlot]; !ok && hasDefault {
+		node.Slots[defaultSlot] = &ListNode{Nodes: defaults}
+	}
+	return node
+}
+
+// Execute, component dosyasını izole bir context'te prop'lar ve slot içerikleriyle render eder.
+func (n *ComponentNode) Execute(ctx *Context) (string, error) {
+	if ctx.engine == nil {
+		return "", fmt.Errorf("engine not set in context for component")
+	}
+	props := make(map[string]interface{}, len(n.Props))
+	for _, prop := range n.Props {
+		val, err := parseVariable(prop.Expr).ExecuteRaw(ctx)
+		if err != nil {
+			return "", err
+		}
+		props[prop.Name] = val
+	}
+	slots := make(map[string]*slotContent, len(n.Slots))
+	for name, body := range n.Slots {
+		slots[name] = &slotContent{node: body, ctx: ctx}
+	}
+	child := ctx.newScope(ctx.engine.mergeContext(props))
+	child.depth = ctx.depth + 1
+	if err := child.checkDepth(); err != nil {
+		return "", err
+	}
+	child.slots = slots
+	child.caller = &n.Pos
+	return ctx.engine.RenderFileContext(n.File, child)
+}
+
+func (n *ComponentNode) ExecuteRaw(ctx *Context) (interface{}, error) {
+	return n.Execute(ctx)
+}
+
+// Execute, çağıranın verdiği slot içeriğini çağıranın context'inde, yoksa fallback içeriği render eder.
+func (n *SlotNode) Execute(ctx *Context) (string, error) {
+	if content, ok := ctx.slots[n.Name]; ok {
+		return content.node.Execute(content.ctx.NewChild(map[string]interface{}{}))
+	}
+	if n.Fallback != nil {
+		return n.Fallback.Execute(ctx)
+	}
+	return "", nil
+}
+
+func (n *SlotNode) ExecuteRaw(ctx *Context) (interface{}, error) {
+	return n.Execute(ctx)
+}
@@ -0,0 +1,142 @@
+package hipoengine
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestComponentSlots(t *testing.T) {
+	e := NewEngine()
+	e.AddTemplatePath("testdata/components")
+	tpl := `{{ component "ui/card.hipo" title=pageTitle }}{{ slot header }}<b>{{ user }}</b>{{ endslot }}Gövde: {{ user }}{{ endcomponent }}`
+	ctx := map[string]interface{}{"pageTitle": "Profil", "user": "Emre"}
+	out, err := e.Render(tpl, ctx)
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	for _, want := range []string{
+		"<h2>Profil</h2>",
+		"<header><b>Emre</b></header>",
+		"<section>Gövde: Emre</section>",
+		"<footer>Varsayılan Alt Bilgi</footer>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Çıktıda '%s' bekleniyordu:\n%s", want, out)
+		}
+	}
+}
+
+func TestComponentSlotSet(t *testing.T) {
+	e := NewEngine()
+	e.AddTemplatePath("testdata/components")
+	tpl := `{{ component "ui/card.hipo" }}{{ slot header }}{{ set h = "Başlık" }}{{ h }}{{ endslot }}{{ set b = user }}{{ b }}{{ endcomponent }}{{ b }}`
+	out, err := e.Render(tpl, map[string]interface{}{"user": "Emre"})
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if !strings.Contains(out, "<header>Başlık</header>") || !strings.Contains(out, "<section>Emre</section>") {
+		t.Errorf("Slot içindeki set çalışmalıydı:\n%s", out)
+	}
+	if strings.HasSuffix(out, "Emre") {
+		t.Errorf("Slot içindeki set çağıranın scope'una sızmamalı:\n%s", out)
+	}
+	// Bir alt scope'un data map'i olmasa da set çalışır
+	ctx := NewContext(nil, e.funcs, e.filters, e).NewChild(nil)
+	if _, err := (&SetNode{VarName: "x", Value: &VariableNode{Value: "1"}}).Execute(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := ctx.Get("x"); v != "1" {
+		t.Errorf("set değeri okunamadı: %v", v)
+	}
+}
+
+func TestComponentIsolatesCallerVariables(t *testing.T) {
+	e := NewEngine()
+	e.AddTemplatePath("testdata/components")
+	out, err := e.Render(`{{ component "ui/card.hipo" }}{{ endcomponent }}`, map[string]interface{}{"title": "Sızmamalı"})
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if strings.Contains(out, "Sızmamalı") {
+		t.Errorf("Çağıranın değişkenleri component'e sızmamalı:\n%s", out)
+	}
+	if !strings.Contains(out, "<header>Varsayılan Başlık</header>") {
+		t.Errorf("Slot verilmediğinde fallback bekleniyordu:\n%s", out)
+	}
+}
+
+func TestComponentPropsDefaultsAndValidation(t *testing.T) {
+	e := NewEngine()
+	e.AddTemplatePath("testdata/components")
+	out, err := e.Render(`{{ component "ui/badge.hipo" label="Yeni" }}{{ endcomponent }}`, map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if !strings.Contains(out, `<span class="badge">Yeni(0)</span>`) {
+		t.Errorf("Default prop değerleri bekleniyordu:\n%s", out)
+	}
+
+	_, err = e.Render("Başlık\n\n{{ component \"ui/badge.hipo\" }}{{ endcomponent }}", map[string]interface{}{})
+	te, ok := err.(*TemplateError)
+	if !ok {
+		t.Fatalf("Eksik prop için TemplateError bekleniyordu, gerçek: %v", err)
+	}
+	if te.Line != 3 || !strings.Contains(te.Message, "label") || !strings.Contains(te.Message, "ui/badge.hipo") {
+		t.Errorf("Hata çağıranın satırını ve prop adını içermeli: %v", te)
+	}
+
+	_, err = e.Render(`{{ component "ui/badge.hipo" label="x" featured="evet" }}{{ endcomponent }}`, map[string]interface{}{})
+	if err == nil || !strings.Contains(err.Error(), "bool") {
+		t.Errorf("Tip uyuşmazlığı hatası bekleniyordu, gerçek: %v", err)
+	}
+}
+
+func TestIncludePropsValidation(t *testing.T) {
+	e := NewEngine()
+	e.AddTemplatePath("testdata/components")
+	out, err := e.Render(`{{ include "ui/badge.hipo" }}`, map[string]interface{}{"label": "Etiket", "items": []interface{}{1, 2}})
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if !strings.Contains(out, "Etiket(2)") {
+		t.Errorf("Beklenen: 'Etiket(2)', Gerçek: '%s'", out)
+	}
+	if _, err := e.Render(`{{ include "ui/badge.hipo" }}`, map[string]interface{}{}); err == nil {
+		t.Error("Include'da eksik zorunlu prop için hata bekleniyordu")
+	}
+}
+
+func TestParseProps(t *testing.T) {
+	decls, err := parseProps(`title: string, items: list = [1, "a"], featured: bool = false, extra`)
+	if err != nil {
+		t.Fatalf("parseProps error: %v", err)
+	}
+	if len(decls) != 4 || decls[1].Default != `[1, "a"]` || decls[3].Type != "any" {
+		t.Errorf("Beklenmeyen bildirimler: %#v", decls)
+	}
+	if _, err := parseProps(`x: unknown`); err == nil {
+		t.Error("Bilinmeyen tip için hata bekleniyordu")
+	}
+}
+
+func TestIncludeScope(t *testing.T) {
+	e := NewEngine()
+	e.AddTemplatePath("testdata/components")
+	out, err := e.Render(`{{ set shared = "dışarıda" }}{{ include "ui/setter.hipo" }}|{{ shared }}`, map[string]interface{}{"label": "Etiket"})
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	// Include edilen dosya çağıranın değişkenlerini okur ve set ile atadıkları çağırana döner
+	if out != "içeride:Etiket\n|içeride" {
+		t.Errorf("Beklenen: 'içeride:Etiket|içeride', Gerçek: %q", out)
+	}
+
+	// Props bildiren dosya kendi scope'unda çalışır: default'lar çağıranın değişkenlerini ezmez
+	out, err = e.Render(`{{ include "ui/badge.hipo" }}|{{ items }}`, map[string]interface{}{"label": "Etiket"})
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if !strings.HasSuffix(out, "|") {
+		t.Errorf("Prop default'u çağırana sızmamalı, gelen %q", out)
+	}
+}
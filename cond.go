@@ -0,0 +1,199 @@
+// cond.go
+// if/elif/for-if koşullarının derleme anında parçalanması ve değerlendirilmesi
+package hipoengine
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Operand, derleme anında çözülmüş bir koşul operandıdır. Path nil ise Value literal değerdir;
+// değilse değer context'te Path ile aranır. hipo compile ile üretilen kod tarafından kullanılır.
+type Operand struct {
+	Path  []string
+	Value interface{}
+}
+
+// compileOperand, operand ifadesini literal veya önceden bölünmüş path'e çevirir.
+func compileOperand(expr string) Operand {
+	expr = strings.TrimSpace(expr)
+	if val, ok := parseLiteral(expr); ok {
+		return Operand{Value: val}
+	}
+	return Operand{Path: splitPathWithBrackets(expr)}
+}
+
+// value, operandın değerini Resolve kurallarıyla döndürür (bulunamayan değişken "" olur).
+func (o Operand) value(ctx *Context) interface{} {
+	if o.Path == nil {
+		return o.Value
+	}
+	return ctx.resolveParts(o.Path)
+}
+
+// lookup, operandın değerini ve bulunup bulunmadığını döndürür (is testleri için).
+func (o Operand) lookup(ctx *Context) (interface{}, bool) {
+	if o.Path == nil {
+		return o.Value, true
+	}
+	return ctx.lookupParts(o.Path)
+}
+
+// condition, bir koşul ifadesinin derleme anında parçalanmış halidir. Operatörler evalBool'un
+// tarihsel sırasıyla aranır: is testi, >=, <=, >, <, == ve !=; hiçbiri yoksa ifade bir
+// değişkenin doğruluk değeri olarak değerlendirilir.
+type condition struct {
+	op     string // "is", ">=", "<=", ">", "<", "==", "!=" veya doğruluk kontrolü için ""
+	left   Operand
+	right  Operand
+	test   string    // is testinin adı
+	negate bool      // is not
+	args   []Operand // is testinin argümanları
+}
+
+// conditionOps, koşullarda aranan karşılaştırma operatörleridir (arama sırasıyla).
+var conditionOps = []string{">=", "<=", ">", "<", "==", "!="}
+
+// compileCondition, koşul ifadesini değerlendirmeye hazır hale getirir.
+func compileCondition(expr string) *condition {
+	expr = strings.TrimSpace(expr)
+	if left, name, args, negate, ok := splitIsExpr(expr); ok {
+		c := &condition{op: "is", left: compileOperand(left), test: name, negate: negate}
+		for _, a := range args {
+			c.args = append(c.args, compileOperand(a))
+		}
+		return c
+	}
+	for _, op := range conditionOps {
+		if strings.Contains(expr, op) {
+			parts := strings.Split(expr, op)
+			return &condition{op: op, left: compileOperand(parts[0]), right: compileOperand(parts[1])}
+		}
+	}
+	return &condition{left: Operand{Path: splitPathWithBrackets(expr)}}
+}
+
+// testArity, built-in testlerin aldığı en fazla argüman sayısıdır; listede olmayan built-in
+// testler argüman almaz.
+var testArity = map[string]int{"divisibleby": 1, "sameas": 1}
+
+// checkCondition, koşuldaki is testinin fazladan token almadığını doğrular. Koşullarda and/or
+// desteklenmez; "x is string and y is even" gibi bir ifade testin argümanı sayılıp sessizce
+// yanlış değerlendirilmek yerine parse hatası verir. Sonradan kaydedilen (RegisterTest) testler
+// herhangi sayıda argüman alabilir.
+func checkCondition(expr string) error {
+	_, name, args, _, ok := splitIsExpr(strings.TrimSpace(expr))
+	if !ok {
+		return nil
+	}
+	for _, a := range args {
+		if a == "and" || a == "or" || a == "not" {
+			return fmt.Errorf("koşullarda '%s' desteklenmiyor: %s", a, strings.TrimSpace(expr))
+		}
+	}
+	if _, builtin := DefaultTests[name]; builtin && len(args) > testArity[name] {
+		return fmt.Errorf("'%s' testi en fazla %d argüman alır, %d verildi: %s", name, testArity[name], len(args), strings.TrimSpace(expr))
+	}
+	return nil
+}
+
+// eval, koşulu verilen context'te değerlendirir.
+func (c *condition) eval(ctx *Context) bool {
+	switch c.op {
+	case "is":
+		return ctx.Test(c.test, c.negate, c.left, c.args...)
+	case ">=":
+		return AsInt(c.left.value(ctx)) >= AsInt(c.right.value(ctx))
+	case "<=":
+		return AsInt(c.left.value(ctx)) <= AsInt(c.right.value(ctx))
+	case ">":
+		return AsInt(c.left.value(ctx)) > AsInt(c.right.value(ctx))
+	case "<":
+		return AsInt(c.left.value(ctx)) < AsInt(c.right.value(ctx))
+	case "==":
+		return AsString(c.left.value(ctx)) == AsString(c.right.value(ctx))
+	case "!=":
+		return AsString(c.left.value(ctx)) != AsString(c.right.value(ctx))
+	}
+	return Truthy(c.left.value(ctx))
+}
+
+// Mantıksal karşılaştırmaları çalıştırır (if, elif vs)
+func evalBool(expr string, ctx *Context) bool {
+	return compileCondition(expr).eval(ctx)
+}
+
+// Truthy, koşullardaki doğruluk kuralını uygular: bool kendi değeri, string boş veya "0" değilse,
+// diğer değerler nil değilse doğrudur.
+func Truthy(val interface{}) bool {
+	if b, ok := val.(bool); ok {
+		return b
+	}
+	if s, ok := val.(string); ok {
+		return s != "" && s != "0"
+	}
+	return val != nil
+}
+
+// AsInt, sıralama karşılaştırmaları (<, >, <=, >=) için değeri tam sayıya çevirir; tam sayı
+// olarak okunamayan değerler 0 olur.
+func AsInt(val interface{}) int {
+	if i, ok := val.(int); ok {
+		return i
+	}
+	i, _ := strconv.Atoi(fmt.Sprintf("%v", val))
+	return i
+}
+
+// AsString, eşitlik karşılaştırmaları (==, !=) için değerin metin halini döndürür.
+func AsString(val interface{}) string {
+	return fmt.Sprintf("%v", val)
+}
+
+// ResolvePath, önceden bölünmüş bir path'i Resolve kurallarıyla context zincirinde çözer.
+func (ctx *Context) ResolvePath(parts ...string) interface{} {
+	return ctx.resolveParts(parts)
+}
+
+// Test, "left is [not] name args" testini çalıştırır. defined/undefined testleri değerin
+// varlığına bakar; bilinmeyen veya izin verilmeyen testler false döner.
+func (ctx *Context) Test(name string, negate bool, left Operand, args ...Operand) bool {
+	fn, found := ctx.lookupTest(name)
+	if !found {
+		fmt.Fprintf(os.Stderr, "[hipoengine] Uyarı: '%s' isimli test bulunamadı.\n", name)
+		return false
+	}
+	if !ctx.testAllowed(name) {
+		fmt.Fprintf(os.Stderr, "[hipoengine] Uyarı: '%s' isimli teste izin verilmiyor.\n", name)
+		return false
+	}
+	val, defined := left.lookup(ctx)
+	vals := make([]interface{}, len(args))
+	for i, a := range args {
+		vals[i], _ = a.lookup(ctx)
+	}
+	var res bool
+	switch name {
+	case "defined":
+		res = defined
+	case "undefined":
+		res = !defined
+	default:
+		res = fn(val, vals...)
+	}
+	if negate {
+		res = !res
+	}
+	return res
+}
+
+// constant, iki tarafı da literal olan karşılaştırmanın sonucunu derleme anında hesaplar. İkinci
+// değer false ise koşulun sonucu context'e bağlıdır.
+func (c *condition) constant() (bool, bool) {
+	if c.op == "" || c.op == "is" || c.left.Path != nil || c.right.Path != nil {
+		return false, false
+	}
+	return c.eval(nil), true
+}
@@ -8,11 +8,19 @@ import (
 
 // Context, template çalıştırılırken değişkenlerin, fonksiyonların ve filtrelerin tutulduğu yapıdır. Scope zinciri için parent referansı içerir.
 type Context struct {
-	data    map[string]interface{} // template verileri
-	funcs   map[string]Function    // fonksiyonlar
-	filters map[string]FilterFunc  // filtreler
-	parent  *Context               // opsiyonel ebeveyn context (scoping için)
-	engine  *Engine                // engine referansı (include vb için)
+	data    map[string]interface{}  // template verileri
+	funcs   map[string]Function     // fonksiyonlar
+	filters map[string]FilterFunc   // filtreler
+	tests   map[string]TestFunc     // "is" testleri
+	parent  *Context                // opsiyonel ebeveyn context (scoping için)
+	engine  *Engine                 // engine referansı (include vb için)
+	slots   map[string]*slotContent // component içinde render edilecek slot içerikleri
+	caller  *Position               // include/component çağrısının konumu (prop hataları için)
+	calls   map[string]callable     // template içinde tanımlanan çağrılabilirler (macro, recursive loop)
+	depth   int                     // include/component/macro/loop recursion derinliği
+	assets  *assetCollector         // render boyunca include edilen dosyaların script/style blokları
+	files   *fileStack              // render zincirindeki template dosyaları (döngü tespiti için)
+	shared  bool                    // data çağıranla paylaşılıyor (include); props bildirimi scope'u ayırır
 
 	CurrentLocale  string
 	StrictMode     bool
@@ -20,16 +28,22 @@ type Context struct {
 	AllowedFilters map[string]bool
 	AllowedFuncs   map[string]bool
 	AllowedVars    map[string]bool
+	AllowedTests   map[string]bool
 	DebugMode      bool
 	DebugLogger    func(msg string)
 }
 
 // NewContext, yeni bir context oluşturur.
 func NewContext(data map[string]interface{}, funcs map[string]Function, filters map[string]FilterFunc, engine *Engine) *Context {
+	tests := DefaultTests
+	if engine != nil {
+		tests = engine.tests
+	}
 	return &Context{
 		data:           data,
 		funcs:          funcs,
 		filters:        filters,
+		tests:          tests,
 		parent:         nil,
 		engine:         engine,
 		CurrentLocale:  "",
@@ -38,6 +52,7 @@ func NewContext(data map[string]interface{}, funcs map[string]Function, filters
 		AllowedFilters: nil,
 		AllowedFuncs:   nil,
 		AllowedVars:    nil,
+		AllowedTests:   nil,
 		DebugMode:      false,
 		DebugLogger:    nil,
 	}
@@ -49,19 +64,54 @@ func (ctx *Context) NewChild(data map[string]interface{}) *Context {
 		data:           data,
 		funcs:          ctx.funcs,
 		filters:        ctx.filters,
+		tests:          ctx.tests,
 		parent:         ctx,
 		engine:         ctx.engine,
+		slots:          ctx.slots,
+		depth:          ctx.depth,
+		assets:         ctx.assets,
+		files:          ctx.files,
 		CurrentLocale:  ctx.CurrentLocale,
 		StrictMode:     ctx.StrictMode,
 		SafeMode:       ctx.SafeMode,
 		AllowedFilters: ctx.AllowedFilters,
 		AllowedFuncs:   ctx.AllowedFuncs,
 		AllowedVars:    ctx.AllowedVars,
+		AllowedTests:   ctx.AllowedTests,
 		DebugMode:      ctx.DebugMode,
 		DebugLogger:    ctx.DebugLogger,
 	}
 }
 
+// newScope, ayarları ve fonksiyonları koruyarak ebeveyni olmayan izole bir context oluşturur (component'ler için).
+func (ctx *Context) newScope(data map[string]interface{}) *Context {
+	child := ctx.NewChild(data)
+	child.parent = nil
+	child.slots = nil
+	return child
+}
+
+// callable, template içinde tanımlanan ve çağıranın context'ini alan fonksiyondur.
+type callable func(caller *Context, args ...interface{}) (interface{}, error)
+
+// setCallable, mevcut scope'a bir macro veya loop fonksiyonu ekler.
+func (ctx *Context) setCallable(name string, fn callable) {
+	if ctx.calls == nil {
+		ctx.calls = make(map[string]callable)
+	}
+	ctx.calls[name] = fn
+}
+
+// lookupCallable, context zincirinde template içinde tanımlanmış çağrılabiliri arar.
+func (ctx *Context) lookupCallable(name string) (callable, bool) {
+	for current := ctx; current != nil; current = current.parent {
+		if fn, ok := current.calls[name]; ok {
+			return fn, true
+		}
+	}
+	return nil, false
+}
+
 // Resolve, verilen path'e göre context zincirinde değişken/fonksiyon/filtre arar ve döndürür.
 func (ctx *Context) Resolve(path string) interface{} {
 	if path == "" {
@@ -71,6 +121,93 @@ func (ctx *Context) Resolve(path string) interface{} {
 	return ctx.resolveParts(parts)
 }
 
+// Get, değişkeni context zincirinde arar; bulunamazsa ikinci değer false döner.
+func (ctx *Context) Get(path string) (interface{}, bool) {
+	return ctx.lookup(path)
+}
+
+// Set, değişkeni mevcut scope'a atar ({{ set }} ile aynı davranış).
+func (ctx *Context) Set(name string, val interface{}) {
+	if ctx.data == nil {
+		ctx.data = make(map[string]interface{})
+	}
+	ctx.data[name] = val
+}
+
+// Engine, context'in bağlı olduğu engine'i döndürür (engine'siz context'lerde nil).
+func (ctx *Context) Engine() *Engine {
+	return ctx.engine
+}
+
+// EvalBool, if koşullarıyla aynı kurallarla bir koşul ifadesini değerlendirir.
+func (ctx *Context) EvalBool(expr string) bool {
+	return evalBool(expr, ctx)
+}
+
+// lookup, Resolve gibi çalışır ancak değişkenin bulunup bulunmadığını da döndürür.
+func (ctx *Context) lookup(path string) (interface{}, bool) {
+	return ctx.lookupParts(splitPathWithBrackets(path))
+}
+
+// lookupParts, lookup'ın önceden bölünmüş path ile çalışan halidir.
+func (ctx *Context) lookupParts(parts []string) (interface{}, bool) {
+	if len(parts) == 0 {
+		return nil, false
+	}
+	if strings.HasSuffix(parts[0], ")") {
+		val := ctx.resolveParts(parts)
+		return val, val != nil
+	}
+	current := ctx
+	for current != nil {
+		if val, ok := current.data[parts[0]]; ok {
+			if len(parts) == 1 {
+				return val, true
+			}
+			return lookupValue(val, parts[1:])
+		}
+		current = current.parent
+	}
+	return nil, false
+}
+
+// lookupValue, resolveValue gibi çalışır ancak map anahtarlarının varlığına bakar; böylece değeri
+// nil olan bir alan da bulunmuş sayılır.
+func lookupValue(val interface{}, parts []string) (interface{}, bool) {
+	for i, part := range parts {
+		m, ok := val.(map[string]interface{})
+		if !ok {
+			val = resolveValue(val, parts[i:])
+			return val, val != nil
+		}
+		if val, ok = m[part]; !ok {
+			return nil, false
+		}
+	}
+	return val, true
+}
+
+// lookupTest, context'teki "is" testini bulur.
+func (ctx *Context) lookupTest(name string) (TestFunc, bool) {
+	if ctx.tests == nil {
+		fn, ok := DefaultTests[name]
+		return fn, ok
+	}
+	fn, ok := ctx.tests[name]
+	return fn, ok
+}
+
+// testAllowed, testin hem context hem engine whitelist'ine göre izinli olup olmadığını kontrol eder.
+func (ctx *Context) testAllowed(name string) bool {
+	if !IsAllowed(name, ctx.AllowedTests) {
+		return false
+	}
+	if ctx.engine != nil && !IsAllowed(name, ctx.engine.AllowedTests) {
+		return false
+	}
+	return true
+}
+
 // resolveParts, path parçalarını recursive olarak çözer.
 func (ctx *Context) resolveParts(parts []string) interface{} {
 	if len(parts) == 0 {
@@ -247,14 +384,20 @@ func (ctx *Context) Copy() *Context {
 		data:           newData,
 		funcs:          ctx.funcs,
 		filters:        ctx.filters,
+		tests:          ctx.tests,
 		parent:         ctx.parent,
 		engine:         ctx.engine,
+		slots:          ctx.slots,
+		depth:          ctx.depth,
+		assets:         ctx.assets,
+		files:          ctx.files,
 		CurrentLocale:  ctx.CurrentLocale,
 		StrictMode:     ctx.StrictMode,
 		SafeMode:       ctx.SafeMode,
 		AllowedFilters: ctx.AllowedFilters,
 		AllowedFuncs:   ctx.AllowedFuncs,
 		AllowedVars:    ctx.AllowedVars,
+		AllowedTests:   ctx.AllowedTests,
 		DebugMode:      ctx.DebugMode,
 		DebugLogger:    ctx.DebugLogger,
 	}
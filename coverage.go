@@ -0,0 +1,493 @@
+// coverage.go
+// Opsiyonel template coverage: dal ve gövde sayaçları, satır/dal raporları (metin ve HTML)
+package hipoengine
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Coverage, coverage modunda render edilen .hipo dosyalarının bölge sayaçlarını tutar. Sayaçlar
+// Reset çağrılana kadar tüm render'lar boyunca birikir; aynı dosya tekrar parse edilse de aynı
+// bölgelere yazılır.
+type Coverage struct {
+	mu    sync.Mutex
+	files map[string]*FileCoverage
+}
+
+// FileCoverage, tek bir dosyanın kaynağı ve coverage bölgeleridir.
+type FileCoverage struct {
+	File   string
+	Source string
+
+	mu      sync.Mutex
+	regions map[string]*CoverRegion
+}
+
+// CoverRegion, sayacı olan bir kaynak bölgesidir. Kind şunlardan biridir: "file" (dosyanın
+// template'i), "if", "elif", "else", "for" (döngü gövdesi), "for-empty" (döngünün hiç dönmediği
+// yol), "case", "default", "block" ve "macro". else veya default yazılmamışsa hiçbir dalın
+// seçilmediği yol Implicit bir else/default dalı olarak sayılır.
+type CoverRegion struct {
+	Kind      string `json:"kind"`
+	Implicit  bool   `json:"implicit,omitempty"`
+	Line      int    `json:"line"` // bölgeyi açan tag'ın konumu
+	Column    int    `json:"column"`
+	StartLine int    `json:"startLine"` // gövdenin satır aralığı
+	EndLine   int    `json:"endLine"`
+	Count     int64  `json:"count"`
+
+	start, end int // gövdenin kaynaktaki offset aralığı
+}
+
+// Branch, bölgenin dal coverage'ına sayılıp sayılmadığını döndürür.
+func (r *CoverRegion) Branch() bool {
+	switch r.Kind {
+	case "if", "elif", "else", "for", "for-empty", "case", "default":
+		return true
+	}
+	return false
+}
+
+// Label, bölgenin raporlarda kullanılan açıklamasıdır.
+func (r *CoverRegion) Label() string {
+	switch {
+	case r.Implicit && r.Kind == "else":
+		return "örtük else dalı (hiçbir koşul doğru değil)"
+	case r.Implicit && r.Kind == "default":
+		return "örtük default dalı (hiçbir case eşleşmedi)"
+	}
+	switch r.Kind {
+	case "file":
+		return "template"
+	case "for":
+		return "for gövdesi"
+	case "for-empty":
+		return "for'un hiç dönmediği yol"
+	case "block", "macro":
+		return r.Kind + " gövdesi"
+	}
+	return r.Kind + " dalı"
+}
+
+func (r *CoverRegion) count() int64 {
+	return atomic.LoadInt64(&r.Count)
+}
+
+// EnableCoverage, coverage modunu açar ve sayaçları döndürür. Bu modda RenderFile, include,
+// component ve extends ile parse edilen dosyaların if/elif/else dalları, for gövdeleri ve boş
+// döngü yolları, switch dalları, block ve macro gövdeleri sayılır. Parse cache'i temizlenir ve
+// Bytecode modu kullanılmaz; Render ile verilen satır içi template'ler ve RenderWithLayout
+// kapsanmaz.
+func (e *Engine) EnableCoverage() *Coverage {
+	if e.coverage == nil {
+		e.coverage = &Coverage{files: make(map[string]*FileCoverage)}
+		e.ClearCache()
+	}
+	return e.coverage
+}
+
+// DisableCoverage, coverage modunu kapatır; toplanan sayaçlar Coverage üzerinden okunmaya devam
+// edebilir.
+func (e *Engine) DisableCoverage() {
+	if e.coverage != nil {
+		e.coverage = nil
+		e.ClearCache()
+	}
+}
+
+// Coverage, coverage modu açıksa sayaçları, değilse nil döndürür.
+func (e *Engine) Coverage() *Coverage {
+	return e.coverage
+}
+
+// Reset, tüm sayaçları sıfırlar.
+func (c *Coverage) Reset() {
+	for _, f := range c.Files() {
+		f.mu.Lock()
+		for _, r := range f.regions {
+			atomic.StoreInt64(&r.Count, 0)
+		}
+		f.mu.Unlock()
+	}
+}
+
+// Files, coverage'ı toplanan dosyaları ada göre sıralı döndürür.
+func (c *Coverage) Files() []*FileCoverage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	files := make([]*FileCoverage, 0, len(c.files))
+	for _, f := range c.files {
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].File < files[j].File })
+	return files
+}
+
+// file, dosyanın coverage kaydını döndürür. Dosyanın içeriği değişmişse eski bölgeler atılır.
+func (c *Coverage) file(name, source string) *FileCoverage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	f, ok := c.files[name]
+	if !ok || f.Source != source {
+		f = &FileCoverage{File: name, Source: source, regions: make(map[string]*CoverRegion)}
+		c.files[name] = f
+	}
+	return f
+}
+
+// region, offset'leri kaynağa göre verilen bölgeyi döndürür; aynı bölge daha önce kaydedildiyse
+// sayacı paylaşılır.
+func (f *FileCoverage) region(kind string, implicit bool, tag, start, end int) *CoverRegion {
+	key := fmt.Sprintf("%s:%t:%d:%d:%d", kind, implicit, tag, start, end)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if r, ok := f.regions[key]; ok {
+		return r
+	}
+	r := &CoverRegion{Kind: kind, Implicit: implicit, start: start, end: end}
+	r.Line, r.Column = getLineCol(f.Source, tag)
+	r.StartLine, _ = getLineCol(f.Source, start)
+	r.EndLine, _ = getLineCol(f.Source, end)
+	f.regions[key] = r
+	return r
+}
+
+// Regions, dosyanın bölgelerini kaynak sırasıyla döndürür.
+func (f *FileCoverage) Regions() []*CoverRegion {
+	f.mu.Lock()
+	regions := make([]*CoverRegion, 0, len(f.regions))
+	for _, r := range f.regions {
+		regions = append(regions, r)
+	}
+	f.mu.Unlock()
+	sort.Slice(regions, func(i, j int) bool {
+		a, b := regions[i], regions[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		if a.start != b.start {
+			return a.start < b.start
+		}
+		return a.Kind < b.Kind
+	})
+	return regions
+}
+
+// LineCoverage, template'teki boş olmayan bir satırın coverage durumudur.
+type LineCoverage struct {
+	Line    int   `json:"line"`
+	Count   int64 `json:"count"`   // satırın ilk karakterini içeren en içteki bölgenin sayacı
+	Partial bool  `json:"partial"` // satırda hiç çalışmamış başka bir bölge de var
+}
+
+// Lines, dosyanın template'i içindeki boş olmayan satırların coverage durumunu döndürür.
+// <script>/<style> blokları gibi template dışındaki satırlar listelenmez.
+func (f *FileCoverage) Lines() []LineCoverage {
+	var regions []*CoverRegion
+	for _, r := range f.Regions() {
+		if r.start < r.end {
+			regions = append(regions, r)
+		}
+	}
+	var lines []LineCoverage
+	offset := 0
+	for i, text := range strings.SplitAfter(f.Source, "\n") {
+		lineStart := offset
+		offset += len(text)
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" {
+			continue
+		}
+		first := lineStart + strings.Index(text, trimmed)
+		last := first + len(trimmed)
+		var inner *CoverRegion
+		for _, r := range regions {
+			if r.start <= first && first < r.end && (inner == nil || r.end-r.start < inner.end-inner.start) {
+				inner = r
+			}
+		}
+		if inner == nil {
+			continue
+		}
+		lc := LineCoverage{Line: i + 1, Count: inner.count()}
+		for _, r := range regions {
+			if r == inner || r.count() > 0 {
+				continue
+			}
+			from, to := maxInt(r.start, first), minInt(r.end, last)
+			if from < to && strings.TrimSpace(f.Source[from:to]) != "" {
+				lc.Partial = true
+				break
+			}
+		}
+		lines = append(lines, lc)
+	}
+	return lines
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// CoverSummary, satır ve dal coverage sayılarıdır.
+type CoverSummary struct {
+	Lines           int `json:"lines"`
+	LinesCovered    int `json:"linesCovered"`
+	Branches        int `json:"branches"`
+	BranchesCovered int `json:"branchesCovered"`
+}
+
+func (s CoverSummary) String() string {
+	return fmt.Sprintf("satırlar %d/%d (%s), dallar %d/%d (%s)", s.LinesCovered, s.Lines, percent(s.LinesCovered, s.Lines), s.BranchesCovered, s.Branches, percent(s.BranchesCovered, s.Branches))
+}
+
+func (s *CoverSummary) add(o CoverSummary) {
+	s.Lines += o.Lines
+	s.LinesCovered += o.LinesCovered
+	s.Branches += o.Branches
+	s.BranchesCovered += o.BranchesCovered
+}
+
+func percent(n, total int) string {
+	if total == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%%%.1f", float64(n)*100/float64(total))
+}
+
+// Summary, dosyanın satır ve dal coverage sayılarını döndürür.
+func (f *FileCoverage) Summary() CoverSummary {
+	var s CoverSummary
+	for _, l := range f.Lines() {
+		s.Lines++
+		if l.Count > 0 {
+			s.LinesCovered++
+		}
+	}
+	for _, r := range f.Regions() {
+		if r.Branch() {
+			s.Branches++
+			if r.count() > 0 {
+				s.BranchesCovered++
+			}
+		}
+	}
+	return s
+}
+
+// Summary, tüm dosyaların toplam satır ve dal coverage sayılarını döndürür.
+func (c *Coverage) Summary() CoverSummary {
+	var s CoverSummary
+	for _, f := range c.Files() {
+		s.add(f.Summary())
+	}
+	return s
+}
+
+// WriteText, her dosya için özet satırını ve hiç çalışmamış dal ve gövdeleri yazar:
+//
+//	templates/index.hipo: satırlar 7/9 (%77.8), dallar 3/6 (%50.0)
+//	    12:5: elif dalı hiç çalışmadı
+//	toplam: satırlar 7/9 (%77.8), dallar 3/6 (%50.0)
+func (c *Coverage) WriteText(w io.Writer) error {
+	for _, f := range c.Files() {
+		if _, err := fmt.Fprintf(w, "%s: %s\n", f.File, f.Summary()); err != nil {
+			return err
+		}
+		for _, r := range f.Regions() {
+			if r.count() == 0 {
+				if _, err := fmt.Fprintf(w, "    %d:%d: %s hiç çalışmadı\n", r.Line, r.Column, r.Label()); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	_, err := fmt.Fprintf(w, "toplam: %s\n", c.Summary())
+	return err
+}
+
+// WriteHTML, dosya özetlerini ve satırları çalışma durumuna göre renklendirilmiş kaynak kodu
+// içeren tek sayfalık bir HTML raporu yazar. Dal tag'ı içeren satırlarda çalışan/toplam dal sayısı
+// gösterilir; çalışmayan dallar satırın üzerine gelince listelenir.
+func (c *Coverage) WriteHTML(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>hipo coverage</title>
+<style>
+body { font: 14px/1.4 system-ui, sans-serif; margin: 24px; color: #222; }
+table { border-collapse: collapse; }
+.summary td, .summary th { padding: 4px 12px; border-bottom: 1px solid #ddd; text-align: left; }
+.source { width: 100%; margin-bottom: 32px; font: 13px/1.4 ui-monospace, Menlo, Consolas, monospace; }
+.source td { padding: 0 8px; vertical-align: top; }
+.source td.num, .source td.count, .source td.branches { color: #888; text-align: right; white-space: nowrap; }
+.source td.code { white-space: pre; width: 100%; }
+.covered td.code { background: #e6ffed; }
+.partial td.code { background: #fff5d6; }
+.uncovered td.code { background: #ffe3e3; }
+td.branches.miss { color: #c62828; font-weight: bold; }
+</style></head>
+<body>
+<h1>Template coverage</h1>
+<table class="summary">
+<tr><th>Dosya</th><th>Satırlar</th><th>Dallar</th></tr>
+`)
+	files := c.Files()
+	for i, f := range files {
+		s := f.Summary()
+		fmt.Fprintf(&sb, "<tr><td><a href=\"#f%d\">%s</a></td><td>%d/%d (%s)</td><td>%d/%d (%s)</td></tr>\n", i, html.EscapeString(f.File), s.LinesCovered, s.Lines, percent(s.LinesCovered, s.Lines), s.BranchesCovered, s.Branches, percent(s.BranchesCovered, s.Branches))
+	}
+	total := c.Summary()
+	fmt.Fprintf(&sb, "<tr><th>Toplam</th><th>%d/%d (%s)</th><th>%d/%d (%s)</th></tr>\n</table>\n", total.LinesCovered, total.Lines, percent(total.LinesCovered, total.Lines), total.BranchesCovered, total.Branches, percent(total.BranchesCovered, total.Branches))
+	for i, f := range files {
+		fmt.Fprintf(&sb, "<h2 id=\"f%d\">%s</h2>\n<table class=\"source\">\n", i, html.EscapeString(f.File))
+		status := map[int]LineCoverage{}
+		for _, l := range f.Lines() {
+			status[l.Line] = l
+		}
+		branches := map[int][]*CoverRegion{}
+		for _, r := range f.Regions() {
+			if r.Branch() {
+				branches[r.Line] = append(branches[r.Line], r)
+			}
+		}
+		for n, text := range strings.Split(strings.TrimSuffix(f.Source, "\n"), "\n") {
+			line := n + 1
+			class, count := "", ""
+			if l, ok := status[line]; ok {
+				count = fmt.Sprint(l.Count)
+				switch {
+				case l.Count == 0:
+					class = "uncovered"
+				case l.Partial:
+					class = "partial"
+				default:
+					class = "covered"
+				}
+			}
+			cell := `<td class="branches"></td>`
+			if rs := branches[line]; len(rs) > 0 {
+				covered := 0
+				var missing []string
+				for _, r := range rs {
+					if r.count() > 0 {
+						covered++
+					} else {
+						missing = append(missing, fmt.Sprintf("%d:%d %s", r.Line, r.Column, r.Label()))
+					}
+				}
+				if len(missing) > 0 {
+					cell = fmt.Sprintf(`<td class="branches miss" title="çalışmayan: %s">%d/%d</td>`, html.EscapeString(strings.Join(missing, "; ")), covered, len(rs))
+				} else {
+					cell = fmt.Sprintf(`<td class="branches">%d/%d</td>`, covered, len(rs))
+				}
+			}
+			fmt.Fprintf(&sb, "<tr class=\"%s\"><td class=\"num\">%d</td><td class=\"count\">%s</td>%s<td class=\"code\">%s</td></tr>\n", class, line, count, cell, html.EscapeString(text))
+		}
+		sb.WriteString("</table>\n")
+	}
+	sb.WriteString("</body></html>\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// coverNode, coverage modunda bir gövdeyi sarar ve her çalıştırıldığında bölgenin sayacını artırır.
+type coverNode struct {
+	region *CoverRegion
+	Body   ASTNode
+}
+
+func (n *coverNode) Execute(ctx *Context) (string, error) {
+	atomic.AddInt64(&n.region.Count, 1)
+	return n.Body.Execute(ctx)
+}
+
+func (n *coverNode) ExecuteRaw(ctx *Context) (interface{}, error) {
+	return n.Execute(ctx)
+}
+
+func (n *coverNode) Children() []ASTNode {
+	return []ASTNode{n.Body}
+}
+
+// loopCoverNode, for döngüsünü sarar; gövde hiç çalışmadan biten her çalıştırmayı boş döngü
+// yolunun (for-empty) sayacına yazar.
+type loopCoverNode struct {
+	*ForNode
+	body, empty *CoverRegion
+}
+
+func (n *loopCoverNode) Execute(ctx *Context) (string, error) {
+	before := n.body.count()
+	out, err := n.ForNode.Execute(ctx)
+	if err == nil && n.body.count() == before {
+		atomic.AddInt64(&n.empty.Count, 1)
+	}
+	return out, err
+}
+
+func (n *loopCoverNode) ExecuteRaw(ctx *Context) (interface{}, error) {
+	return n.Execute(ctx)
+}
+
+func (n *loopCoverNode) Children() []ASTNode {
+	return []ASTNode{n.ForNode}
+}
+
+// cover, coverage modunda gövdeyi (parser template'indeki [start, end) aralığı) bir bölgeyle
+// sarar. tag, bölgeyi açan tag'ın offset'idir. Coverage kapalıysa gövde olduğu gibi döner.
+func (p *Parser) cover(body ASTNode, kind string, tag, start, end int) ASTNode {
+	if p.cov == nil {
+		return body
+	}
+	return &coverNode{region: p.cov.region(kind, false, p.base+tag, p.base+start, p.base+end), Body: body}
+}
+
+// coverImplicit, yazılmamış bir else/default dalı için boş, sayaçlı bir gövde döndürür. at,
+// dalın seçileceği kapanış tag'ının offset'idir.
+func (p *Parser) coverImplicit(kind string, tag, at int) ASTNode {
+	return &coverNode{region: p.cov.region(kind, true, p.base+tag, p.base+at, p.base+at), Body: &ListNode{}}
+}
+
+// coverLoop, coverage modunda for node'unu boş döngü yolunu sayan bir node ile sarar.
+func (p *Parser) coverLoop(n *ForNode, tag, end int) ASTNode {
+	body, ok := n.Body.(*coverNode)
+	if p.cov == nil || !ok {
+		return n
+	}
+	return &loopCoverNode{ForNode: n, body: body.region, empty: p.cov.region("for-empty", false, p.base+tag, p.base+end, p.base+end)}
+}
+
+// coverSkipped, hiç çalıştırılmayacak bir gövdeyi (ör: child template'in override ettiği layout
+// bloğu) sayacı sıfır kalan bir bölge olarak kaydeder.
+func (p *Parser) coverSkipped(kind string, tag, start, end int) {
+	if p.cov != nil {
+		p.cov.region(kind, false, p.base+tag, p.base+start, p.base+end)
+	}
+}
+
+// coverFile, kök parser'ın sonucunu dosyanın template bölgesiyle sarar.
+func (p *Parser) coverFile(node ASTNode) ASTNode {
+	if p.cov == nil || p.nested {
+		return node
+	}
+	return p.cover(node, "file", 0, 0, len(p.template))
+}
@@ -0,0 +1,106 @@
+package hipoengine
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func uncoveredRegions(f *FileCoverage) []string {
+	var out []string
+	for _, r := range f.Regions() {
+		if r.Count == 0 {
+			out = append(out, r.Kind)
+		}
+	}
+	return out
+}
+
+func TestCoverage(t *testing.T) {
+	e := NewEngine()
+	c := e.EnableCoverage()
+	contexts := []map[string]interface{}{
+		{"title": "A", "user": map[string]interface{}{"name": "Ali"}, "products": []interface{}{"x", "y"}, "lang": "tr"},
+		{"title": "B", "user": map[string]interface{}{}, "products": []interface{}{}, "lang": "de"},
+	}
+	for _, ctx := range contexts {
+		if _, err := e.RenderFile("testdata/coverage/page.hipo", ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := e.RenderFile("testdata/coverage/child.hipo", map[string]interface{}{"body": "x"}); err != nil {
+		t.Fatal(err)
+	}
+
+	files := c.Files()
+	var names []string
+	for _, f := range files {
+		names = append(names, f.File)
+	}
+	if want := []string{"testdata/coverage/base.hipo", "testdata/coverage/child.hipo", "testdata/coverage/page.hipo"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("dosyalar %v, beklenen %v", names, want)
+	}
+	base, page := files[0], files[2]
+
+	if got, want := page.Summary(), (CoverSummary{Lines: 15, LinesCovered: 14, Branches: 10, BranchesCovered: 7}); got != want {
+		t.Errorf("page özeti %v, beklenen %v", got, want)
+	}
+	if got, want := uncoveredRegions(page), []string{"if", "case", "if"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("page çalışmayan bölgeler %v, beklenen %v", got, want)
+	}
+	lines := map[int]LineCoverage{}
+	for _, l := range page.Lines() {
+		lines[l.Line] = l
+	}
+	if l := lines[4]; l.Count != 0 {
+		t.Errorf("4. satır çalışmamalıydı: %+v", l)
+	}
+	if l := lines[12]; l.Count != 2 || l.Partial {
+		t.Errorf("12. satır iki kez çalışmalıydı: %+v", l)
+	}
+	if l := lines[15]; l.Count == 0 || !l.Partial {
+		t.Errorf("15. satır kısmen çalışmalıydı: %+v", l)
+	}
+	if _, ok := lines[20]; ok {
+		t.Error("<style> bloğu satır coverage'ına sayılmamalı")
+	}
+
+	// child'ın override ettiği content bloğunun varsayılan gövdesi hiç çalışmaz.
+	if got, want := uncoveredRegions(base), []string{"block"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("base çalışmayan bölgeler %v, beklenen %v", got, want)
+	}
+	if got, want := base.Summary(), (CoverSummary{Lines: 10, LinesCovered: 9}); got != want {
+		t.Errorf("base özeti %v, beklenen %v", got, want)
+	}
+
+	var text bytes.Buffer
+	if err := c.WriteText(&text); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"testdata/coverage/page.hipo: satırlar 14/15 (%93.3), dallar 7/10 (%70.0)\n",
+		"    15:39: case dalı hiç çalışmadı\n",
+		"    3:1: block gövdesi hiç çalışmadı\n",
+		"toplam: satırlar 27/29 (%93.1), dallar 7/10 (%70.0)\n",
+	} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("metin raporunda %q yok:\n%s", want, text.String())
+		}
+	}
+
+	var report bytes.Buffer
+	if err := c.WriteHTML(&report); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"testdata/coverage/page.hipo", `class="uncovered"`, `class="partial"`, "&lt;h1&gt;"} {
+		if !strings.Contains(report.String(), want) {
+			t.Errorf("HTML raporunda %q yok", want)
+		}
+	}
+
+	c.Reset()
+	if s := c.Summary(); s.LinesCovered != 0 || s.BranchesCovered != 0 {
+		t.Errorf("Reset sonrası sayaçlar sıfırlanmadı: %v", s)
+	}
+}
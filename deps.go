@@ -0,0 +1,367 @@
+// deps.go
+// Template'ler arası bağımlılık grafiği (extends, include, component) ve döngü tespiti
+package hipoengine
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Bağımlılık türleri
+const (
+	DepExtends   = "extends"
+	DepInclude   = "include"
+	DepComponent = "component"
+)
+
+// Dependency, From template'inin To template'ine olan bağımlılığıdır.
+type Dependency struct {
+	From string
+	To   string
+	Kind string // DepExtends, DepInclude veya DepComponent
+}
+
+// DependencyGraph, template'ler arası bağımlılıkları tutar. Aynı dosyaya alias, arama yolu veya
+// doğrudan yol ile yapılan referanslar tek bir düğümde birleşir; düğümler ilk görülen adlarıyla
+// raporlanır.
+type DependencyGraph struct {
+	key     func(name string) string
+	names   map[string]string // key -> görünen ad
+	out     map[string][]Dependency
+	in      map[string][]Dependency
+	missing map[string]bool // çözülemeyen (bulunamayan) template'ler
+}
+
+func newDependencyGraph(key func(string) string) *DependencyGraph {
+	return &DependencyGraph{
+		key:     key,
+		names:   make(map[string]string),
+		out:     make(map[string][]Dependency),
+		in:      make(map[string][]Dependency),
+		missing: make(map[string]bool),
+	}
+}
+
+// DependencyGraph, verilen template'leri ve bağımlı oldukları tüm template'leri parse ederek
+// bağımlılık grafiğini oluşturur. Dosya verilmezse template arama yollarındaki tüm .hipo dosyaları
+// (TemplateFiles) kullanılır. Bulunamayan bağımlılıklar Missing ile raporlanır; verilen
+// dosyalardan biri bulunamaz veya parse edilemezse hata döner.
+func (e *Engine) DependencyGraph(files ...string) (*DependencyGraph, error) {
+	if len(files) == 0 {
+		all, err := e.TemplateFiles()
+		if err != nil {
+			return nil, err
+		}
+		files = all
+	}
+	g := newDependencyGraph(e.templateKey)
+	roots := make(map[string]bool, len(files))
+	for _, f := range files {
+		roots[e.templateKey(f)] = true
+	}
+	seen := make(map[string]bool)
+	queue := append([]string{}, files...)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		key := g.node(name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if _, err := e.resolveTemplatePath(name); err != nil {
+			if roots[key] {
+				return nil, err
+			}
+			g.missing[key] = true
+			continue
+		}
+		ast, err := e.ParseFile(name)
+		if err != nil {
+			if roots[key] {
+				return nil, err
+			}
+			continue
+		}
+		info := Analyze(ast)
+		for kind, targets := range map[string][]string{DepExtends: info.Extends, DepInclude: info.Includes, DepComponent: info.Components} {
+			for _, target := range targets {
+				g.add(Dependency{From: g.names[key], To: target, Kind: kind})
+				queue = append(queue, target)
+			}
+		}
+	}
+	for key := range g.out {
+		sortDeps(g.out[key])
+	}
+	for key := range g.in {
+		sortDeps(g.in[key])
+	}
+	return g, nil
+}
+
+// TemplateFiles, template arama yollarındaki tüm .hipo dosyalarını arama yoluna göreli adlarıyla
+// sıralı döndürür. Önceki bir arama yolundaki aynı adlı dosyanın gölgelediği dosyalar ve "." ile
+// başlayan dizinler atlanır. Varsayılan "." arama yolu yalnızca başka yol eklenmemişse taranır.
+func (e *Engine) TemplateFiles() ([]string, error) {
+	dirs := e.templatePaths
+	if len(dirs) > 1 && dirs[0] == "." {
+		dirs = dirs[1:]
+	}
+	seen := make(map[string]bool)
+	var files []string
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path != dir && strings.HasPrefix(d.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if filepath.Ext(path) != ".hipo" {
+				return nil
+			}
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			name := filepath.ToSlash(rel)
+			if seen[name] || e.templateKey(name) != filepath.Clean(path) {
+				return nil
+			}
+			seen[name] = true
+			files = append(files, name)
+			return nil
+		})
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// templateKey, template adını dosya kimliğine çevirir (alias ve arama yolları çözülür).
+func (e *Engine) templateKey(name string) string {
+	if resolved, err := e.resolveTemplatePath(name); err == nil {
+		return filepath.Clean(resolved)
+	}
+	return name
+}
+
+// node, template'i grafiğe ekler ve anahtarını döndürür.
+func (g *DependencyGraph) node(name string) string {
+	key := g.key(name)
+	if _, ok := g.names[key]; !ok {
+		g.names[key] = name
+	}
+	return key
+}
+
+func (g *DependencyGraph) add(d Dependency) {
+	from := g.node(d.From)
+	to := g.node(d.To)
+	d.To = g.names[to]
+	g.out[from] = append(g.out[from], d)
+	g.in[to] = append(g.in[to], d)
+}
+
+func sortDeps(deps []Dependency) {
+	sort.Slice(deps, func(i, j int) bool {
+		if deps[i].From != deps[j].From {
+			return deps[i].From < deps[j].From
+		}
+		if deps[i].To != deps[j].To {
+			return deps[i].To < deps[j].To
+		}
+		return deps[i].Kind < deps[j].Kind
+	})
+}
+
+// Files, grafikteki tüm template'leri sıralı döndürür.
+func (g *DependencyGraph) Files() []string {
+	files := make([]string, 0, len(g.names))
+	for _, name := range g.names {
+		files = append(files, name)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// Edges, tüm bağımlılıkları sıralı döndürür.
+func (g *DependencyGraph) Edges() []Dependency {
+	var edges []Dependency
+	for _, deps := range g.out {
+		edges = append(edges, deps...)
+	}
+	sortDeps(edges)
+	return edges
+}
+
+// Dependencies, template'in doğrudan bağımlılıklarını döndürür.
+func (g *DependencyGraph) Dependencies(name string) []Dependency {
+	return g.out[g.key(name)]
+}
+
+// Dependents, template'e doğrudan veya dolaylı olarak bağımlı olan tüm template'leri döndürür.
+// ör: Dependents("partials/footer.hipo") footer değiştiğinde yeniden render edilmesi gerekenleri verir.
+func (g *DependencyGraph) Dependents(name string) []string {
+	start := g.key(name)
+	seen := map[string]bool{start: true}
+	queue := []string{start}
+	var result []string
+	for len(queue) > 0 {
+		key := queue[0]
+		queue = queue[1:]
+		for _, d := range g.in[key] {
+			from := g.key(d.From)
+			if seen[from] {
+				continue
+			}
+			seen[from] = true
+			result = append(result, g.names[from])
+			queue = append(queue, from)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Missing, hedefi bulunamayan bağımlılıkları döndürür.
+func (g *DependencyGraph) Missing() []Dependency {
+	var deps []Dependency
+	for key := range g.missing {
+		deps = append(deps, g.in[key]...)
+	}
+	sortDeps(deps)
+	return deps
+}
+
+// Cycles, grafikteki döngüleri döndürür. Her döngü, döngüye girilen template ile başlar ve
+// biter (ör: [a.hipo b.hipo a.hipo]).
+func (g *DependencyGraph) Cycles() [][]string {
+	keys := make([]string, 0, len(g.names))
+	for key := range g.names {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return g.names[keys[i]] < g.names[keys[j]] })
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int)
+	var stack []string
+	var cycles [][]string
+	var visit func(key string)
+	visit = func(key string) {
+		state[key] = visiting
+		stack = append(stack, key)
+		for _, d := range g.out[key] {
+			to := g.key(d.To)
+			switch state[to] {
+			case unvisited:
+				visit(to)
+			case visiting:
+				var cycle []string
+				for i := len(stack) - 1; i >= 0; i-- {
+					if stack[i] == to {
+						for _, k := range stack[i:] {
+							cycle = append(cycle, g.names[k])
+						}
+						break
+					}
+				}
+				cycles = append(cycles, append(cycle, g.names[to]))
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[key] = done
+	}
+	for _, key := range keys {
+		if state[key] == unvisited {
+			visit(key)
+		}
+	}
+	return cycles
+}
+
+// CycleError, render sırasında tespit edilen döngüsel template bağımlılığıdır. extends döngüleri
+// hiç sonlanamayacağı için hemen reddedilir; include/component döngüleri (ör: ağaç render eden
+// recursive partial'lar) meşru olabileceğinden recursion derinliği aşıldığında raporlanır.
+type CycleError struct {
+	Cycle []string // döngüye girilen template ile başlayıp biten zincir
+	Err   error    // include/component döngülerinde derinlik limiti hatası
+}
+
+func (e *CycleError) Error() string {
+	msg := "döngüsel template bağımlılığı: " + strings.Join(e.Cycle, " -> ")
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *CycleError) Unwrap() error {
+	return e.Err
+}
+
+// fileStack, render zincirindeki (include, component ve extends ile girilen) template dosyalarıdır.
+type fileStack struct {
+	key     string
+	name    string
+	extends bool // extends ile girilen base template
+	parent  *fileStack
+}
+
+// pushFile, dosyayı ctx'in render zincirine ekler.
+func (ctx *Context) pushFile(name string, extends bool) {
+	key := name
+	if ctx.engine != nil {
+		key = ctx.engine.templateKey(name)
+	}
+	ctx.files = &fileStack{key: key, name: name, extends: extends, parent: ctx.files}
+}
+
+// cycle, zincirin tepesindeki dosya zincirde daha önce görülmüşse o noktadan başlayan döngüyü
+// döndürür. extendsOnly ise arama, tepedeki dosyanın extends zinciriyle sınırlıdır.
+func (f *fileStack) cycle(extendsOnly bool) []string {
+	if f == nil {
+		return nil
+	}
+	names := []string{f.name}
+	for p := f.parent; p != nil; p = p.parent {
+		names = append(names, p.name)
+		if p.key == f.key {
+			for i, j := 0, len(names)-1; i < j; i, j = i+1, j-1 {
+				names[i], names[j] = names[j], names[i]
+			}
+			return names
+		}
+		if extendsOnly && !p.extends {
+			break
+		}
+	}
+	return nil
+}
+
+// withCycle, recursion derinliği hatasını, render zincirinde tekrar eden bir dosya varsa döngü
+// bilgisiyle sarar.
+func withCycle(ctx *Context, err error) error {
+	var depth *depthError
+	var cycle *CycleError
+	if !errors.As(err, &depth) || errors.As(err, &cycle) {
+		return err
+	}
+	if names := ctx.files.cycle(false); names != nil {
+		return &CycleError{Cycle: names, Err: err}
+	}
+	return err
+}
@@ -0,0 +1,120 @@
+package hipoengine
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDependencyGraph(t *testing.T) {
+	e := NewEngine()
+	e.AddTemplatePath("testdata/deps")
+	g, err := e.DependencyGraph("page.hipo", "about.hipo")
+	if err != nil {
+		t.Fatalf("DependencyGraph error: %v", err)
+	}
+	if got, want := g.Dependents("partials/footer.hipo"), []string{"about.hipo", "page.hipo", "partials/card.hipo"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Dependents(footer): got %v, want %v", got, want)
+	}
+	if got, want := g.Dependents("partials/nav.hipo"), []string{"layouts/base.hipo", "page.hipo"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Dependents(nav): got %v, want %v", got, want)
+	}
+	// Aynı dosyaya farklı yolla yapılan referans aynı düğümdür
+	if got, want := g.Dependents("testdata/deps/partials/copyright.hipo"), []string{"about.hipo", "page.hipo", "partials/card.hipo", "partials/footer.hipo"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Dependents(copyright): got %v, want %v", got, want)
+	}
+	want := []Dependency{
+		{From: "page.hipo", To: "layouts/base.hipo", Kind: DepExtends},
+		{From: "page.hipo", To: "partials/footer.hipo", Kind: DepInclude},
+	}
+	if got := g.Dependencies("page.hipo"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Dependencies(page): got %v, want %v", got, want)
+	}
+	if got := g.Missing(); len(got) != 1 || got[0].From != "about.hipo" || got[0].To != "partials/missing.hipo" {
+		t.Errorf("Missing: got %v", got)
+	}
+	if cycles := g.Cycles(); len(cycles) != 0 {
+		t.Errorf("beklenmeyen döngü: %v", cycles)
+	}
+}
+
+func TestDependencyGraphCycles(t *testing.T) {
+	e := NewEngine()
+	e.AddTemplatePath("testdata/deps")
+	g, err := e.DependencyGraph("cycle/a.hipo")
+	if err != nil {
+		t.Fatalf("DependencyGraph error: %v", err)
+	}
+	want := [][]string{{"cycle/a.hipo", "cycle/b.hipo", "cycle/a.hipo"}}
+	if got := g.Cycles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Cycles: got %v, want %v", got, want)
+	}
+	g, err = e.DependencyGraph("cycle/ext_a.hipo")
+	if err != nil {
+		t.Fatalf("DependencyGraph error: %v", err)
+	}
+	want = [][]string{{"cycle/ext_a.hipo", "cycle/ext_b.hipo", "cycle/ext_a.hipo"}}
+	if got := g.Cycles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Cycles (extends): got %v, want %v", got, want)
+	}
+	if _, err := e.DependencyGraph("yok.hipo"); err == nil {
+		t.Error("bulunamayan kök template için hata bekleniyordu")
+	}
+}
+
+func TestDependencyGraphScansTemplatePaths(t *testing.T) {
+	e := NewEngine()
+	e.AddTemplatePath("testdata/deps")
+	files, err := e.TemplateFiles()
+	if err != nil {
+		t.Fatalf("TemplateFiles error: %v", err)
+	}
+	want := []string{"about.hipo", "cycle/a.hipo", "cycle/b.hipo", "cycle/ext_a.hipo", "cycle/ext_b.hipo", "layouts/base.hipo", "page.hipo",
+		"partials/card.hipo", "partials/copyright.hipo", "partials/footer.hipo", "partials/nav.hipo"}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("TemplateFiles: got %v, want %v", files, want)
+	}
+	// Kök verilmeden tüm arama yolları taranır; footer'a bağlı sayfalar listelenmeden bulunur
+	g, err := e.DependencyGraph()
+	if err != nil {
+		t.Fatalf("DependencyGraph error: %v", err)
+	}
+	if got, want := g.Dependents("partials/footer.hipo"), []string{"about.hipo", "page.hipo", "partials/card.hipo"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Dependents(footer): got %v, want %v", got, want)
+	}
+	if got := g.Cycles(); len(got) != 2 {
+		t.Errorf("Cycles: 2 döngü bekleniyordu, gelen %v", got)
+	}
+}
+
+func TestRenderReportsCycles(t *testing.T) {
+	e := NewEngine()
+	e.AddTemplatePath("testdata/deps")
+	e.SetMaxRecursionDepth(5)
+
+	var cycleErr *CycleError
+	_, err := e.RenderFile("cycle/ext_a.hipo", nil)
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("extends döngüsü için CycleError bekleniyordu, gelen: %v", err)
+	}
+	if want := []string{"cycle/ext_a.hipo", "cycle/ext_b.hipo", "cycle/ext_a.hipo"}; !reflect.DeepEqual(cycleErr.Cycle, want) {
+		t.Errorf("extends döngüsü: got %v, want %v", cycleErr.Cycle, want)
+	}
+
+	_, err = e.RenderFile("cycle/a.hipo", nil)
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("include döngüsü için CycleError bekleniyordu, gelen: %v", err)
+	}
+	if c := cycleErr.Cycle; len(c) != 3 || c[0] != c[2] || c[0] == c[1] {
+		t.Errorf("include döngüsü: beklenmeyen zincir %v", c)
+	}
+	var depth *depthError
+	if !errors.As(err, &depth) {
+		t.Errorf("include döngüsü derinlik hatasını sarmalı: %v", err)
+	}
+
+	// Aynı partial'ın tekrar kullanılması döngü değildir
+	if _, err := e.RenderFile("about.hipo", map[string]interface{}{}); errors.As(err, &cycleErr) {
+		t.Errorf("beklenmeyen döngü hatası: %v", err)
+	}
+}
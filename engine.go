@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -15,11 +16,15 @@ import (
 
 // Engine, template engine'in ana yapısıdır. Filtre, fonksiyon, cache ve context yönetimini içerir.
 type Engine struct {
-	filters   map[string]FilterFunc
-	funcs     map[string]Function
-	cache     map[string]ASTNode        // template cache (ana template için)
-	fileCache map[string]fileCacheEntry // dosya içeriği cache
-	cacheMu   sync.RWMutex              // cache için mutex
+	filters    map[string]FilterFunc
+	funcs      map[string]Function
+	tests      map[string]TestFunc
+	tags       map[string]*tagSpec
+	filterDocs map[string]string         // SetFilterDoc ile eklenen filtre açıklamaları
+	cache      map[string]ASTNode        // template cache (ana template için)
+	programs   map[string]programEntry   // bytecode modunda dosyalardan derlenmiş programlar (dosya adı -> kaynak ve program)
+	fileCache  map[string]fileCacheEntry // dosya içeriği cache
+	cacheMu    sync.RWMutex              // cache için mutex
 
 	templatePaths   []string
 	templateAliases map[string]string
@@ -39,13 +44,20 @@ type Engine struct {
 	AllowedFilters map[string]bool
 	AllowedFuncs   map[string]bool
 	AllowedVars    map[string]bool
+	AllowedTests   map[string]bool
 	DebugMode      bool
 	DebugLogger    func(msg string)
 	currentLocale  string // dinamik dil için
 
+	MaxRecursionDepth int  // recursive loop/macro/include derinlik limiti (0: DefaultMaxRecursionDepth)
+	Bytecode          bool // Render/RenderFile template'leri bytecode VM ile çalıştırır (coverage modunda kullanılmaz)
+	Minify            bool // render çıktısına MinifyHTML uygulanır (NewEngine'de açık)
+
 	Profiler    *Profiler
 	LastTrace   *RenderTrace
 	AuditLogger AuditLogFunc
+
+	coverage *Coverage // EnableCoverage ile açılır
 }
 
 type fileCacheEntry struct {
@@ -58,10 +70,16 @@ func NewEngine() *Engine {
 	for k, v := range DefaultFilters {
 		filters[k] = v
 	}
+	tests := make(map[string]TestFunc)
+	for k, v := range DefaultTests {
+		tests[k] = v
+	}
 	e := &Engine{
 		filters:           filters,
 		funcs:             make(map[string]Function),
+		tests:             tests,
 		cache:             make(map[string]ASTNode),
+		programs:          make(map[string]programEntry),
 		fileCache:         make(map[string]fileCacheEntry),
 		templatePaths:     []string{"."},
 		templateAliases:   make(map[string]string),
@@ -74,9 +92,11 @@ func NewEngine() *Engine {
 		AllowedFilters:    nil,
 		AllowedFuncs:      nil,
 		AllowedVars:       nil,
+		AllowedTests:      nil,
 		DebugMode:         false,
 		DebugLogger:       nil,
 		currentLocale:     "",
+		Minify:            true,
 		Profiler:          NewProfiler(),
 		LastTrace:         nil,
 		AuditLogger:       nil,
@@ -106,6 +126,42 @@ func (e *Engine) RegisterFilter(name string, filter FilterFunc) {
 	e.filters[name] = filter
 }
 
+// SetFilterDoc, filtre için editör hover/tamamlamada gösterilecek açıklamayı ayarlar.
+func (e *Engine) SetFilterDoc(name, doc string) {
+	if e.filterDocs == nil {
+		e.filterDocs = make(map[string]string)
+	}
+	e.filterDocs[name] = doc
+}
+
+// FilterDoc, filtrenin açıklamasını döndürür (SetFilterDoc, yoksa FilterDocs).
+func (e *Engine) FilterDoc(name string) string {
+	if doc, ok := e.filterDocs[name]; ok {
+		return doc
+	}
+	return FilterDocs[name]
+}
+
+// FilterNames, kayıtlı filtre adlarını sıralı döndürür.
+func (e *Engine) FilterNames() []string {
+	names := make([]string, 0, len(e.filters))
+	for name := range e.filters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FunctionNames, kayıtlı fonksiyon adlarını sıralı döndürür.
+func (e *Engine) FunctionNames() []string {
+	names := make([]string, 0, len(e.funcs))
+	for name := range e.funcs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // RegisterFunction, yeni bir fonksiyon kaydeder.
 func (e *Engine) RegisterFunction(name string, fn Function) {
 	if _, exists := e.funcs[name]; exists {
@@ -114,6 +170,15 @@ func (e *Engine) RegisterFunction(name string, fn Function) {
 	e.funcs[name] = fn
 }
 
+// RegisterTest, {{ if x is name }} ifadelerinde kullanılacak yeni bir test kaydeder.
+func (e *Engine) RegisterTest(name string, test TestFunc) {
+	if _, exists := e.tests[name]; exists {
+		fmt.Fprintf(os.Stderr, "[hipoengine] Uyarı: '%s' isimli test zaten kayıtlı, üzerine yazılıyor.\n", name)
+	}
+	e.tests[name] = test
+}
+
+// ParseFile, dosyanın <template> bloğunu parse eder ve AST'yi cache'ler.
 func (e *Engine) ParseFile(filename string) (ASTNode, error) {
 	resolved, err := e.resolveTemplatePath(filename)
 	if err != nil {
@@ -130,8 +195,11 @@ func (e *Engine) ParseFile(filename string) (ASTNode, error) {
 	if err != nil {
 		return nil, err
 	}
-	parser := NewParser(string(data))
-	ast, err := parser.Parse()
+	desc, err := ParseSFC(string(data))
+	if err != nil {
+		return nil, withFile(err, filename)
+	}
+	ast, err := e.renderParser(desc, filename, false).Parse()
 	if err != nil {
 		return nil, err
 	}
@@ -141,6 +209,82 @@ func (e *Engine) ParseFile(filename string) (ASTNode, error) {
 	return ast, nil
 }
 
+// newParser, engine'e kayıtlı özel tag'ları tanıyan bir parser oluşturur.
+func (e *Engine) newParser(template, filename string) *Parser {
+	p := NewParserWithFile(template, filename)
+	p.tags = e.tagSpecs()
+	return p
+}
+
+// tagSpecs, kayıtlı özel tag'ların o anki haritasını döndürür. RegisterTag haritayı yerinde
+// değiştirmez, yenisini atar; dönen harita okunurken değişmez.
+func (e *Engine) tagSpecs() map[string]*tagSpec {
+	e.cacheMu.RLock()
+	defer e.cacheMu.RUnlock()
+	return e.tags
+}
+
+// templateParser, SFC dosyasının <template> bloğu için parser oluşturur. Satır/sütun bilgileri
+// bloğun içeriğine göre değil, dosyanın tamamına göre hesaplanır. trim true ise içeriğin
+// başındaki ve sonundaki boşluklar atılır.
+func (e *Engine) templateParser(desc *SFCDescriptor, filename string, trim bool) *Parser {
+	p := e.newParser(desc.Source, filename)
+	if desc.Template == nil {
+		if fm := desc.FrontMatter; fm != nil {
+			// Front matter template'e dahil edilmez; satır/sütunlar yine dosyaya göre hesaplanır
+			p.template = desc.Source[fm.End:]
+			p.source = desc.Source
+			p.base = fm.End
+		}
+		return p
+	}
+	start := desc.Template.ContentStart
+	end := start + len(desc.Template.Content)
+	if trim {
+		for start < end && strings.ContainsRune(" \t\r\n", rune(desc.Source[start])) {
+			start++
+		}
+		for end > start && strings.ContainsRune(" \t\r\n", rune(desc.Source[end-1])) {
+			end--
+		}
+	}
+	p.template = desc.Source[start:end]
+	p.source = desc.Source
+	p.base = start
+	p.scope = scopeFor(desc.Styles, filename)
+	return p
+}
+
+// renderParser, render edilecek dosya için templateParser'ı döndürür; coverage modunda parser
+// dosyanın dal ve gövde bölgelerini kaydeder.
+func (e *Engine) renderParser(desc *SFCDescriptor, filename string, trim bool) *Parser {
+	p := e.templateParser(desc, filename, trim)
+	if e.coverage != nil && filename != "" {
+		name := filename
+		if resolved, err := e.resolveTemplatePath(filename); err == nil {
+			name = resolved
+		}
+		p.cov = e.coverage.file(name, desc.Source)
+	}
+	return p
+}
+
+// ClearCache, parse edilmiş template (ParseFile), bytecode programı ve dosya içeriği
+// cache'lerini temizler. Template, veri veya çeviri dosyaları değiştiğinde (ör: hipo serve)
+// çağrılmalıdır; ParseFile cache'i dosya değişikliklerini kendiliğinden algılamaz.
+func (e *Engine) ClearCache() {
+	e.cacheMu.Lock()
+	e.clearCaches()
+	e.cacheMu.Unlock()
+}
+
+// clearCaches, cache'leri temizler; çağıran cacheMu'yu tutmalıdır.
+func (e *Engine) clearCaches() {
+	e.cache = make(map[string]ASTNode)
+	e.programs = make(map[string]programEntry)
+	e.fileCache = make(map[string]fileCacheEntry)
+}
+
 // Dosya içeriğini thread-safe cache'le
 func (e *Engine) ReadFileCached(filename string) (string, error) {
 	resolved, err := e.resolveTemplatePath(filename)
@@ -191,17 +335,63 @@ func (e *Engine) mergeContext(ctx map[string]interface{}) map[string]interface{}
 
 // Render, verilen template stringini ve context'i render eder.
 func (e *Engine) Render(template string, ctx map[string]interface{}) (string, error) {
-	e.lastContext = ctx
-	ctx = e.mergeContext(ctx)
-	parser := NewParser(template)
-	ast, err := parser.Parse()
+	exec, err := e.executor("", template, e.newParser(template, "").Parse)
 	if err != nil {
 		return "", err
 	}
+	return e.execute(exec, ctx, newAssetCollector())
+}
+
+// programEntry, bir dosyadan derlenen bytecode programı ve derlendiği kaynaktır.
+type programEntry struct {
+	source string
+	prog   *Program
+}
+
+// executor, template'i engine ayarına göre tree-walking veya bytecode VM ile çalıştıran fonksiyonu
+// döndürür. Bytecode modunda dosyalardan derlenen programlar dosya adıyla cache'lenir; dosya
+// tekrar render edildiğinde kaynağı aynıysa parse ve derleme yapılmaz, değiştiyse program
+// yeniden derlenip eskisinin yerine yazılır. Render ile verilen satır içi template'ler cache'lenmez;
+// dinamik üretilen template'ler belleği büyütmez.
+func (e *Engine) executor(filename, source string, parse func() (ASTNode, error)) (func(ctx *Context) (string, error), error) {
+	if !e.Bytecode || e.coverage != nil {
+		ast, err := parse()
+		if err != nil {
+			return nil, err
+		}
+		return ast.Execute, nil
+	}
+	if filename != "" {
+		e.cacheMu.RLock()
+		entry, ok := e.programs[filename]
+		e.cacheMu.RUnlock()
+		if ok && entry.source == source {
+			return entry.prog.Run, nil
+		}
+	}
+	ast, err := parse()
+	if err != nil {
+		return nil, err
+	}
+	prog := CompileProgram(ast)
+	if filename != "" {
+		e.cacheMu.Lock()
+		e.programs[filename] = programEntry{source: source, prog: prog}
+		e.cacheMu.Unlock()
+	}
+	return prog.Run, nil
+}
+
+// execute, parse edilmiş (veya derlenmiş) bir template'i global context ile render eder. Render
+// sırasında toplanan script/style blokları assets üzerinden çıktıya yerleştirilir.
+func (e *Engine) execute(exec func(ctx *Context) (string, error), ctx map[string]interface{}, assets *assetCollector) (string, error) {
+	e.lastContext = ctx
+	ctx = e.mergeContext(ctx)
 	context := NewContext(ctx, e.funcs, e.filters, e)
+	context.assets = assets
 
 	start := time.Now()
-	result, err := ast.Execute(context)
+	result, err := exec(context)
 	dur := time.Since(start)
 	if e.Profiler != nil {
 		e.Profiler.Add("Render", "template", dur)
@@ -224,7 +414,7 @@ func (e *Engine) Render(template string, ctx map[string]interface{}) (string, er
 	if err != nil {
 		return "", err
 	}
-	return MinifyHTML(result), nil // otomatik minify!
+	return e.minify(assets.apply(result)), nil
 }
 
 // RenderWithLayout, view ve layout dosyalarını birleştirerek render eder.
@@ -236,7 +426,10 @@ func (e *Engine) RenderWithLayout(viewFile string, layoutFile string, ctx map[st
 	}
 	viewBlocks := SplitBlocks(viewContent)
 	viewTpl := viewBlocks.Template
-	viewBlockMap, err := ParseBlocks(viewTpl)
+	viewScope := scopeFor(viewBlocks.Styles, viewFile)
+	viewParser := e.newParser("", viewFile)
+	viewParser.scope = viewScope
+	viewBlockMap, err := viewParser.parseBlocks(viewTpl, -1)
 	if err != nil {
 		return "", fmt.Errorf("View block parse hatası: %w", err)
 	}
@@ -247,11 +440,19 @@ func (e *Engine) RenderWithLayout(viewFile string, layoutFile string, ctx map[st
 	layoutBlocks := SplitBlocks(layoutContent)
 	layoutTpl := layoutBlocks.Template
 	layoutTpl = strings.Replace(layoutTpl, "{{ embed }}", viewTpl, 1)
-	ast, err := NewParser(layoutTpl).ParseWithBlocks(viewBlockMap)
+	layoutScope := scopeFor(layoutBlocks.Styles, layoutFile)
+	layoutParser := e.newParser(layoutTpl, layoutFile)
+	layoutParser.scope = layoutScope
+	ast, err := layoutParser.ParseWithBlocks(viewBlockMap)
 	if err != nil {
 		return "", fmt.Errorf("Layout parse hatası: %w", err)
 	}
 	context := NewContext(ctx, e.funcs, e.filters, e)
+	context.assets = newAssetCollector()
+	context.assets.add(scopedStyles(layoutBlocks.Styles, layoutScope)...)
+	context.assets.add(layoutBlocks.Scripts...)
+	context.assets.add(scopedStyles(viewBlocks.Styles, viewScope)...)
+	context.assets.add(viewBlocks.Scripts...)
 
 	start := time.Now()
 	html, err := ast.Execute(context)
@@ -276,17 +477,7 @@ func (e *Engine) RenderWithLayout(viewFile string, layoutFile string, ctx map[st
 	if err != nil {
 		return "", fmt.Errorf("Layout render hatası: %w", err)
 	}
-	finalScript := strings.TrimSpace(layoutBlocks.Script + "\n" + viewBlocks.Script)
-	finalStyle := strings.TrimSpace(layoutBlocks.Style + "\n" + viewBlocks.Style)
-	output := ""
-	if finalScript != "" {
-		output += "<script>\n" + finalScript + "\n</script>\n"
-	}
-	output += html
-	if finalStyle != "" {
-		output += "\n<style>\n" + finalStyle + "\n</style>"
-	}
-	return output, nil
+	return context.assets.apply(html), nil
 }
 
 // RenderFile, verilen dosya adını ve context'i render eder.
@@ -296,21 +487,40 @@ func (e *Engine) RenderFile(filename string, ctx map[string]interface{}) (string
 	if err != nil {
 		return "", err
 	}
-	blocks := SplitBlocks(content)
-	html := ""
-	if blocks.Template != "" {
-		html, err = e.Render(blocks.Template, ctx)
-		if err != nil {
-			return "", err
-		}
+	desc, err := ParseSFC(content)
+	if err != nil {
+		return "", withFile(err, filename)
 	}
-	out := ""
-	if blocks.Script != "" {
-		out += "<script>\n" + blocks.Script + "\n</script>\n"
+	exec, err := e.executor(filename, content, e.renderParser(desc, filename, false).Parse)
+	if err != nil {
+		return "", err
 	}
-	out += html
-	if blocks.Style != "" {
-		out += "\n<style>\n" + blocks.Style + "\n</style>"
+	return e.renderFile(filename, ctx, start, fileAssets(desc, filename), exec)
+}
+
+// RenderCompiled, CompileGo ile üretilen render fonksiyonlarını RenderFile ile aynı şekilde
+// (global context, asset toplama, minify, profiler ve trace) çalıştırır.
+func (e *Engine) RenderCompiled(filename string, ctx map[string]interface{}, assets []*SFCBlock, render func(ctx *Context) (string, error)) (string, error) {
+	return e.renderFile(filename, ctx, time.Now(), assets, render)
+}
+
+// fileAssets, dosyanın script ve (scope'lanmış) style bloklarını döndürür.
+func fileAssets(desc *SFCDescriptor, filename string) []*SFCBlock {
+	blocks := append([]*SFCBlock{}, desc.Scripts...)
+	return append(blocks, scopedStyles(desc.Styles, scopeFor(desc.Styles, filename))...)
+}
+
+// renderFile, RenderFile ve RenderCompiled'in ortak render, profiler ve trace adımlarıdır.
+func (e *Engine) renderFile(filename string, ctx map[string]interface{}, start time.Time, blocks []*SFCBlock, exec func(ctx *Context) (string, error)) (string, error) {
+	assets := newAssetCollector()
+	assets.add(blocks...)
+	run := func(context *Context) (string, error) {
+		context.pushFile(filename, false)
+		return exec(context)
+	}
+	out, err := e.execute(run, ctx, assets)
+	if err != nil {
+		return "", err
 	}
 	dur := time.Since(start)
 	if e.Profiler != nil {
@@ -330,7 +540,7 @@ func (e *Engine) RenderFile(filename string, ctx map[string]interface{}) (string
 	if e.AuditLogger != nil {
 		e.AuditLogger(user, filename, trace.ContextSummary, dur, err == nil, err)
 	}
-	return MinifyHTML(out), nil
+	return out, nil
 }
 
 // RenderFileContext, zincirli context ile dosya render eder.
@@ -340,27 +550,36 @@ func (e *Engine) RenderFileContext(filename string, ctx *Context) (string, error
 	if err != nil {
 		return "", err
 	}
-	blocks := SplitBlocks(content)
-	html := ""
-	if blocks.Template != "" {
-		parser := NewParser(blocks.Template)
-		ast, err := parser.Parse()
+	desc, err := ParseSFC(content)
+	if err != nil {
+		return "", withFile(err, filename)
+	}
+	// Render'ın asset toplayıcısı varsa bloklar oraya eklenir ve çıktıya tek sefer yerleştirilir;
+	// yoksa bu dosya için bir toplayıcı oluşturulur.
+	owner := ctx.assets == nil
+	if owner {
+		ctx.assets = newAssetCollector()
+		defer func() { ctx.assets = nil }()
+	}
+	ctx.assets.add(desc.Scripts...)
+	ctx.assets.add(scopedStyles(desc.Styles, scopeFor(desc.Styles, filename))...)
+	prev := ctx.files
+	ctx.pushFile(filename, false)
+	defer func() { ctx.files = prev }()
+	out := ""
+	if desc.Template != nil && desc.Template.Content != "" {
+		ast, err := e.renderParser(desc, filename, false).Parse()
 		if err != nil {
 			return "", err
 		}
 		result, err := ast.Execute(ctx)
 		if err != nil {
-			return "", err
+			return "", withCycle(ctx, err)
 		}
-		html = result
+		out = result
 	}
-	out := ""
-	if blocks.Script != "" {
-		out += "<script>\n" + blocks.Script + "\n</script>\n"
-	}
-	out += html
-	if blocks.Style != "" {
-		out += "\n<style>\n" + blocks.Style + "\n</style>"
+	if owner {
+		out = ctx.assets.apply(out)
 	}
 	dur := time.Since(start)
 	if e.Profiler != nil {
@@ -384,7 +603,20 @@ func (e *Engine) RenderFileContext(filename string, ctx *Context) (string, error
 			ctx.engine.AuditLogger(user, filename, trace.ContextSummary, dur, err == nil, err)
 		}
 	}
-	return MinifyHTML(out), nil
+	return e.minify(out), nil
+}
+
+// minify, Minify açıksa çıktıyı MinifyHTML ile sadeleştirir.
+func (e *Engine) minify(out string) string {
+	if !e.Minify {
+		return out
+	}
+	return MinifyHTML(out)
+}
+
+// ResolveTemplatePath, template adını alias ve arama yollarına göre dosya yoluna çevirir.
+func (e *Engine) ResolveTemplatePath(name string) (string, error) {
+	return e.resolveTemplatePath(name)
 }
 
 // Template dosya yolunu alias ve arama yollarına göre çözer
@@ -657,6 +889,16 @@ func (ctx *Context) SetStrictMode(strict bool) {
 	ctx.StrictMode = strict
 }
 
+// Bytecode mode
+func (e *Engine) SetBytecodeMode(enabled bool) {
+	e.Bytecode = enabled
+}
+
+// Minify mode
+func (e *Engine) SetMinifyMode(enabled bool) {
+	e.Minify = enabled
+}
+
 // Safe mode
 func (e *Engine) SetSafeMode(safe bool) {
 	e.SafeMode = safe
@@ -728,3 +970,19 @@ func (ctx *Context) SetAllowedVars(vars []string) {
 	}
 	ctx.AllowedVars = m
 }
+
+// Allowed tests
+func (e *Engine) SetAllowedTests(tests []string) {
+	m := make(map[string]bool)
+	for _, t := range tests {
+		m[t] = true
+	}
+	e.AllowedTests = m
+}
+func (ctx *Context) SetAllowedTests(tests []string) {
+	m := make(map[string]bool)
+	for _, t := range tests {
+		m[t] = true
+	}
+	ctx.AllowedTests = m
+}
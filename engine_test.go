@@ -1,6 +1,8 @@
 package hipoengine
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -247,3 +249,197 @@ func TestLookupNamespaceWithTestMap(t *testing.T) {
 		t.Errorf("lookupNamespace 'cart.items' bulamadı (test map)")
 	}
 }
+
+func TestForBreakContinue(t *testing.T) {
+	e := NewEngine()
+	ctx := map[string]interface{}{"items": []interface{}{1, 2, 3, 4, 5}}
+	tpl := `{{ for i in items }}{{ if i == 2 }}{{ continue }}{{ endif }}{{ if i == 4 }}{{ break }}{{ endif }}{{ i }}{{ endfor }}`
+	out, err := e.Render(tpl, ctx)
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	// continue ile kesilen eleman satır sonu eklemez; break'ten önceki elemanın satır sonu kalır
+	if out != "1\n3\n" {
+		t.Errorf("Beklenen: '1\\n3\\n', Gerçek: %q", out)
+	}
+}
+
+func TestForSeparatorWithEmptyItems(t *testing.T) {
+	// Çıktı üretmeyen elemanlar da satır sonu ekler; yalnızca continue ile kesilenler eklemez
+	cases := map[string]string{
+		`{{ for y in ys }}{{ y }}{{ endfor }}`:                                            "a\n\nc",
+		`{{ for y in ys }}{{ if y == "" }}{{ continue }}{{ endif }}{{ y }}{{ endfor }}`:   "a\nc",
+		`{{ for y in ys }}{{ y }}{{ if y == "a" }}{{ continue }}{{ endif }}!{{ endfor }}`: "a\n!\nc!",
+	}
+	for _, bytecode := range []bool{false, true} {
+		e := NewEngine()
+		e.SetBytecodeMode(bytecode)
+		for tpl, want := range cases {
+			out, err := e.Render(tpl, map[string]interface{}{"ys": []interface{}{"a", "", "c"}})
+			if err != nil {
+				t.Fatalf("Render error: %v", err)
+			}
+			if out != want {
+				t.Errorf("bytecode=%v %s: beklenen %q, gerçek %q", bytecode, tpl, want, out)
+			}
+		}
+	}
+}
+
+func TestForInlineFilterAndLoopInfo(t *testing.T) {
+	e := NewEngine()
+	ctx := map[string]interface{}{
+		"products": []interface{}{
+			map[string]interface{}{"name": "A", "active": true},
+			map[string]interface{}{"name": "B", "active": false},
+			map[string]interface{}{"name": "C", "active": true},
+		},
+	}
+	tpl := `{{ for p in products if p.active }}{{ loop.index }}/{{ loop.length }}:{{ p.name }}{{ if loop.last }}.{{ endif }}{{ endfor }}`
+	out, err := e.Render(tpl, ctx)
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if out != "1/2:A\n2/2:C." {
+		t.Errorf("Beklenen: '1/2:A\\n2/2:C.', Gerçek: %q", out)
+	}
+}
+
+func TestBreakOutsideLoop(t *testing.T) {
+	e := NewEngine()
+	_, err := e.Render(`{{ break }}`, map[string]interface{}{})
+	if err == nil {
+		t.Fatal("for dışında break için hata bekleniyordu")
+	}
+}
+
+func TestNestedIfAndFor(t *testing.T) {
+	e := NewEngine()
+	ctx := map[string]interface{}{
+		"rows": []interface{}{
+			map[string]interface{}{"cells": []interface{}{"a", "b"}},
+		},
+		"x": true,
+		"y": false,
+	}
+	out, err := e.Render(`{{ for r in rows }}{{ for c in r.cells }}{{ c }}{{ endfor }}{{ endfor }}`, ctx)
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if out != "a\nb" {
+		t.Errorf("Beklenen: 'a\\nb', Gerçek: %q", out)
+	}
+	out, err = e.Render(`{{ if x }}{{ if y }}1{{ else }}2{{ endif }}{{ else }}3{{ endif }}`, ctx)
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if out != "2" {
+		t.Errorf("Beklenen: '2', Gerçek: %q", out)
+	}
+}
+
+func TestConditionLiteralOperands(t *testing.T) {
+	// before: literal'lerin değişken adı olarak çözüldüğü eski davranış (boş string, sayısal 0).
+	// Değişken operandlı karşılaştırmalar değişmedi; literal operandlar artık değer olarak okunur.
+	ctx := map[string]interface{}{"n": 5, "one": 1, "s": "a", "t": true, "xs": []interface{}{1, 2, 3}}
+	cases := []struct{ tpl, before, want string }{
+		{`{{ for i in xs if i > 1 }}{{ i }}{{ endfor }}`, "1\n2\n3", "2\n3"},
+		{`{{ for i in xs }}{{ if i > 1 }}{{ i }}{{ endif }}{{ endfor }}`, "1\n2\n3", "\n2\n3"},
+		{`{{ if n == 5 }}A{{ endif }}`, "", "A"},
+		{`{{ if n == 5.0 }}A{{ endif }}`, "", "A"},
+		{`{{ if n < 10 }}A{{ endif }}`, "", "A"},
+		{`{{ if 2 > 1 }}A{{ endif }}`, "", "A"},
+		{`{{ if s == "a" }}A{{ endif }}`, "", "A"},
+		{`{{ if s == 'a' }}A{{ endif }}`, "", "A"},
+		{`{{ if s != "a" }}A{{ endif }}`, "A", ""},
+		{`{{ if t == true }}A{{ endif }}`, "", "A"},
+		{`{{ if missing == none }}A{{ endif }}`, "A", ""},
+		{`{{ if n >= 5 }}A{{ endif }}`, "A", "A"},
+		{`{{ if n > one }}A{{ endif }}`, "A", "A"},
+		{`{{ if missing == "" }}A{{ endif }}`, "A", "A"},
+	}
+	for _, bytecode := range []bool{false, true} {
+		e := NewEngine()
+		e.SetBytecodeMode(bytecode)
+		for _, c := range cases {
+			out, err := e.Render(c.tpl, ctx)
+			if err != nil {
+				t.Fatalf("Render error (%s): %v", c.tpl, err)
+			}
+			if out != c.want {
+				t.Errorf("bytecode=%v %s: beklenen %q (eski: %q), gerçek %q", bytecode, c.tpl, c.want, c.before, out)
+			}
+		}
+	}
+}
+
+func TestSwitchCase(t *testing.T) {
+	e := NewEngine()
+	tpl := `{{ switch order.status }}{{ case "paid", "shipped" }}Ödendi{{ case "cancelled" }}İptal{{ default }}Bekliyor{{ endswitch }}`
+	cases := map[string]string{"paid": "Ödendi", "shipped": "Ödendi", "cancelled": "İptal", "new": "Bekliyor"}
+	for status, want := range cases {
+		ctx := map[string]interface{}{"order": map[string]interface{}{"status": status}}
+		out, err := e.Render(tpl, ctx)
+		if err != nil {
+			t.Fatalf("Render error: %v", err)
+		}
+		if out != want {
+			t.Errorf("%s: beklenen '%s', gerçek '%s'", status, want, out)
+		}
+	}
+
+	// Tipli karşılaştırma: sayı ile string eşleşmez, int ve float eşleşir
+	tpl = `{{ switch code }}{{ case "1" }}string{{ case 1 }}sayı{{ endswitch }}`
+	out, err := e.Render(tpl, map[string]interface{}{"code": 1.0})
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if out != "sayı" {
+		t.Errorf("Beklenen: 'sayı', Gerçek: '%s'", out)
+	}
+}
+
+func TestSwitchEvaluatesSubjectOnce(t *testing.T) {
+	e := NewEngine()
+	calls := 0
+	e.RegisterFunction("status", func(args ...interface{}) interface{} {
+		calls++
+		return "c"
+	})
+	out, err := e.Render(`{{ switch status() }}{{ case "a" }}A{{ case "b" }}B{{ case "c" }}C{{ endswitch }}`, map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if out != "C" || calls != 1 {
+		t.Errorf("Beklenen: 'C' ve 1 çağrı, Gerçek: '%s' ve %d çağrı", out, calls)
+	}
+}
+
+func TestClearCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "page.hipo")
+	if err := os.WriteFile(path, []byte("<template>eski</template>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	e := NewEngine()
+	render := func() string {
+		ast, err := e.ParseFile(path)
+		if err != nil {
+			t.Fatalf("ParseFile error: %v", err)
+		}
+		out, _ := ast.Execute(NewContext(nil, nil, nil, e))
+		return out
+	}
+	if out := render(); out != "eski" {
+		t.Fatalf("Beklenen: 'eski', Gerçek: '%s'", out)
+	}
+	if err := os.WriteFile(path, []byte("<template>yeni</template>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if out := render(); out != "eski" {
+		t.Errorf("ParseFile cache'i kullanılmalıydı, Gerçek: '%s'", out)
+	}
+	e.ClearCache()
+	if out := render(); out != "yeni" {
+		t.Errorf("ClearCache sonrası Beklenen: 'yeni', Gerçek: '%s'", out)
+	}
+}
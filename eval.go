@@ -0,0 +1,56 @@
+// eval.go
+// Template dışında tek bir ifadeyi değerlendirme (Engine.Eval / Engine.EvalBool)
+package hipoengine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Eval, bir ifadeyi {{ set x = ... }} sağ tarafıyla aynı gramerle değerlendirir; engine'e kayıtlı
+// filtre, fonksiyon ve global context kullanılır.
+//
+//	v, err := engine.Eval(`user.name|upper`, data)
+func (e *Engine) Eval(expr string, data map[string]interface{}) (interface{}, error) {
+	expr, err := checkExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+	if val, ok := parseLiteral(expr); ok {
+		return val, nil
+	}
+	node, err := e.newParser("", "").parseExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+	return node.ExecuteRaw(e.evalContext(data))
+}
+
+// EvalBool, bir koşulu {{ if ... }} ile aynı kurallarla (karşılaştırmalar, is testleri) değerlendirir.
+func (e *Engine) EvalBool(expr string, data map[string]interface{}) (bool, error) {
+	expr, err := checkExpr(expr)
+	if err != nil {
+		return false, err
+	}
+	if err := checkCondition(expr); err != nil {
+		return false, err
+	}
+	return evalBool(expr, e.evalContext(data)), nil
+}
+
+// evalContext, Render ile aynı şekilde global context ve context processor'larla birleştirilmiş bir context oluşturur.
+func (e *Engine) evalContext(data map[string]interface{}) *Context {
+	return NewContext(e.mergeContext(data), e.funcs, e.filters, e)
+}
+
+// checkExpr, ifadenin boş olmadığını ve tag sınırlayıcıları içermediğini kontrol eder.
+func checkExpr(expr string) (string, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return "", fmt.Errorf("boş ifade")
+	}
+	if strings.Contains(expr, "{{") || strings.Contains(expr, "}}") {
+		return "", fmt.Errorf("ifade {{ }} içermemeli: %s", expr)
+	}
+	return expr, nil
+}
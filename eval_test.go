@@ -0,0 +1,58 @@
+package hipoengine
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEngineEval(t *testing.T) {
+	e := NewEngine()
+	e.SetGlobalContext(map[string]interface{}{"site": "Hipo"})
+	data := map[string]interface{}{
+		"user":  map[string]interface{}{"name": "emre", "age": 21},
+		"items": []interface{}{1, 2, 3},
+	}
+	cases := map[string]interface{}{
+		`user.name|upper`: "EMRE",
+		`user.age`:        21,
+		`"sabit"`:         "sabit",
+		`42`:              42,
+		`site`:            "Hipo",
+	}
+	for expr, want := range cases {
+		got, err := e.Eval(expr, data)
+		if err != nil {
+			t.Fatalf("Eval(%s) error: %v", expr, err)
+		}
+		if got != want {
+			t.Errorf("Eval(%s): beklenen %#v, gerçek %#v", expr, want, got)
+		}
+	}
+	if _, err := e.Eval("{{ user }}", data); err == nil || !strings.Contains(err.Error(), "{{") {
+		t.Errorf("Tag sınırlayıcıları içeren ifade hata vermeliydi: %v", err)
+	}
+}
+
+func TestEngineEvalBool(t *testing.T) {
+	e := NewEngine()
+	data := map[string]interface{}{"user": map[string]interface{}{"age": 21}, "n": 4}
+	cases := map[string]bool{
+		`user.age >= 18`:       true,
+		`user.age < 18`:        false,
+		`n is even`:            true,
+		`user.city is defined`: false,
+		`user`:                 true,
+	}
+	for expr, want := range cases {
+		got, err := e.EvalBool(expr, data)
+		if err != nil {
+			t.Fatalf("EvalBool(%s) error: %v", expr, err)
+		}
+		if got != want {
+			t.Errorf("EvalBool(%s): beklenen %v, gerçek %v", expr, want, got)
+		}
+	}
+	if _, err := e.EvalBool("  ", data); err == nil {
+		t.Errorf("Boş ifade hata vermeliydi")
+	}
+}
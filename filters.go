@@ -327,3 +327,36 @@ var DefaultFilters = map[string]FilterFunc{
 		return re.ReplaceAllString(s, repl)
 	},
 }
+
+// FilterDocs, built-in filtrelerin kısa açıklamalarıdır (editör hover ve tamamlama için).
+// Uygulama filtreleri için Engine.SetFilterDoc kullanılır.
+var FilterDocs = map[string]string{
+	"upper":         "Metni büyük harfe çevirir. `{{ name|upper }}`",
+	"lower":         "Metni küçük harfe çevirir. `{{ name|lower }}`",
+	"length":        "String veya listenin uzunluğunu döndürür. `{{ items|length }}`",
+	"trim":          "Baştaki ve sondaki boşlukları siler. `{{ text|trim }}`",
+	"title":         "Her kelimenin ilk harfini büyütür. `{{ name|title }}`",
+	"reverse":       "Metni ters çevirir. `{{ name|reverse }}`",
+	"default":       "Değer boş veya tanımsızsa verilen değeri döndürür. `{{ name|default:\"Anonim\" }}`",
+	"safe":          "Çıktıyı HTML escape etmeden yazar. `{{ html|safe }}`",
+	"date":          "time.Time değerini Go formatıyla biçimlendirir (varsayılan 2006-01-02). `{{ created|date:\"02.01.2006\" }}`",
+	"join":          "Listeyi ayraçla birleştirir (varsayılan \",\"). `{{ tags|join:\", \" }}`",
+	"add":           "Sayıya verilen değeri ekler. `{{ count|add:1 }}`",
+	"money":         "Ondalıklı sayıyı iki basamakla yazar. `{{ price|money }}`",
+	"truncate":      "Metni verilen uzunlukta keser ve \"...\" ekler (varsayılan 10). `{{ text|truncate:20 }}`",
+	"slice":         "Liste veya metnin [başlangıç:bitiş) aralığını döndürür. `{{ items|slice:0,3 }}`",
+	"replace":       "Metindeki tüm eşleşmeleri değiştirir. `{{ text|replace:\"a\",\"b\" }}`",
+	"abs":           "Sayının mutlak değerini döndürür. `{{ diff|abs }}`",
+	"yesno":         "Doğru/yanlış değeri metne çevirir (varsayılan evet/hayır). `{{ active|yesno:\"açık\",\"kapalı\" }}`",
+	"sort":          "String veya int listesini sıralar. `{{ names|sort }}`",
+	"uniq":          "Listedeki tekrar eden elemanları çıkarır. `{{ tags|uniq }}`",
+	"split":         "Metni ayraçla listeye böler (varsayılan \",\"). `{{ csv|split:\";\" }}`",
+	"slugify":       "Metni URL dostu hale getirir, Türkçe karakterleri dönüştürür. `{{ title|slugify }}`",
+	"startswith":    "Metnin verilen önekle başlayıp başlamadığını döndürür. `{{ path|startswith:\"/admin\" }}`",
+	"endswith":      "Metnin verilen sonekle bitip bitmediğini döndürür. `{{ file|endswith:\".pdf\" }}`",
+	"pad":           "Metni sağdan boşlukla verilen genişliğe tamamlar. `{{ code|pad:8 }}`",
+	"ljust":         "Metni sola yaslayıp verilen genişliğe tamamlar. `{{ name|ljust:10 }}`",
+	"rjust":         "Metni sağa yaslayıp verilen genişliğe tamamlar. `{{ price|rjust:10 }}`",
+	"humanize":      "time.Time değerini \"5 dakika önce\" gibi göreli metne çevirir. `{{ created|humanize }}`",
+	"regex_replace": "Regexp ile eşleşen kısımları değiştirir. `{{ phone|regex_replace:\"[^0-9]\",\"\" }}`",
+}
@@ -0,0 +1,502 @@
+// format.go
+// Kayıpsız sözdizimi ağacı ve hipo fmt için kanonik template formatlayıcı
+package hipoengine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SyntaxKind, sözdizimi ağacındaki node türüdür.
+type SyntaxKind int
+
+const (
+	SyntaxText  SyntaxKind = iota // düz metin
+	SyntaxTag                     // tek bir {{ ... }} tag'ı
+	SyntaxBlock                   // açılış tag'ı, gövde, ara tag'lar (elif, else, case...) ve kapanış tag'ı
+	SyntaxSFC                     // dosya seviyesindeki <template>, <script>, <style> veya özel blok
+)
+
+// SyntaxNode, kaynağın hiçbir karakterini kaybetmeyen sözdizimi ağacı node'udur. Block node'larının
+// çocukları açılış tag'ı ile başlar ve kapanış tag'ı ile biter; SFC template bloğunun çocukları
+// içeriğin node'larıdır.
+type SyntaxNode struct {
+	Kind     SyntaxKind
+	Token    Token     // SyntaxText ve SyntaxTag için; Offset/Line/Column dosyaya göredir
+	SFC      *SFCBlock // SyntaxSFC için
+	Children []*SyntaxNode
+}
+
+// SyntaxTree, bir .hipo dosyasının sözdizimi ağacıdır. String kaynağı birebir geri üretir.
+type SyntaxTree struct {
+	Source string
+	Nodes  []*SyntaxNode
+}
+
+// String, ağacı kaynak metne geri çevirir.
+func (t *SyntaxTree) String() string {
+	var sb strings.Builder
+	for _, n := range t.Nodes {
+		t.write(&sb, n)
+	}
+	return sb.String()
+}
+
+func (t *SyntaxTree) write(sb *strings.Builder, n *SyntaxNode) {
+	switch n.Kind {
+	case SyntaxText, SyntaxTag:
+		sb.WriteString(n.Token.Raw)
+	case SyntaxBlock:
+		for _, c := range n.Children {
+			t.write(sb, c)
+		}
+	case SyntaxSFC:
+		b := n.SFC
+		contentEnd := b.ContentStart + len(b.Content)
+		sb.WriteString(t.Source[b.Start:b.ContentStart])
+		if n.Children == nil {
+			sb.WriteString(b.Content)
+		}
+		for _, c := range n.Children {
+			t.write(sb, c)
+		}
+		sb.WriteString(t.Source[contentEnd:b.End])
+	}
+}
+
+// ParseSyntax, kaynağı SFC bloklarıyla birlikte kayıpsız sözdizimi ağacına ayırır. <template>
+// bloğu yoksa kaynağın tamamı template olarak ayrıştırılır. Eşleşmeyen blok tag'ları TemplateError döner.
+func (e *Engine) ParseSyntax(src, filename string) (*SyntaxTree, error) {
+	desc, err := ParseSFC(src)
+	if err != nil {
+		return nil, withFile(err, filename)
+	}
+	tree := &SyntaxTree{Source: src}
+	if desc.Template == nil {
+		tree.Nodes, err = e.syntaxNodes(src, 0, len(src), filename)
+		return tree, err
+	}
+	prev := 0
+	for _, b := range desc.Blocks {
+		if b.Start > prev {
+			tree.Nodes = append(tree.Nodes, syntaxText(src, prev, b.Start))
+		}
+		node := &SyntaxNode{Kind: SyntaxSFC, SFC: b}
+		if b.Type == "template" {
+			node.Children, err = e.syntaxNodes(src, b.ContentStart, b.ContentStart+len(b.Content), filename)
+			if err != nil {
+				return nil, err
+			}
+			if node.Children == nil {
+				node.Children = []*SyntaxNode{}
+			}
+		}
+		tree.Nodes = append(tree.Nodes, node)
+		prev = b.End
+	}
+	if prev < len(src) {
+		tree.Nodes = append(tree.Nodes, syntaxText(src, prev, len(src)))
+	}
+	return tree, nil
+}
+
+func syntaxText(src string, start, end int) *SyntaxNode {
+	line, col := getLineCol(src, start)
+	text := src[start:end]
+	return &SyntaxNode{Kind: SyntaxText, Token: Token{Kind: TokenText, Raw: text, Content: text, Offset: start, Line: line, Column: col}}
+}
+
+// syntaxNodes, src'nin [start:end) aralığındaki template içeriğini blok yapısına göre ağaçlaştırır.
+func (e *Engine) syntaxNodes(src string, start, end int, filename string) ([]*SyntaxNode, error) {
+	content := src[start:end]
+	baseLine, baseCol := getLineCol(src, start)
+	tokens := Tokenize(content)
+	tags := e.tagSpecs()
+	for i := range tokens {
+		if tokens[i].Line == 1 {
+			tokens[i].Column += baseCol - 1
+		}
+		tokens[i].Line += baseLine - 1
+		tokens[i].Offset += start
+	}
+	type frame struct {
+		node *SyntaxNode
+		end  string
+	}
+	root := &SyntaxNode{Kind: SyntaxBlock}
+	stack := []frame{{node: root}}
+	errAt := func(tok Token, format string, args ...interface{}) error {
+		return &TemplateError{File: filename, Line: tok.Line, Column: tok.Column, Message: fmt.Sprintf(format, args...)}
+	}
+	for i, tok := range tokens {
+		top := stack[len(stack)-1]
+		leaf := &SyntaxNode{Kind: SyntaxText, Token: tok}
+		if tok.Kind != TokenTag {
+			top.node.Children = append(top.node.Children, leaf)
+			continue
+		}
+		leaf.Kind = SyntaxTag
+		name := tok.Name
+		end := blockEnds[name]
+		if spec, ok := tags[name]; ok {
+			end = spec.endTag
+		}
+		if name == "slot" {
+			// {{ slot }} yalnızca bir sonraki slot tag'ı endslot ise gövde alır (parser ile aynı kural)
+			end = ""
+			for _, next := range tokens[i+1:] {
+				if next.Kind == TokenTag && (next.Name == "slot" || next.Name == "endslot") {
+					if next.Name == "endslot" {
+						end = "endslot"
+					}
+					break
+				}
+			}
+		}
+		switch {
+		case end != "":
+			block := &SyntaxNode{Kind: SyntaxBlock, Children: []*SyntaxNode{leaf}}
+			top.node.Children = append(top.node.Children, block)
+			stack = append(stack, frame{node: block, end: end})
+		case name == "elif" || name == "else" || name == "case" || name == "default":
+			owner := "if"
+			if name == "case" || name == "default" {
+				owner = "switch"
+			}
+			if len(stack) == 1 || top.node.Children[0].Token.Name != owner {
+				return nil, errAt(tok, "{{ %s }} bir %s bloğu içinde değil", name, owner)
+			}
+			top.node.Children = append(top.node.Children, leaf)
+		case len(stack) > 1 && name != "" && top.end == name:
+			top.node.Children = append(top.node.Children, leaf)
+			stack = stack[:len(stack)-1]
+		case isEndTag(name, tags):
+			if len(stack) == 1 {
+				return nil, errAt(tok, "{{ %s }} için açılış tag'ı yok", name)
+			}
+			return nil, errAt(tok, "{{ %s }} beklenirken {{ %s }} bulundu", top.end, name)
+		default:
+			top.node.Children = append(top.node.Children, leaf)
+		}
+	}
+	if len(stack) > 1 {
+		open := stack[len(stack)-1].node.Children[0].Token
+		return nil, errAt(open, "{{ %s }} kapatılmamış, {{ %s }} eksik", open.Name, stack[len(stack)-1].end)
+	}
+	return root.Children, nil
+}
+
+// FormatOptions, formatlayıcı ayarlarıdır.
+type FormatOptions struct {
+	Indent string // blok gövdeleri için girinti birimi (varsayılan 4 boşluk)
+}
+
+// Format, .hipo kaynağını kanonik biçimde yeniden üretir:
+//   - tag'lar {{ name args }} şeklinde tek boşlukla yazılır, tırnak dışındaki boşluklar teke
+//     indirilir ve filtre ayracı boşluksuz yazılır: {{ user.name|upper }}
+//   - birden fazla satıra yayılan blokların ara ve kapanış tag'ları açılış tag'ının satırıyla
+//     aynı girintiye, gövdeleri bir birim içeriye alınır (gövdedeki göreli HTML girintisi korunur)
+//   - satır sonu boşlukları silinir, art arda boş satırlar teke indirilir
+//   - SFC blokları arasında bir boş satır bırakılır, dosya tek bir satır sonuyla biter
+//
+// <pre>, <textarea>, <script> ve <style> içerikleri değiştirilmez.
+func (e *Engine) Format(src, filename string, opts FormatOptions) (string, error) {
+	if opts.Indent == "" {
+		opts.Indent = "    "
+	}
+	tree, err := e.ParseSyntax(src, filename)
+	if err != nil {
+		return "", err
+	}
+	hasSFC := false
+	for _, n := range tree.Nodes {
+		if n.Kind == SyntaxSFC {
+			hasSFC = true
+		}
+	}
+	if !hasSFC {
+		return trimBlankLines(formatTemplate(tree.Nodes, opts.Indent)) + "\n", nil
+	}
+	var parts []string
+	for _, n := range tree.Nodes {
+		if n.Kind != SyntaxSFC {
+			if text := strings.TrimSpace(n.Token.Raw); text != "" {
+				parts = append(parts, text)
+			}
+			continue
+		}
+		b := n.SFC
+		open := tree.Source[b.Start:b.ContentStart]
+		closeTag := tree.Source[b.ContentStart+len(b.Content) : b.End]
+		var body string
+		switch b.Type {
+		case "template":
+			body = trimBlankLines(formatTemplate(n.Children, opts.Indent))
+		case "script", "style":
+			body = trimBlankLines(b.Content)
+		default:
+			parts = append(parts, open+b.Content+closeTag)
+			continue
+		}
+		if body == "" {
+			parts = append(parts, open+closeTag)
+		} else {
+			parts = append(parts, open+"\n"+body+"\n"+closeTag)
+		}
+	}
+	return strings.Join(parts, "\n\n") + "\n", nil
+}
+
+// trimBlankLines, baştaki boş satırları ve sondaki boşlukları atar.
+func trimBlankLines(s string) string {
+	for {
+		nl := strings.IndexByte(s, '\n')
+		if nl == -1 || strings.TrimSpace(s[:nl]) != "" {
+			break
+		}
+		s = s[nl+1:]
+	}
+	if strings.TrimSpace(s) == "" {
+		return ""
+	}
+	return strings.TrimRight(s, " \t\r\n")
+}
+
+// canonicalTag, tag içeriğini kanonik hale getirir.
+func canonicalTag(content string) string {
+	var sb strings.Builder
+	quote := byte(0)
+	space := false
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		if quote != 0 {
+			sb.WriteByte(c)
+			if c == quote && content[i-1] != '\\' {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case ' ', '\t', '\r', '\n':
+			space = true
+			continue
+		case '|':
+			space = false
+			sb.WriteByte(c)
+			for i+1 < len(content) && strings.IndexByte(" \t\r\n", content[i+1]) != -1 {
+				i++
+			}
+			continue
+		case '"', '\'':
+			quote = c
+		}
+		if space && sb.Len() > 0 {
+			sb.WriteByte(' ')
+		}
+		space = false
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
+
+// Satırın ilk öğesinin blok içindeki rolü
+const (
+	roleNone = iota
+	roleOpen
+	roleMiddle
+	roleEnd
+)
+
+// fmtItem, formatlanacak template'in düzleştirilmiş bir parçasıdır.
+type fmtItem struct {
+	text  string
+	tag   bool
+	role  int
+	block int
+}
+
+// fmtLine, formatlayıcının işlediği tek bir çıktı satırıdır.
+type fmtLine struct {
+	indent    string // kaynaktaki girinti
+	body      string
+	owner     int // satır başında en içteki açık blok (-1: yok)
+	segment   int // owner bloğunun kaçıncı gövdesi (elif/else/case sonrası artar)
+	lead      int // satırın ilk öğesi owner'ın ara/kapanış tag'ı ise roleMiddle/roleEnd
+	frozen    bool
+	hasIndent bool // satır başındaki boşluklar okunuyor
+}
+
+// formatTemplate, template node'larını kanonik tag'lar ve blok girintisiyle yeniden yazar.
+func formatTemplate(nodes []*SyntaxNode, unit string) string {
+	var items []fmtItem
+	blocks := 0
+	var flatten func(nodes []*SyntaxNode)
+	flatten = func(nodes []*SyntaxNode) {
+		for _, n := range nodes {
+			switch n.Kind {
+			case SyntaxText:
+				items = append(items, fmtItem{text: n.Token.Raw})
+			case SyntaxTag:
+				items = append(items, fmtItem{text: "{{ " + canonicalTag(n.Token.Content) + " }}", tag: true})
+			case SyntaxBlock:
+				id := blocks
+				blocks++
+				last := len(n.Children) - 1
+				for i, c := range n.Children {
+					if c.Kind != SyntaxTag || (i != 0 && i != last && !isMiddleTag(c.Token.Name)) {
+						flatten([]*SyntaxNode{c})
+						continue
+					}
+					role := roleMiddle
+					if i == 0 {
+						role = roleOpen
+					} else if i == last {
+						role = roleEnd
+					}
+					items = append(items, fmtItem{text: "{{ " + canonicalTag(c.Token.Content) + " }}", tag: true, role: role, block: id})
+				}
+			}
+		}
+	}
+	flatten(nodes)
+
+	// Satırlara ayır
+	var stack []int
+	segments := make(map[int]int)
+	openLine := make(map[int]int)
+	raw := ""
+	var lines []*fmtLine
+	newLine := func() *fmtLine {
+		l := &fmtLine{owner: -1, hasIndent: true, frozen: raw != ""}
+		if len(stack) > 0 {
+			l.owner = stack[len(stack)-1]
+			l.segment = segments[l.owner]
+		}
+		lines = append(lines, l)
+		return l
+	}
+	cur := newLine()
+	for _, it := range items {
+		if it.tag {
+			if cur.hasIndent && it.block == cur.owner && (it.role == roleMiddle || it.role == roleEnd) {
+				cur.lead = it.role
+			}
+			cur.hasIndent = false
+			switch it.role {
+			case roleOpen:
+				stack = append(stack, it.block)
+				openLine[it.block] = len(lines) - 1
+			case roleMiddle:
+				segments[it.block]++
+			case roleEnd:
+				stack = stack[:len(stack)-1]
+			}
+			cur.body += it.text
+			continue
+		}
+		for i, seg := range strings.Split(it.text, "\n") {
+			if i > 0 {
+				cur = newLine()
+			}
+			if cur.hasIndent {
+				ws := len(seg) - len(strings.TrimLeft(seg, " \t"))
+				cur.indent += seg[:ws]
+				seg = seg[ws:]
+				if seg != "" {
+					cur.hasIndent = false
+				}
+			}
+			cur.body += seg
+			raw = rawElementState(raw, seg)
+		}
+	}
+
+	// Gövde segmentlerinin kaynaktaki en küçük girintisi
+	type segKey struct{ owner, segment int }
+	baseline := make(map[segKey]int)
+	for _, l := range lines {
+		if l.owner == -1 || l.frozen || l.lead != roleNone || strings.TrimSpace(l.body) == "" {
+			continue
+		}
+		k := segKey{l.owner, l.segment}
+		if b, ok := baseline[k]; !ok || len(l.indent) < b {
+			baseline[k] = len(l.indent)
+		}
+	}
+
+	final := make([]string, len(lines))
+	blockIndent := func(block int) string {
+		return final[openLine[block]]
+	}
+	var out []string
+	for i, l := range lines {
+		if l.frozen {
+			final[i] = l.indent
+			out = append(out, l.indent+l.body)
+			continue
+		}
+		body := strings.TrimRight(l.body, " \t\r")
+		if body == "" {
+			// art arda boş satırlar teke indirilir
+			if len(out) == 0 || out[len(out)-1] != "" {
+				out = append(out, "")
+			}
+			continue
+		}
+		switch {
+		case l.owner == -1:
+			final[i] = l.indent
+		case l.lead != roleNone:
+			final[i] = blockIndent(l.owner)
+		default:
+			base := baseline[segKey{l.owner, l.segment}]
+			final[i] = blockIndent(l.owner) + unit + l.indent[base:]
+		}
+		out = append(out, final[i]+body)
+	}
+	return strings.Join(out, "\n")
+}
+
+// isMiddleTag, tag'ın if/switch bloklarının ara tag'ı olup olmadığını döndürür.
+func isMiddleTag(name string) bool {
+	return name == "elif" || name == "else" || name == "case" || name == "default"
+}
+
+// rawElements, içeriği formatlanmayan HTML elementleridir.
+var rawElements = []string{"pre", "textarea", "script", "style"}
+
+// rawElementState, metin parçasını işledikten sonra içinde bulunulan ham elementi döndürür.
+func rawElementState(raw, text string) string {
+	lower := strings.ToLower(text)
+	for lower != "" {
+		if raw != "" {
+			idx := strings.Index(lower, "</"+raw)
+			if idx == -1 {
+				return raw
+			}
+			lower = lower[idx+2+len(raw):]
+			raw = ""
+			continue
+		}
+		first, name := -1, ""
+		for _, el := range rawElements {
+			idx := strings.Index(lower, "<"+el)
+			if idx == -1 {
+				continue
+			}
+			after := idx + 1 + len(el)
+			if after < len(lower) && !strings.ContainsRune(" \t>/", rune(lower[after])) {
+				continue
+			}
+			if first == -1 || idx < first {
+				first, name = idx, el
+			}
+		}
+		if first == -1 {
+			return ""
+		}
+		raw = name
+		lower = lower[first+1+len(name):]
+	}
+	return raw
+}
@@ -0,0 +1,162 @@
+package hipoengine
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseSyntaxLossless(t *testing.T) {
+	e := NewEngine()
+	for _, file := range []string{"testdata/layouts/layout.hipo", "testdata/views/child.hipo", "testdata/scoped/card.hipo", "testdata/sfc/module.hipo", "testdata/assets/partials/button.hipo"} {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tree, err := e.ParseSyntax(string(src), file)
+		if err != nil {
+			t.Fatalf("%s: ParseSyntax error: %v", file, err)
+		}
+		if got := tree.String(); got != string(src) {
+			t.Errorf("%s: ağaç kaynağı birebir üretmedi:\n%s", file, got)
+		}
+	}
+}
+
+func TestParseSyntaxStructure(t *testing.T) {
+	src := "<!-- not -->\n<template>\n{{ if a }}x{{ elif b }}{{ for i in xs }}{{ i }}{{ endfor }}{{ else }}z{{ endif }}\n</template>\n<style>p{}</style>"
+	tree, err := NewEngine().ParseSyntax(src, "x.hipo")
+	if err != nil {
+		t.Fatalf("ParseSyntax error: %v", err)
+	}
+	if len(tree.Nodes) != 4 || tree.Nodes[1].Kind != SyntaxSFC || tree.Nodes[3].SFC.Type != "style" {
+		t.Fatalf("beklenmeyen üst seviye node'lar: %+v", tree.Nodes)
+	}
+	ifBlock := tree.Nodes[1].Children[1]
+	if ifBlock.Kind != SyntaxBlock || len(ifBlock.Children) != 7 {
+		t.Fatalf("if bloğu hatalı: %+v", ifBlock)
+	}
+	if names := []string{ifBlock.Children[0].Token.Name, ifBlock.Children[2].Token.Name, ifBlock.Children[4].Token.Name, ifBlock.Children[6].Token.Name}; strings.Join(names, ",") != "if,elif,else,endif" {
+		t.Errorf("if bloğu tag'ları: %v", names)
+	}
+	if tok := ifBlock.Children[2].Token; tok.Line != 3 || tok.Column != 12 {
+		t.Errorf("elif konumu dosyaya göre olmalı: %d:%d", tok.Line, tok.Column)
+	}
+
+	_, err = NewEngine().ParseSyntax("<template>\n{{ for x in xs }}{{ endif }}\n</template>", "bad.hipo")
+	te, ok := err.(*TemplateError)
+	if !ok || te.Line != 2 || te.Message != "{{ endfor }} beklenirken {{ endif }} bulundu" {
+		t.Errorf("TemplateError bekleniyordu, got %v", err)
+	}
+}
+
+func TestParseSyntaxWithoutTemplateBlock(t *testing.T) {
+	// <template> bloğu olmayan dosyada <style>/<script> blokları olsa da kaynağın tamamı template'tir
+	src := "{{ if a }}x{{ endif }}\n<style>p { color: red; }</style>"
+	tree, err := NewEngine().ParseSyntax(src, "plain.hipo")
+	if err != nil {
+		t.Fatalf("ParseSyntax error: %v", err)
+	}
+	if len(tree.Nodes) == 0 || tree.Nodes[0].Kind != SyntaxBlock || tree.Nodes[0].Children[0].Token.Name != "if" {
+		t.Fatalf("if bloğu template olarak ayrıştırılmadı: %+v", tree.Nodes)
+	}
+	if got := tree.String(); got != src {
+		t.Errorf("ağaç kaynağı birebir üretmedi: %q", got)
+	}
+
+	_, err = NewEngine().ParseSyntax("{{ for x in xs }}{{ endif }}\n<script>let a = 1</script>", "plain.hipo")
+	if _, ok := err.(*TemplateError); !ok {
+		t.Errorf("eşleşmeyen tag için TemplateError bekleniyordu, got %v", err)
+	}
+}
+
+func TestParseSyntaxEmptyTag(t *testing.T) {
+	// Boş tag'ın adı "" olduğu için kök çerçeveyi kapatmamalı (editörde yarım yazılmış {{ }})
+	e := NewEngine()
+	for _, src := range []string{"{{ }}x", "{{}}{{ y }}", "{{ if a }}{{ }}b{{ endif }}"} {
+		tree, err := e.ParseSyntax(src, "f.hipo")
+		if err != nil {
+			t.Errorf("%q: ParseSyntax error: %v", src, err)
+			continue
+		}
+		if got := tree.String(); got != src {
+			t.Errorf("%q: ağaç kaynağı birebir üretmedi: %q", src, got)
+		}
+	}
+	if _, err := e.Format("{{ }}x", "f.hipo", FormatOptions{}); err != nil {
+		t.Errorf("Format error: %v", err)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	src := `<!-- kart -->
+<template>
+
+
+<ul>
+{{if items}}
+  {{ for item in items }}
+  <li>{{item.name | upper}}   {{ item.price|default : "0" }}</li>   
+  {{endfor}}
+{{ else }}
+<li>boş</li>
+    {{ endif }}
+</ul>
+<pre>
+  {{ raw }}
+</pre>
+</template>
+<style>
+
+p { color: red; }
+</style>
+`
+	want := `<!-- kart -->
+
+<template>
+<ul>
+{{ if items }}
+    {{ for item in items }}
+        <li>{{ item.name|upper }}   {{ item.price|default : "0" }}</li>
+    {{ endfor }}
+{{ else }}
+    <li>boş</li>
+{{ endif }}
+</ul>
+<pre>
+  {{ raw }}
+</pre>
+</template>
+
+<style>
+p { color: red; }
+</style>
+`
+	e := NewEngine()
+	got, err := e.Format(src, "kart.hipo", FormatOptions{})
+	if err != nil {
+		t.Fatalf("Format error: %v", err)
+	}
+	if got != want {
+		t.Errorf("Format sonucu farklı:\n%s\nbeklenen:\n%s", got, want)
+	}
+	again, err := e.Format(got, "kart.hipo", FormatOptions{})
+	if err != nil || again != got {
+		t.Errorf("Format idempotent değil:\n%s", again)
+	}
+	// Formatlanan template aynı çıktıyı üretir
+	data := map[string]interface{}{"items": []interface{}{map[string]interface{}{"name": "a", "price": 3}}}
+	before, _ := e.Render("{{if items}}{{ for item in items }}{{item.name | upper}}{{endfor}}{{ endif }}", data)
+	after, _ := e.Render(strings.TrimSpace(mustFormat(t, e, "{{if items}}{{ for item in items }}{{item.name | upper}}{{endfor}}{{ endif }}")), data)
+	if before != after {
+		t.Errorf("formatlama çıktıyı değiştirdi: %q -> %q", before, after)
+	}
+}
+
+func mustFormat(t *testing.T, e *Engine, src string) string {
+	out, err := e.Format(src, "", FormatOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
@@ -0,0 +1,62 @@
+// hipotest.go
+// Template spec dosyalarını go test içinden alt testler olarak çalıştırma
+package hipotest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"hipoengine"
+)
+
+// UpdateEnv, ayarlandığında ("1" veya "true") golden dosyalarının gerçek çıktıyla yeniden
+// yazılmasını sağlayan ortam değişkenidir:
+//
+//	HIPO_UPDATE=1 go test ./...
+const UpdateEnv = "HIPO_UPDATE"
+
+// Run, verilen dosya ve dizinlerdeki spec dosyalarını (*.test.yaml, *.test.json, *.test.toml)
+// engine ile çalıştırır. Her spec ve senaryo, spec dosyasının adıyla "index.test.yaml/senaryo"
+// şeklinde ayrı bir alt testtir (dizin kısmı "/" alt test ayırıcısı olduğundan ada katılmaz);
+// böylece go test -run 'TestTemplates/index.test.yaml/misafir' ile tek bir senaryo seçilebilir.
+// Uygulamanın filtre, fonksiyon ve template yolları engine'e kayıtlı olmalıdır:
+//
+//	func TestTemplates(t *testing.T) {
+//		engine := views.NewEngine()
+//		hipotest.Run(t, engine, "templates")
+//	}
+func Run(t *testing.T, engine *hipoengine.Engine, paths ...string) {
+	t.Helper()
+	specs, err := hipoengine.FindTestSpecs(paths...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(specs) == 0 {
+		t.Fatalf("%s altında spec dosyası bulunamadı", strings.Join(paths, ", "))
+	}
+	opts := hipoengine.TestOptions{Update: os.Getenv(UpdateEnv) == "1" || os.Getenv(UpdateEnv) == "true"}
+	for _, file := range specs {
+		spec, err := hipoengine.LoadTestSpec(file)
+		if err != nil {
+			t.Errorf("%v", err)
+			continue
+		}
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			for _, c := range spec.Cases {
+				one := *spec
+				one.Cases = []hipoengine.TestCase{c}
+				t.Run(c.Name, func(t *testing.T) {
+					r := engine.RunTestSpec(&one, opts)[0]
+					if r.Updated {
+						t.Logf("golden dosyası güncellendi: %s", c.Golden)
+					}
+					if !r.Passed {
+						t.Error(r.Message)
+					}
+				})
+			}
+		})
+	}
+}
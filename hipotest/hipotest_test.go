@@ -0,0 +1,15 @@
+package hipotest
+
+import (
+	"testing"
+
+	"hipoengine"
+)
+
+func TestRun(t *testing.T) {
+	e := hipoengine.NewEngine()
+	if err := e.SetTranslationsFromDir("../testdata/spectest/locale"); err != nil {
+		t.Fatal(err)
+	}
+	Run(t, e, "../testdata/spectest")
+}
@@ -0,0 +1,363 @@
+// i18n.go
+// Template'lerdeki çeviri anahtarlarını toplama ve çeviri kataloglarını kontrol etme (hipo i18n)
+package hipoengine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TransKey, bir template'te trans(...) ile kullanılan çeviri anahtarıdır.
+type TransKey struct {
+	Key    string `json:"key"`
+	Plural bool   `json:"plural"` // çağrıda count argümanı var
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+// Çeviri kataloğu sorun türleri
+const (
+	TransMissing = "missing" // anahtar bu dilin kataloğunda yok
+	TransUnused  = "unused"  // katalogdaki anahtar hiçbir template'te kullanılmıyor
+	TransPlural  = "plural"  // count ile kullanılan anahtarın çoğul formları eksik
+)
+
+// PluralForms, count ile kullanılan her anahtarda bulunması beklenen çoğul formlardır
+// (trans, 1 için "one", diğer sayılar için "other" formunu seçer; "zero" isteğe bağlıdır).
+var PluralForms = []string{"one", "other"}
+
+// pluralCategories, bir map'in çoğul çeviri olarak tanınmasını sağlayan form adlarıdır.
+var pluralCategories = map[string]bool{"zero": true, "one": true, "two": true, "few": true, "many": true, "other": true}
+
+// TransIssue, CheckTranslations'ın bulduğu tek bir katalog sorunudur.
+type TransIssue struct {
+	Kind  string    `json:"kind"`
+	Lang  string    `json:"lang"`
+	Key   string    `json:"key"`
+	Forms []string  `json:"forms,omitempty"` // TransPlural: eksik çoğul formlar
+	Usage *TransKey `json:"usage,omitempty"` // anahtarın ilk kullanımı; TransUnused'da nil
+}
+
+func (i TransIssue) String() string {
+	var msg string
+	switch i.Kind {
+	case TransMissing:
+		msg = fmt.Sprintf("%s: eksik çeviri: %s", i.Lang, i.Key)
+	case TransUnused:
+		msg = fmt.Sprintf("%s: kullanılmayan çeviri: %s", i.Lang, i.Key)
+	case TransPlural:
+		msg = fmt.Sprintf("%s: eksik çoğul formlar: %s (%s)", i.Lang, i.Key, strings.Join(i.Forms, ", "))
+	}
+	if i.Usage != nil {
+		return fmt.Sprintf("%s:%d:%d: %s", i.Usage.File, i.Usage.Line, i.Usage.Column, msg)
+	}
+	return msg
+}
+
+// ExtractTransKeys, dosyayı okuyup ExtractTransKeysSource ile çeviri anahtarlarını toplar.
+func (e *Engine) ExtractTransKeys(filename string) ([]TransKey, error) {
+	content, err := e.ReadFileCached(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ExtractTransKeysSource(content, filename)
+}
+
+// ExtractTransKeysSource, template'teki trans("anahtar", ...) çağrılarını kaynaktaki sırasıyla
+// döndürür. Anahtarı string sabiti olmayan çağrılar atlanır. Bir çağrı şu durumlarda çoğul
+// (Plural) sayılır: argümanlardan biri sayı sabitiyse, context'ten sonra üçüncü bir argüman
+// verilmişse (trans("k", ctx, count)) ya da ikinci argüman count/total/n adlı bir değişken veya
+// length filtresi içeren bir ifadeyse.
+func ExtractTransKeysSource(src, filename string) ([]TransKey, error) {
+	desc, err := ParseSFC(src)
+	if err != nil {
+		return nil, withFile(err, filename)
+	}
+	content, base := desc.Source, 0
+	if desc.Template != nil {
+		content, base = desc.Template.Content, desc.Template.ContentStart
+	} else if desc.FrontMatter != nil {
+		content, base = desc.Source[desc.FrontMatter.End:], desc.FrontMatter.End
+	}
+	var keys []TransKey
+	for _, tok := range Tokenize(content) {
+		if tok.Kind != TokenTag {
+			continue
+		}
+		for _, call := range transCalls(tok.Raw) {
+			key, ok := stringLiteral(call.args[0])
+			if !ok {
+				continue
+			}
+			line, col := getLineCol(desc.Source, base+tok.Offset+call.offset)
+			keys = append(keys, TransKey{Key: key, Plural: isPluralCall(call.args), File: filename, Line: line, Column: col})
+		}
+	}
+	return keys, nil
+}
+
+// transCall, tag içindeki tek bir trans çağrısıdır; offset "trans" kelimesinin tag içindeki yeridir.
+type transCall struct {
+	offset int
+	args   []string
+}
+
+// transCalls, ifadedeki trans(...) çağrılarını tırnak içlerini ve iç içe parantezleri hesaba
+// katarak bulur.
+func transCalls(s string) []transCall {
+	var calls []transCall
+	quote := byte(0)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if quote != 0 {
+			if c == quote && s[i-1] != '\\' {
+				quote = 0
+			}
+			continue
+		}
+		if c == '"' || c == '\'' {
+			quote = c
+			continue
+		}
+		if !strings.HasPrefix(s[i:], "trans") || (i > 0 && (isIdentChar(s[i-1]) || s[i-1] == '.')) {
+			continue
+		}
+		j := i + len("trans")
+		for j < len(s) && s[j] == ' ' {
+			j++
+		}
+		if j == len(s) || s[j] != '(' {
+			continue
+		}
+		args, end := callArgs(s, j)
+		if len(args) > 0 {
+			calls = append(calls, transCall{offset: i, args: args})
+		}
+		i = end
+	}
+	return calls
+}
+
+// callArgs, s[open] parantezinden başlayan argüman listesini ayırır ve kapanış parantezinin
+// indexini döndürür.
+func callArgs(s string, open int) ([]string, int) {
+	var args []string
+	depth, start := 0, open+1
+	quote := byte(0)
+	for i := open; i < len(s); i++ {
+		c := s[i]
+		if quote != 0 {
+			if c == quote && s[i-1] != '\\' {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '"', '\'':
+			quote = c
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+			if depth == 0 {
+				if arg := strings.TrimSpace(s[start:i]); arg != "" || len(args) > 0 {
+					args = append(args, arg)
+				}
+				return args, i
+			}
+		case ',':
+			if depth == 1 {
+				args = append(args, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return nil, len(s)
+}
+
+// stringLiteral, tek veya çift tırnaklı bir string sabitinin değerini döndürür.
+func stringLiteral(s string) (string, bool) {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1], true
+	}
+	return "", false
+}
+
+func isPluralCall(args []string) bool {
+	for i, arg := range args[1:] {
+		if _, ok := stringLiteral(arg); ok {
+			continue
+		}
+		if isNumeric(arg) || i > 0 {
+			return true
+		}
+		name := strings.TrimSpace(strings.SplitN(arg, "|", 2)[0])
+		if dot := strings.LastIndexByte(name, '.'); dot != -1 {
+			name = name[dot+1:]
+		}
+		name = strings.ToLower(name)
+		if strings.HasSuffix(name, "count") || name == "total" || name == "n" || strings.Contains(arg, "length") {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckTranslations, kullanılan anahtarları dil -> katalog map'ine (SetTranslations ile aynı
+// biçim) göre kontrol eder: her dilde eksik anahtarlar, count ile kullanılan anahtarlarda eksik
+// çoğul formlar (PluralForms) ve hiçbir template'te kullanılmayan katalog anahtarları. Noktalı
+// anahtarlar trans'ta olduğu gibi iç içe map'lerde aranır. Sonuçlar dile, sonra anahtara göre
+// sıralıdır.
+func CheckTranslations(keys []TransKey, translations map[string]interface{}) []TransIssue {
+	used := map[string]*TransKey{}
+	var order []string
+	for _, k := range keys {
+		if u, ok := used[k.Key]; ok {
+			u.Plural = u.Plural || k.Plural
+			continue
+		}
+		k := k
+		used[k.Key] = &k
+		order = append(order, k.Key)
+	}
+	sort.Strings(order)
+	langs := make([]string, 0, len(translations))
+	for lang := range translations {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	var issues []TransIssue
+	for _, lang := range langs {
+		catalog, _ := translations[lang].(map[string]interface{})
+		for _, key := range order {
+			usage := *used[key]
+			v, ok := lookupNamespace(catalog, key)
+			if !ok {
+				issues = append(issues, TransIssue{Kind: TransMissing, Lang: lang, Key: key, Usage: &usage})
+				continue
+			}
+			if !usage.Plural {
+				continue
+			}
+			forms, _ := v.(map[string]interface{})
+			var missing []string
+			for _, form := range PluralForms {
+				if _, ok := forms[form]; !ok {
+					missing = append(missing, form)
+				}
+			}
+			if len(missing) > 0 {
+				issues = append(issues, TransIssue{Kind: TransPlural, Lang: lang, Key: key, Forms: missing, Usage: &usage})
+			}
+		}
+		for _, key := range catalogKeys(catalog, "") {
+			if used[key] == nil {
+				issues = append(issues, TransIssue{Kind: TransUnused, Lang: lang, Key: key})
+			}
+		}
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Lang != issues[j].Lang {
+			return issues[i].Lang < issues[j].Lang
+		}
+		return issues[i].Key < issues[j].Key
+	})
+	return issues
+}
+
+// catalogKeys, katalogdaki çeviri anahtarlarını noktalı yollar olarak sıralı döndürür; çoğul
+// form map'leri tek bir anahtar sayılır.
+func catalogKeys(catalog map[string]interface{}, prefix string) []string {
+	var keys []string
+	for name, v := range catalog {
+		key := prefix + name
+		if m, ok := v.(map[string]interface{}); ok && !isPluralMap(m) {
+			keys = append(keys, catalogKeys(m, key+".")...)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func isPluralMap(m map[string]interface{}) bool {
+	if len(m) == 0 {
+		return false
+	}
+	for form := range m {
+		if !pluralCategories[form] {
+			return false
+		}
+	}
+	return true
+}
+
+// StubTranslations, TransMissing ve TransPlural sorunları için kataloglara yer tutucu ekler ve
+// değişen dilleri sıralı döndürür. Yer tutucu metin anahtarın kendisidir (trans'ın bulunamayan
+// anahtarlarda döndürdüğü değer); tekil bir çeviri çoğul map'e çevrilirken mevcut metin korunur.
+// Var olmayan diller için katalog oluşturulur.
+func StubTranslations(translations map[string]interface{}, issues []TransIssue) []string {
+	changed := map[string]bool{}
+	for _, issue := range issues {
+		if issue.Kind != TransMissing && issue.Kind != TransPlural {
+			continue
+		}
+		catalog, ok := translations[issue.Lang].(map[string]interface{})
+		if !ok {
+			catalog = map[string]interface{}{}
+			translations[issue.Lang] = catalog
+		}
+		var value interface{} = issue.Key
+		if issue.Kind == TransPlural {
+			forms := map[string]interface{}{}
+			v, _ := lookupNamespace(catalog, issue.Key)
+			text := interface{}(issue.Key)
+			if m, ok := v.(map[string]interface{}); ok {
+				forms = m
+				if other, ok := m["other"]; ok {
+					text = other
+				}
+			} else if v != nil {
+				text = v
+			}
+			for _, form := range issue.Forms {
+				forms[form] = text
+			}
+			value = forms
+		} else if issue.Usage != nil && issue.Usage.Plural {
+			forms := map[string]interface{}{}
+			for _, form := range PluralForms {
+				forms[form] = issue.Key
+			}
+			value = forms
+		}
+		if setNamespace(catalog, issue.Key, value) {
+			changed[issue.Lang] = true
+		}
+	}
+	return sortedSet(changed)
+}
+
+// setNamespace, noktalı anahtarı iç içe map'ler oluşturarak yazar. Yoldaki bir ara anahtar map
+// değilse hiçbir şey yazılmaz ve false döner.
+func setNamespace(m map[string]interface{}, key string, value interface{}) bool {
+	parts := strings.Split(key, ".")
+	cur := m
+	for _, part := range parts[:len(parts)-1] {
+		switch next := cur[part].(type) {
+		case map[string]interface{}:
+			cur = next
+		case nil:
+			child := map[string]interface{}{}
+			cur[part] = child
+			cur = child
+		default:
+			return false
+		}
+	}
+	cur[parts[len(parts)-1]] = value
+	return true
+}
@@ -0,0 +1,89 @@
+package hipoengine
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractTransKeys(t *testing.T) {
+	src := `---
+title: x
+---
+<template>
+<h1>{{ trans("welcome", ctx) }}</h1>{{ set label = trans('nav.home') }}
+<p>{{ trans("cart.items", ctx, count) }} {{ trans("files", 3) }} {{ trans("users", users|length) }}</p>
+{{ trans(key) }} {{ "trans('ignored')" }} {{ mytrans("other") }}
+</template>`
+	keys, err := ExtractTransKeysSource(src, "page.hipo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []TransKey{
+		{Key: "welcome", File: "page.hipo", Line: 5, Column: 8},
+		{Key: "nav.home", File: "page.hipo", Line: 5, Column: 52},
+		{Key: "cart.items", Plural: true, File: "page.hipo", Line: 6, Column: 7},
+		{Key: "files", Plural: true, File: "page.hipo", Line: 6, Column: 45},
+		{Key: "users", Plural: true, File: "page.hipo", Line: 6, Column: 69},
+	}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("ExtractTransKeysSource sonucu farklı:\n%v\nbeklenen:\n%v", keys, want)
+	}
+}
+
+func TestCheckTranslations(t *testing.T) {
+	keys := []TransKey{
+		{Key: "welcome", File: "a.hipo", Line: 1, Column: 1},
+		{Key: "cart.items", File: "a.hipo", Line: 2, Column: 1},
+		{Key: "cart.items", Plural: true, File: "b.hipo", Line: 3, Column: 1},
+		{Key: "files", Plural: true, File: "b.hipo", Line: 4, Column: 1},
+	}
+	translations := map[string]interface{}{
+		"en": map[string]interface{}{
+			"welcome": "Welcome!",
+			"bye":     "Bye!",
+			"cart":    map[string]interface{}{"items": map[string]interface{}{"one": "1 item", "other": "{{ count }} items"}},
+			"files":   "files",
+		},
+		"tr": map[string]interface{}{
+			"welcome": "Hoşgeldiniz!",
+			"cart":    map[string]interface{}{"items": map[string]interface{}{"other": "{{ count }} ürün"}},
+		},
+	}
+	issues := CheckTranslations(keys, translations)
+	var got []string
+	for _, i := range issues {
+		got = append(got, i.String())
+	}
+	want := []string{
+		"en: kullanılmayan çeviri: bye",
+		"b.hipo:4:1: en: eksik çoğul formlar: files (one, other)",
+		"a.hipo:2:1: tr: eksik çoğul formlar: cart.items (one)",
+		"b.hipo:4:1: tr: eksik çeviri: files",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("CheckTranslations sonucu farklı:\n%q\nbeklenen:\n%q", got, want)
+	}
+
+	changed := StubTranslations(translations, issues)
+	if !reflect.DeepEqual(changed, []string{"en", "tr"}) {
+		t.Errorf("değişen diller: %v", changed)
+	}
+	en := translations["en"].(map[string]interface{})
+	if !reflect.DeepEqual(en["files"], map[string]interface{}{"one": "files", "other": "files"}) {
+		t.Errorf("en files yer tutucusu: %#v", en["files"])
+	}
+	tr := translations["tr"].(map[string]interface{})
+	if !reflect.DeepEqual(tr["files"], map[string]interface{}{"one": "files", "other": "files"}) {
+		t.Errorf("tr files yer tutucusu: %#v", tr["files"])
+	}
+	items := tr["cart"].(map[string]interface{})["items"]
+	if !reflect.DeepEqual(items, map[string]interface{}{"one": "{{ count }} ürün", "other": "{{ count }} ürün"}) {
+		t.Errorf("tr cart.items yer tutucusu: %#v", items)
+	}
+	if _, ok := en["bye"]; !ok {
+		t.Error("kullanılmayan anahtarlar silinmemeli")
+	}
+	if left := CheckTranslations(keys, translations); len(left) != 1 || left[0].Kind != TransUnused {
+		t.Errorf("yer tutuculardan sonra kalan sorunlar: %v", left)
+	}
+}
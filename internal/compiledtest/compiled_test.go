@@ -0,0 +1,116 @@
+package compiledtest
+
+import (
+	"os"
+	"testing"
+
+	"hipoengine"
+)
+
+const templateDir = "../../testdata/compile"
+
+// compiledFiles, doc.go'daki go:generate satırıyla aynı sırada derlenen template'lerdir.
+var compiledFiles = []string{
+	"page.hipo", "partials/footer.hipo",
+	"render/break_continue.hipo", "render/for.hipo", "render/loop_filter.hipo", "render/macro.hipo",
+	"render/nested.hipo", "render/scope.hipo", "render/simple.hipo", "render/switch.hipo",
+	"render/tests.hipo", "render/vm.hipo",
+}
+
+func newEngine() *hipoengine.Engine {
+	e := hipoengine.NewEngine()
+	e.AddTemplatePath(templateDir)
+	e.SetGlobalContext(map[string]interface{}{"site": "HipoEngine"})
+	e.RegisterFunction("status", func(args ...interface{}) interface{} { return "c" })
+	e.RegisterTest("adult", func(val interface{}, args ...interface{}) bool {
+		n, _ := val.(int)
+		return n >= 18
+	})
+	return e
+}
+
+func item(name string, active bool, tags ...interface{}) map[string]interface{} {
+	return map[string]interface{}{"name": name, "active": active, "tags": tags}
+}
+
+func contexts() []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"title":    "ürünler",
+			"name":     "Emre",
+			"html":     "<i>ok</i>",
+			"user":     map[string]interface{}{"name": "Emre", "age": 21, "role": "admin", "phone": nil},
+			"items":    []interface{}{item("Kalem", true, "a", "b", "c"), item("Silgi", false), item("Defter", true, "b", "c"), item("Cetvel", true)},
+			"letters":  []interface{}{"a", "b", "c"},
+			"numbers":  []interface{}{1, 2, 3, 4, 5},
+			"products": []interface{}{item("A", true), item("B", false), item("C", true)},
+			"rows":     []interface{}{map[string]interface{}{"cells": []interface{}{"a", "b"}}},
+			"x":        true,
+			"y":        false,
+			"order":    map[string]interface{}{"status": "shipped"},
+			"code":     1.0,
+			"n":        4,
+			"empty":    nil,
+			"nothing":  []interface{}{},
+			"xs":       []interface{}{1, 0, "", "z"},
+			"a":        "takma ad",
+			"tree": []interface{}{
+				map[string]interface{}{"name": "kök", "children": []interface{}{
+					map[string]interface{}{"name": "yaprak"},
+				}},
+			},
+		},
+		{
+			"title":    "boş",
+			"user":     map[string]interface{}{"age": 15, "role": "author"},
+			"items":    []interface{}{},
+			"letters":  "not_a_slice",
+			"numbers":  []interface{}{2, 4},
+			"products": []interface{}{item("B", false)},
+			"rows":     []interface{}{},
+			"x":        false,
+			"order":    map[string]interface{}{"status": "new"},
+			"code":     "1",
+			"n":        3,
+			"nothing":  []interface{}{},
+			"xs":       []interface{}{},
+			"tree":     []interface{}{},
+		},
+	}
+}
+
+// TestCompiledOutputMatchesRenderFile, derlenmiş her template'i RenderFile ile aynı context'lerde
+// çalıştırır; çıktılar ve hatalar birebir aynı olmalıdır.
+func TestCompiledOutputMatchesRenderFile(t *testing.T) {
+	e := newEngine()
+	if len(Templates) != len(compiledFiles) {
+		t.Fatalf("%d template derlenmeliydi, %d derlenmiş", len(compiledFiles), len(Templates))
+	}
+	for name, render := range Templates {
+		for i, data := range contexts() {
+			want, werr := e.RenderFile(name, data)
+			got, gerr := render(e, data)
+			if (werr == nil) != (gerr == nil) || (werr != nil && werr.Error() != gerr.Error()) {
+				t.Errorf("%s (context %d) hataları farklı.\nRenderFile: %v\nDerlenmiş:  %v", name, i, werr, gerr)
+				continue
+			}
+			if got != want {
+				t.Errorf("%s (context %d) çıktısı farklı.\nRenderFile:\n%s\nDerlenmiş:\n%s", name, i, want, got)
+			}
+		}
+	}
+}
+
+func TestGeneratedCodeIsUpToDate(t *testing.T) {
+	src, err := newEngine().CompileGo(compiledFiles, hipoengine.CompileOptions{Package: "compiledtest"})
+	if err != nil {
+		t.Fatalf("CompileGo error: %v", err)
+	}
+	current, err := os.ReadFile("templates_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(current) != string(src) {
+		t.Errorf("templates_gen.go güncel değil, 'go generate ./internal/compiledtest' çalıştırın")
+	}
+}
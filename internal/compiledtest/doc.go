@@ -0,0 +1,6 @@
+// Package compiledtest, hipo compile ile üretilen kodun Engine.RenderFile ile aynı çıktıyı
+// verdiğini doğrulamak için testdata/compile template'lerinin derlenmiş halini içerir.
+// render/ altındaki template'ler paket testlerindeki render senaryolarının kopyalarıdır.
+package compiledtest
+
+//go:generate go run ../../cmd/hipo compile -pkg compiledtest -path ../../testdata/compile -o templates_gen.go page.hipo partials/footer.hipo render/break_continue.hipo render/for.hipo render/loop_filter.hipo render/macro.hipo render/nested.hipo render/scope.hipo render/simple.hipo render/switch.hipo render/tests.hipo render/vm.hipo
@@ -0,0 +1,986 @@
+// Code generated by hipo compile. DO NOT EDIT.
+
+package compiledtest
+
+import (
+	"strings"
+
+	hipoengine "hipoengine"
+)
+
+// Templates, derlenen template dosya adlarını render fonksiyonlarına eşler.
+var Templates = map[string]func(e *hipoengine.Engine, data map[string]interface{}) (string, error){
+	"page.hipo":                  RenderPage,
+	"partials/footer.hipo":       RenderPartialsFooter,
+	"render/break_continue.hipo": RenderRenderBreakContinue,
+	"render/for.hipo":            RenderRenderFor,
+	"render/loop_filter.hipo":    RenderRenderLoopFilter,
+	"render/macro.hipo":          RenderRenderMacro,
+	"render/nested.hipo":         RenderRenderNested,
+	"render/scope.hipo":          RenderRenderScope,
+	"render/simple.hipo":         RenderRenderSimple,
+	"render/switch.hipo":         RenderRenderSwitch,
+	"render/tests.hipo":          RenderRenderTests,
+	"render/vm.hipo":             RenderRenderVm,
+}
+
+// RenderPage, "page.hipo" template'inin derlenmiş halidir (Engine.RenderFile ile aynı çıktı).
+func RenderPage(e *hipoengine.Engine, data map[string]interface{}) (string, error) {
+	return e.RenderCompiled("page.hipo", data, pageAssets, renderPage)
+}
+
+var pageAssets = []*hipoengine.SFCBlock{{Type: "script", Content: "\nconsole.log(\"page\");\n"}, {Type: "style", Content: "\nh1[data-h-28cee8aa] { color: navy; }\n"}}
+
+func renderPage(ctx *hipoengine.Context) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("\n<h1 data-h-28cee8aa>")
+	sb.WriteString(hipoengine.FormatValue(ctx.ApplyFilter("upper", ctx.ResolvePath("title")), false))
+	sb.WriteString("</h1>\n")
+	ctx.Set("greeting", "Merhaba")
+	sb.WriteString("\n<p data-h-28cee8aa>")
+	sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("greeting"), false))
+	sb.WriteString(", ")
+	sb.WriteString(hipoengine.FormatValue(ctx.ApplyFilter("default", ctx.ResolvePath("user", "name"), "Anonim"), false))
+	sb.WriteString("</p>\n")
+	if hipoengine.AsInt(ctx.ResolvePath("user", "age")) >= 18 {
+		sb.WriteString("<span data-h-28cee8aa>Yetişkin</span>")
+	} else if hipoengine.AsInt(ctx.ResolvePath("user", "age")) > 12 {
+		sb.WriteString("<span data-h-28cee8aa>Genç</span>")
+	} else {
+		sb.WriteString("<span data-h-28cee8aa>Çocuk</span>")
+	}
+	sb.WriteString("\n<ul data-h-28cee8aa>\n")
+	{
+		items, err := ctx.LoopItems("items", "items")
+		if err != nil {
+			return "", err
+		}
+		kept := items[:0]
+		for _, item := range items {
+			ctx := ctx.NewChild(map[string]interface{}{"item": item})
+			if hipoengine.Truthy(ctx.ResolvePath("item", "active")) {
+				kept = append(kept, item)
+			}
+		}
+		items = kept
+		for i, item := range items {
+			out, brk, cont, err := func(ctx *hipoengine.Context) (string, bool, bool, error) {
+				var sb strings.Builder
+				sb.WriteString("<li data-h-28cee8aa class=\"")
+				if hipoengine.Truthy(ctx.ResolvePath("loop", "first")) {
+					sb.WriteString("first")
+				}
+				sb.WriteString("\">")
+				sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("loop", "index"), false))
+				sb.WriteString(". ")
+				sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("item", "name"), false))
+				sb.WriteString("</li>")
+				if hipoengine.AsString(ctx.ResolvePath("loop", "index")) == "2" {
+					return sb.String(), true, false, nil
+				}
+				return sb.String(), false, false, nil
+			}(ctx.NewChild(map[string]interface{}{"item": item, "loop": hipoengine.LoopInfo(i, len(items))}))
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(out)
+			if brk {
+				break
+			}
+			if i != len(items)-1 && !(cont && out == "") {
+				sb.WriteString("\n")
+			}
+		}
+	}
+	sb.WriteString("\n</ul>\n")
+	{
+		var subject interface{}
+		subject = ctx.ResolvePath("user", "role")
+		if hipoengine.Equal(subject, "admin") {
+			sb.WriteString("Yönetici")
+		} else if hipoengine.Equal(subject, "editor") || hipoengine.Equal(subject, "author") {
+			sb.WriteString("Yazar")
+		} else {
+			sb.WriteString("Ziyaretçi")
+		}
+	}
+	ctx.DefineMacro("badge", []string{"text", "kind"}, map[string]hipoengine.Operand{"kind": {Value: "info"}}, func(ctx *hipoengine.Context) (string, error) {
+		var sb strings.Builder
+		sb.WriteString("<b data-h-28cee8aa class=\"")
+		sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("kind"), false))
+		sb.WriteString("\">")
+		sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("text"), false))
+		sb.WriteString("</b>")
+		return sb.String(), nil
+	})
+	if val, ok, err := ctx.Call("badge", "yeni"); err != nil {
+		return "", err
+	} else if ok {
+		sb.WriteString(hipoengine.FormatValue(val, false))
+	}
+	if out, err := ctx.Include("partials/footer.hipo", hipoengine.Position{File: "page.hipo", Line: 12, Column: 1}); err != nil {
+		return "", err
+	} else {
+		sb.WriteString(out)
+	}
+	sb.WriteString("\n")
+	return sb.String(), nil
+}
+
+// RenderPartialsFooter, "partials/footer.hipo" template'inin derlenmiş halidir (Engine.RenderFile ile aynı çıktı).
+func RenderPartialsFooter(e *hipoengine.Engine, data map[string]interface{}) (string, error) {
+	return e.RenderCompiled("partials/footer.hipo", data, partialsFooterAssets, renderPartialsFooter)
+}
+
+var partialsFooterAssets = []*hipoengine.SFCBlock{{Type: "style", Content: "\nfooter { font-size: 12px; }\n"}}
+
+func renderPartialsFooter(ctx *hipoengine.Context) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("\n<footer>")
+	sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("site"), false))
+	sb.WriteString("</footer>\n")
+	return sb.String(), nil
+}
+
+// RenderRenderBreakContinue, "render/break_continue.hipo" template'inin derlenmiş halidir (Engine.RenderFile ile aynı çıktı).
+func RenderRenderBreakContinue(e *hipoengine.Engine, data map[string]interface{}) (string, error) {
+	return e.RenderCompiled("render/break_continue.hipo", data, renderBreakContinueAssets, renderRenderBreakContinue)
+}
+
+var renderBreakContinueAssets = []*hipoengine.SFCBlock{}
+
+func renderRenderBreakContinue(ctx *hipoengine.Context) (string, error) {
+	var sb strings.Builder
+	{
+		items, err := ctx.LoopItems("numbers", "numbers")
+		if err != nil {
+			return "", err
+		}
+		for i, item := range items {
+			out, brk, cont, err := func(ctx *hipoengine.Context) (string, bool, bool, error) {
+				var sb strings.Builder
+				if hipoengine.AsString(ctx.ResolvePath("i")) == "2" {
+					return sb.String(), false, true, nil
+				}
+				if hipoengine.AsString(ctx.ResolvePath("i")) == "4" {
+					return sb.String(), true, false, nil
+				}
+				sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("i"), false))
+				return sb.String(), false, false, nil
+			}(ctx.NewChild(map[string]interface{}{"i": item, "loop": hipoengine.LoopInfo(i, len(items))}))
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(out)
+			if brk {
+				break
+			}
+			if i != len(items)-1 && !(cont && out == "") {
+				sb.WriteString("\n")
+			}
+		}
+	}
+	sb.WriteString("|")
+	{
+		items, err := ctx.LoopItems("xs", "xs")
+		if err != nil {
+			return "", err
+		}
+		for i, item := range items {
+			out, brk, cont, err := func(ctx *hipoengine.Context) (string, bool, bool, error) {
+				var sb strings.Builder
+				sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("x"), false))
+				return sb.String(), false, false, nil
+			}(ctx.NewChild(map[string]interface{}{"x": item, "loop": hipoengine.LoopInfo(i, len(items))}))
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(out)
+			if brk {
+				break
+			}
+			if i != len(items)-1 && !(cont && out == "") {
+				sb.WriteString("\n")
+			}
+		}
+	}
+	sb.WriteString("|")
+	{
+		items, err := ctx.LoopItems("xs", "xs")
+		if err != nil {
+			return "", err
+		}
+		for i, item := range items {
+			out, brk, cont, err := func(ctx *hipoengine.Context) (string, bool, bool, error) {
+				var sb strings.Builder
+				if hipoengine.AsString(ctx.ResolvePath("x")) == "" {
+					return sb.String(), false, true, nil
+				}
+				sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("x"), false))
+				return sb.String(), false, false, nil
+			}(ctx.NewChild(map[string]interface{}{"x": item, "loop": hipoengine.LoopInfo(i, len(items))}))
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(out)
+			if brk {
+				break
+			}
+			if i != len(items)-1 && !(cont && out == "") {
+				sb.WriteString("\n")
+			}
+		}
+	}
+	return sb.String(), nil
+}
+
+// RenderRenderFor, "render/for.hipo" template'inin derlenmiş halidir (Engine.RenderFile ile aynı çıktı).
+func RenderRenderFor(e *hipoengine.Engine, data map[string]interface{}) (string, error) {
+	return e.RenderCompiled("render/for.hipo", data, renderForAssets, renderRenderFor)
+}
+
+var renderForAssets = []*hipoengine.SFCBlock{}
+
+func renderRenderFor(ctx *hipoengine.Context) (string, error) {
+	var sb strings.Builder
+	{
+		items, err := ctx.LoopItems("letters", "letters")
+		if err != nil {
+			return "", err
+		}
+		for i, item := range items {
+			out, brk, cont, err := func(ctx *hipoengine.Context) (string, bool, bool, error) {
+				var sb strings.Builder
+				sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("item"), false))
+				return sb.String(), false, false, nil
+			}(ctx.NewChild(map[string]interface{}{"item": item, "loop": hipoengine.LoopInfo(i, len(items))}))
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(out)
+			if brk {
+				break
+			}
+			if i != len(items)-1 && !(cont && out == "") {
+				sb.WriteString("\n")
+			}
+		}
+	}
+	return sb.String(), nil
+}
+
+// RenderRenderLoopFilter, "render/loop_filter.hipo" template'inin derlenmiş halidir (Engine.RenderFile ile aynı çıktı).
+func RenderRenderLoopFilter(e *hipoengine.Engine, data map[string]interface{}) (string, error) {
+	return e.RenderCompiled("render/loop_filter.hipo", data, renderLoopFilterAssets, renderRenderLoopFilter)
+}
+
+var renderLoopFilterAssets = []*hipoengine.SFCBlock{}
+
+func renderRenderLoopFilter(ctx *hipoengine.Context) (string, error) {
+	var sb strings.Builder
+	{
+		items, err := ctx.LoopItems("products", "products")
+		if err != nil {
+			return "", err
+		}
+		kept := items[:0]
+		for _, item := range items {
+			ctx := ctx.NewChild(map[string]interface{}{"p": item})
+			if hipoengine.Truthy(ctx.ResolvePath("p", "active")) {
+				kept = append(kept, item)
+			}
+		}
+		items = kept
+		for i, item := range items {
+			out, brk, cont, err := func(ctx *hipoengine.Context) (string, bool, bool, error) {
+				var sb strings.Builder
+				sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("loop", "index"), false))
+				sb.WriteString("/")
+				sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("loop", "length"), false))
+				sb.WriteString(":")
+				sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("p", "name"), false))
+				if hipoengine.Truthy(ctx.ResolvePath("loop", "last")) {
+					sb.WriteString(".")
+				}
+				return sb.String(), false, false, nil
+			}(ctx.NewChild(map[string]interface{}{"p": item, "loop": hipoengine.LoopInfo(i, len(items))}))
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(out)
+			if brk {
+				break
+			}
+			if i != len(items)-1 && !(cont && out == "") {
+				sb.WriteString("\n")
+			}
+		}
+	}
+	return sb.String(), nil
+}
+
+// RenderRenderMacro, "render/macro.hipo" template'inin derlenmiş halidir (Engine.RenderFile ile aynı çıktı).
+func RenderRenderMacro(e *hipoengine.Engine, data map[string]interface{}) (string, error) {
+	return e.RenderCompiled("render/macro.hipo", data, renderMacroAssets, renderRenderMacro)
+}
+
+var renderMacroAssets = []*hipoengine.SFCBlock{}
+
+func renderRenderMacro(ctx *hipoengine.Context) (string, error) {
+	var sb strings.Builder
+	ctx.DefineMacro("item", []string{"node", "prefix"}, map[string]hipoengine.Operand{"prefix": {Value: "-"}}, func(ctx *hipoengine.Context) (string, error) {
+		var sb strings.Builder
+		sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("prefix"), false))
+		sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("node", "name"), false))
+		sb.WriteString(";")
+		if hipoengine.Truthy(ctx.ResolvePath("node", "children")) {
+			{
+				items, err := ctx.LoopItems("node.children", "node", "children")
+				if err != nil {
+					return "", err
+				}
+				for i, item := range items {
+					out, brk, cont, err := func(ctx *hipoengine.Context) (string, bool, bool, error) {
+						var sb strings.Builder
+						if val, ok, err := ctx.Call("item", ctx.ResolvePath("c"), "--"); err != nil {
+							return "", false, false, err
+						} else if ok {
+							sb.WriteString(hipoengine.FormatValue(val, false))
+						}
+						return sb.String(), false, false, nil
+					}(ctx.NewChild(map[string]interface{}{"c": item, "loop": hipoengine.LoopInfo(i, len(items))}))
+					if err != nil {
+						return "", err
+					}
+					sb.WriteString(out)
+					if brk {
+						break
+					}
+					if i != len(items)-1 && !(cont && out == "") {
+						sb.WriteString("\n")
+					}
+				}
+			}
+		}
+		return sb.String(), nil
+	})
+	{
+		items, err := ctx.LoopItems("tree", "tree")
+		if err != nil {
+			return "", err
+		}
+		for i, item := range items {
+			out, brk, cont, err := func(ctx *hipoengine.Context) (string, bool, bool, error) {
+				var sb strings.Builder
+				if val, ok, err := ctx.Call("item", ctx.ResolvePath("n")); err != nil {
+					return "", false, false, err
+				} else if ok {
+					sb.WriteString(hipoengine.FormatValue(val, false))
+				}
+				return sb.String(), false, false, nil
+			}(ctx.NewChild(map[string]interface{}{"n": item, "loop": hipoengine.LoopInfo(i, len(items))}))
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(out)
+			if brk {
+				break
+			}
+			if i != len(items)-1 && !(cont && out == "") {
+				sb.WriteString("\n")
+			}
+		}
+	}
+	ctx.DefineMacro("b", []string{"t"}, nil, func(ctx *hipoengine.Context) (string, error) {
+		var sb strings.Builder
+		sb.WriteString("<b>")
+		sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("t"), false))
+		sb.WriteString("</b>")
+		return sb.String(), nil
+	})
+	if val, ok, err := ctx.Call("b", ctx.ResolvePath("html")); err != nil {
+		return "", err
+	} else if ok {
+		sb.WriteString(hipoengine.FormatValue(val, false))
+	}
+	{
+		items, err := ctx.LoopItems("items", "items")
+		if err != nil {
+			return "", err
+		}
+		for i, item := range items {
+			out, brk, cont, err := func(ctx *hipoengine.Context) (string, bool, bool, error) {
+				var sb strings.Builder
+				if val, ok, err := ctx.Call("b", ctx.ResolvePath("item", "name")); err != nil {
+					return "", false, false, err
+				} else if ok {
+					sb.WriteString(hipoengine.FormatValue(val, false))
+				}
+				return sb.String(), false, false, nil
+			}(ctx.NewChild(map[string]interface{}{"item": item, "loop": hipoengine.LoopInfo(i, len(items))}))
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(out)
+			if brk {
+				break
+			}
+			if i != len(items)-1 && !(cont && out == "") {
+				sb.WriteString("\n")
+			}
+		}
+	}
+	return sb.String(), nil
+}
+
+// RenderRenderNested, "render/nested.hipo" template'inin derlenmiş halidir (Engine.RenderFile ile aynı çıktı).
+func RenderRenderNested(e *hipoengine.Engine, data map[string]interface{}) (string, error) {
+	return e.RenderCompiled("render/nested.hipo", data, renderNestedAssets, renderRenderNested)
+}
+
+var renderNestedAssets = []*hipoengine.SFCBlock{}
+
+func renderRenderNested(ctx *hipoengine.Context) (string, error) {
+	var sb strings.Builder
+	{
+		items, err := ctx.LoopItems("rows", "rows")
+		if err != nil {
+			return "", err
+		}
+		for i, item := range items {
+			out, brk, cont, err := func(ctx *hipoengine.Context) (string, bool, bool, error) {
+				var sb strings.Builder
+				{
+					items, err := ctx.LoopItems("r.cells", "r", "cells")
+					if err != nil {
+						return "", false, false, err
+					}
+					for i, item := range items {
+						out, brk, cont, err := func(ctx *hipoengine.Context) (string, bool, bool, error) {
+							var sb strings.Builder
+							sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("c"), false))
+							return sb.String(), false, false, nil
+						}(ctx.NewChild(map[string]interface{}{"c": item, "loop": hipoengine.LoopInfo(i, len(items))}))
+						if err != nil {
+							return "", false, false, err
+						}
+						sb.WriteString(out)
+						if brk {
+							break
+						}
+						if i != len(items)-1 && !(cont && out == "") {
+							sb.WriteString("\n")
+						}
+					}
+				}
+				return sb.String(), false, false, nil
+			}(ctx.NewChild(map[string]interface{}{"r": item, "loop": hipoengine.LoopInfo(i, len(items))}))
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(out)
+			if brk {
+				break
+			}
+			if i != len(items)-1 && !(cont && out == "") {
+				sb.WriteString("\n")
+			}
+		}
+	}
+	if hipoengine.Truthy(ctx.ResolvePath("x")) {
+		if hipoengine.Truthy(ctx.ResolvePath("y")) {
+			sb.WriteString("1")
+		} else {
+			sb.WriteString("2")
+		}
+	} else {
+		sb.WriteString("3")
+	}
+	if hipoengine.Truthy(ctx.ResolvePath("items")) {
+		{
+			items, err := ctx.LoopItems("items", "items")
+			if err != nil {
+				return "", err
+			}
+			for i, item := range items {
+				out, brk, cont, err := func(ctx *hipoengine.Context) (string, bool, bool, error) {
+					var sb strings.Builder
+					sb.WriteString(hipoengine.FormatValue(ctx.ApplyFilter("upper", ctx.ResolvePath("item", "name")), false))
+					return sb.String(), false, false, nil
+				}(ctx.NewChild(map[string]interface{}{"item": item, "loop": hipoengine.LoopInfo(i, len(items))}))
+				if err != nil {
+					return "", err
+				}
+				sb.WriteString(out)
+				if brk {
+					break
+				}
+				if i != len(items)-1 && !(cont && out == "") {
+					sb.WriteString("\n")
+				}
+			}
+		}
+	}
+	return sb.String(), nil
+}
+
+// RenderRenderScope, "render/scope.hipo" template'inin derlenmiş halidir (Engine.RenderFile ile aynı çıktı).
+func RenderRenderScope(e *hipoengine.Engine, data map[string]interface{}) (string, error) {
+	return e.RenderCompiled("render/scope.hipo", data, renderScopeAssets, renderRenderScope)
+}
+
+var renderScopeAssets = []*hipoengine.SFCBlock{}
+
+func renderRenderScope(ctx *hipoengine.Context) (string, error) {
+	var sb strings.Builder
+	{
+		ctx := ctx.NewChild(map[string]interface{}{})
+		ctx.Set("t", "Başlık")
+		sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("t"), false))
+	}
+	sb.WriteString("|")
+	sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("t"), false))
+	{
+		items, err := ctx.LoopItems("xs", "xs")
+		if err != nil {
+			return "", err
+		}
+		for i, item := range items {
+			out, brk, cont, err := func(ctx *hipoengine.Context) (string, bool, bool, error) {
+				var sb strings.Builder
+				if hipoengine.Truthy(ctx.ResolvePath("x")) {
+					sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("x"), false))
+				} else {
+					sb.WriteString("-")
+				}
+				return sb.String(), false, false, nil
+			}(ctx.NewChild(map[string]interface{}{"x": item, "loop": hipoengine.LoopInfo(i, len(items))}))
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(out)
+			if brk {
+				break
+			}
+			if i != len(items)-1 && !(cont && out == "") {
+				sb.WriteString("\n")
+			}
+		}
+	}
+	{
+		ctx := ctx.NewChild(map[string]interface{}{"b": ctx.ResolvePath("a")})
+		sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("b"), false))
+	}
+	ctx.Set("shared", "dışarıda")
+	if out, err := ctx.Include("partials/footer.hipo", hipoengine.Position{File: "render/scope.hipo", Line: 3, Column: 30}); err != nil {
+		return "", err
+	} else {
+		sb.WriteString(out)
+	}
+	sb.WriteString("|")
+	sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("shared"), false))
+	return sb.String(), nil
+}
+
+// RenderRenderSimple, "render/simple.hipo" template'inin derlenmiş halidir (Engine.RenderFile ile aynı çıktı).
+func RenderRenderSimple(e *hipoengine.Engine, data map[string]interface{}) (string, error) {
+	return e.RenderCompiled("render/simple.hipo", data, renderSimpleAssets, renderRenderSimple)
+}
+
+var renderSimpleAssets = []*hipoengine.SFCBlock{}
+
+func renderRenderSimple(ctx *hipoengine.Context) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("Merhaba ")
+	sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("name"), false))
+	sb.WriteString("! ")
+	sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("notfound"), false))
+	sb.WriteString("|")
+	sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("html"), false))
+	sb.WriteString("|")
+	sb.WriteString(hipoengine.FormatValue(ctx.ApplyFilter("safe", ctx.ResolvePath("html")), true))
+	sb.WriteString("|")
+	if val, ok, err := ctx.Call("NotAFunction"); err != nil {
+		return "", err
+	} else if ok {
+		sb.WriteString(hipoengine.FormatValue(val, false))
+	}
+	return sb.String(), nil
+}
+
+// RenderRenderSwitch, "render/switch.hipo" template'inin derlenmiş halidir (Engine.RenderFile ile aynı çıktı).
+func RenderRenderSwitch(e *hipoengine.Engine, data map[string]interface{}) (string, error) {
+	return e.RenderCompiled("render/switch.hipo", data, renderSwitchAssets, renderRenderSwitch)
+}
+
+var renderSwitchAssets = []*hipoengine.SFCBlock{}
+
+func renderRenderSwitch(ctx *hipoengine.Context) (string, error) {
+	var sb strings.Builder
+	{
+		var subject interface{}
+		subject = ctx.ResolvePath("order", "status")
+		if hipoengine.Equal(subject, "paid") || hipoengine.Equal(subject, "shipped") {
+			sb.WriteString("Ödendi")
+		} else if hipoengine.Equal(subject, "cancelled") {
+			sb.WriteString("İptal")
+		} else {
+			sb.WriteString("Bekliyor")
+		}
+	}
+	sb.WriteString("|")
+	{
+		var subject interface{}
+		subject = ctx.ResolvePath("code")
+		if hipoengine.Equal(subject, "1") {
+			sb.WriteString("string")
+		} else if hipoengine.Equal(subject, 1) {
+			sb.WriteString("sayı")
+		}
+	}
+	sb.WriteString("|")
+	{
+		var subject interface{}
+		if val, ok, err := ctx.Call("status"); err != nil {
+			return "", err
+		} else if ok {
+			subject = val
+		} else {
+			subject = ""
+		}
+		if hipoengine.Equal(subject, "a") {
+			sb.WriteString("A")
+		} else if hipoengine.Equal(subject, "b") {
+			sb.WriteString("B")
+		} else if hipoengine.Equal(subject, "c") {
+			sb.WriteString("C")
+		}
+	}
+	return sb.String(), nil
+}
+
+// RenderRenderTests, "render/tests.hipo" template'inin derlenmiş halidir (Engine.RenderFile ile aynı çıktı).
+func RenderRenderTests(e *hipoengine.Engine, data map[string]interface{}) (string, error) {
+	return e.RenderCompiled("render/tests.hipo", data, renderTestsAssets, renderRenderTests)
+}
+
+var renderTestsAssets = []*hipoengine.SFCBlock{}
+
+func renderRenderTests(ctx *hipoengine.Context) (string, error) {
+	var sb strings.Builder
+	if ctx.Test("even", false, hipoengine.Operand{Path: []string{"n"}}) {
+		sb.WriteString("evet")
+	} else {
+		sb.WriteString("hayır")
+	}
+	if ctx.Test("odd", false, hipoengine.Operand{Path: []string{"n"}}) {
+		sb.WriteString("evet")
+	} else {
+		sb.WriteString("hayır")
+	}
+	if ctx.Test("divisibleby", false, hipoengine.Operand{Path: []string{"n"}}, hipoengine.Operand{Value: 2}) {
+		sb.WriteString("evet")
+	} else {
+		sb.WriteString("hayır")
+	}
+	if ctx.Test("divisibleby", false, hipoengine.Operand{Path: []string{"n"}}, hipoengine.Operand{Value: 3}) {
+		sb.WriteString("evet")
+	} else {
+		sb.WriteString("hayır")
+	}
+	if ctx.Test("string", false, hipoengine.Operand{Path: []string{"name"}}) {
+		sb.WriteString("evet")
+	} else {
+		sb.WriteString("hayır")
+	}
+	if ctx.Test("iterable", false, hipoengine.Operand{Path: []string{"items"}}) {
+		sb.WriteString("evet")
+	} else {
+		sb.WriteString("hayır")
+	}
+	if ctx.Test("mapping", false, hipoengine.Operand{Path: []string{"user"}}) {
+		sb.WriteString("evet")
+	} else {
+		sb.WriteString("hayır")
+	}
+	if ctx.Test("defined", true, hipoengine.Operand{Path: []string{"missing"}}) {
+		sb.WriteString("evet")
+	} else {
+		sb.WriteString("hayır")
+	}
+	if ctx.Test("defined", false, hipoengine.Operand{Path: []string{"user", "age"}}) {
+		sb.WriteString("evet")
+	} else {
+		sb.WriteString("hayır")
+	}
+	if ctx.Test("none", false, hipoengine.Operand{Path: []string{"user", "city"}}) {
+		sb.WriteString("evet")
+	} else {
+		sb.WriteString("hayır")
+	}
+	if ctx.Test("defined", false, hipoengine.Operand{Path: []string{"empty"}}) {
+		sb.WriteString("evet")
+	} else {
+		sb.WriteString("hayır")
+	}
+	if ctx.Test("defined", false, hipoengine.Operand{Path: []string{"user", "phone"}}) {
+		sb.WriteString("evet")
+	} else {
+		sb.WriteString("hayır")
+	}
+	if ctx.Test("sameas", false, hipoengine.Operand{Path: []string{"items"}}, hipoengine.Operand{Path: []string{"items"}}) {
+		sb.WriteString("evet")
+	} else {
+		sb.WriteString("hayır")
+	}
+	if ctx.Test("adult", false, hipoengine.Operand{Path: []string{"user", "age"}}) {
+		sb.WriteString("Yetişkin")
+	} else {
+		sb.WriteString("Çocuk")
+	}
+	return sb.String(), nil
+}
+
+// RenderRenderVm, "render/vm.hipo" template'inin derlenmiş halidir (Engine.RenderFile ile aynı çıktı).
+func RenderRenderVm(e *hipoengine.Engine, data map[string]interface{}) (string, error) {
+	return e.RenderCompiled("render/vm.hipo", data, renderVmAssets, renderRenderVm)
+}
+
+var renderVmAssets = []*hipoengine.SFCBlock{}
+
+func renderRenderVm(ctx *hipoengine.Context) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("Merhaba ")
+	sb.WriteString(hipoengine.FormatValue(ctx.ApplyFilter("upper", ctx.ResolvePath("user", "name")), false))
+	sb.WriteString("&lt;b&gt;")
+	sb.WriteString("42")
+	sb.WriteString(hipoengine.FormatValue(ctx.ApplyFilter("safe", ctx.ResolvePath("html")), true))
+	if hipoengine.AsInt(ctx.ResolvePath("user", "age")) >= 18 {
+		sb.WriteString("Yetişkin")
+	} else if hipoengine.AsInt(ctx.ResolvePath("user", "age")) > 12 {
+		sb.WriteString("Genç")
+	} else {
+		sb.WriteString("Çocuk")
+	}
+	sb.WriteString("!\n<ul>")
+	{
+		items, err := ctx.LoopItems("items", "items")
+		if err != nil {
+			return "", err
+		}
+		for i, item := range items {
+			out, brk, cont, err := func(ctx *hipoengine.Context) (string, bool, bool, error) {
+				var sb strings.Builder
+				sb.WriteString("<li>")
+				sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("loop", "index"), false))
+				sb.WriteString("/")
+				sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("loop", "length"), false))
+				sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("item", "name"), false))
+				if hipoengine.Truthy(ctx.ResolvePath("loop", "last")) {
+					sb.WriteString(" son")
+				}
+				sb.WriteString("</li>")
+				return sb.String(), false, false, nil
+			}(ctx.NewChild(map[string]interface{}{"item": item, "loop": hipoengine.LoopInfo(i, len(items))}))
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(out)
+			if brk {
+				break
+			}
+			if i != len(items)-1 && !(cont && out == "") {
+				sb.WriteString("\n")
+			}
+		}
+	}
+	sb.WriteString("</ul>\n")
+	{
+		items, err := ctx.LoopItems("items", "items")
+		if err != nil {
+			return "", err
+		}
+		for i, item := range items {
+			out, brk, cont, err := func(ctx *hipoengine.Context) (string, bool, bool, error) {
+				var sb strings.Builder
+				if hipoengine.Truthy(ctx.ResolvePath("item", "active")) {
+					return sb.String(), false, true, nil
+				}
+				sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("item", "name"), false))
+				return sb.String(), false, false, nil
+			}(ctx.NewChild(map[string]interface{}{"item": item, "loop": hipoengine.LoopInfo(i, len(items))}))
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(out)
+			if brk {
+				break
+			}
+			if i != len(items)-1 && !(cont && out == "") {
+				sb.WriteString("\n")
+			}
+		}
+	}
+	{
+		items, err := ctx.LoopItems("items", "items")
+		if err != nil {
+			return "", err
+		}
+		for i, item := range items {
+			out, brk, cont, err := func(ctx *hipoengine.Context) (string, bool, bool, error) {
+				var sb strings.Builder
+				sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("item", "name"), false))
+				if hipoengine.AsString(ctx.ResolvePath("loop", "index")) == "2" {
+					return sb.String(), true, false, nil
+				}
+				sb.WriteString("-")
+				return sb.String(), false, false, nil
+			}(ctx.NewChild(map[string]interface{}{"item": item, "loop": hipoengine.LoopInfo(i, len(items))}))
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(out)
+			if brk {
+				break
+			}
+			if i != len(items)-1 && !(cont && out == "") {
+				sb.WriteString("\n")
+			}
+		}
+	}
+	sb.WriteString("son\n")
+	{
+		items, err := ctx.LoopItems("items", "items")
+		if err != nil {
+			return "", err
+		}
+		kept := items[:0]
+		for _, item := range items {
+			ctx := ctx.NewChild(map[string]interface{}{"item": item})
+			if hipoengine.Truthy(ctx.ResolvePath("item", "active")) {
+				kept = append(kept, item)
+			}
+		}
+		items = kept
+		for i, item := range items {
+			out, brk, cont, err := func(ctx *hipoengine.Context) (string, bool, bool, error) {
+				var sb strings.Builder
+				{
+					items, err := ctx.LoopItems("item.tags", "item", "tags")
+					if err != nil {
+						return "", false, false, err
+					}
+					for i, item := range items {
+						out, brk, cont, err := func(ctx *hipoengine.Context) (string, bool, bool, error) {
+							var sb strings.Builder
+							sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("t"), false))
+							if hipoengine.AsString(ctx.ResolvePath("t")) == "b" {
+								return sb.String(), true, false, nil
+							}
+							return sb.String(), false, false, nil
+						}(ctx.NewChild(map[string]interface{}{"t": item, "loop": hipoengine.LoopInfo(i, len(items))}))
+						if err != nil {
+							return "", false, false, err
+						}
+						sb.WriteString(out)
+						if brk {
+							break
+						}
+						if i != len(items)-1 && !(cont && out == "") {
+							sb.WriteString("\n")
+						}
+					}
+				}
+				sb.WriteString(";")
+				return sb.String(), false, false, nil
+			}(ctx.NewChild(map[string]interface{}{"item": item, "loop": hipoengine.LoopInfo(i, len(items))}))
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(out)
+			if brk {
+				break
+			}
+			if i != len(items)-1 && !(cont && out == "") {
+				sb.WriteString("\n")
+			}
+		}
+	}
+	{
+		items, err := ctx.LoopItems("items", "items")
+		if err != nil {
+			return "", err
+		}
+		for i, item := range items {
+			out, brk, cont, err := func(ctx *hipoengine.Context) (string, bool, bool, error) {
+				var sb strings.Builder
+				{
+					ctx := ctx.NewChild(map[string]interface{}{"n": ctx.ResolvePath("item", "name")})
+					sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("n"), false))
+					return sb.String(), true, false, nil
+				}
+			}(ctx.NewChild(map[string]interface{}{"item": item, "loop": hipoengine.LoopInfo(i, len(items))}))
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(out)
+			if brk {
+				break
+			}
+			if i != len(items)-1 && !(cont && out == "") {
+				sb.WriteString("\n")
+			}
+		}
+	}
+	{
+		items, err := ctx.LoopItems("nothing", "nothing")
+		if err != nil {
+			return "", err
+		}
+		for i, item := range items {
+			out, brk, cont, err := func(ctx *hipoengine.Context) (string, bool, bool, error) {
+				var sb strings.Builder
+				sb.WriteString("x")
+				return sb.String(), false, false, nil
+			}(ctx.NewChild(map[string]interface{}{"item": item, "loop": hipoengine.LoopInfo(i, len(items))}))
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(out)
+			if brk {
+				break
+			}
+			if i != len(items)-1 && !(cont && out == "") {
+				sb.WriteString("\n")
+			}
+		}
+	}
+	sb.WriteString("boş\n")
+	{
+		items, err := ctx.LoopItems("items", "items")
+		if err != nil {
+			return "", err
+		}
+		for i, item := range items {
+			out, brk, cont, err := func(ctx *hipoengine.Context) (string, bool, bool, error) {
+				var sb strings.Builder
+				{
+					ctx := ctx.NewChild(map[string]interface{}{})
+					sb.WriteString(hipoengine.FormatValue(ctx.ResolvePath("item", "name"), false))
+					return sb.String(), false, true, nil
+				}
+			}(ctx.NewChild(map[string]interface{}{"item": item, "loop": hipoengine.LoopInfo(i, len(items))}))
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(out)
+			if brk {
+				break
+			}
+			if i != len(items)-1 && !(cont && out == "") {
+				sb.WriteString("\n")
+			}
+		}
+	}
+	return sb.String(), nil
+}
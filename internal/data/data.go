@@ -0,0 +1,104 @@
+// data.go
+// Package data, hipo'nun context, front matter, spec ve çeviri dosyaları için JSON, YAML ve TOML
+// okuyucusudur. Template engine'in public API'sinin parçası değildir; engine ve hipo komutu
+// tarafından kullanılır.
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Veri formatları
+const (
+	JSON = "json"
+	YAML = "yaml"
+	TOML = "toml"
+)
+
+// Format, dosya uzantısına göre veri formatını döndürür; desteklenmeyen uzantılar için "".
+func Format(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		return JSON
+	case ".yaml", ".yml":
+		return YAML
+	case ".toml":
+		return TOML
+	}
+	return ""
+}
+
+// Load, JSON, YAML veya TOML veri dosyasını uzantısına göre okuyup context map'ine çevirir.
+func Load(filename string) (map[string]interface{}, error) {
+	format := Format(filename)
+	if format == "" {
+		return nil, fmt.Errorf("%s: desteklenmeyen veri formatı (json, yaml veya toml bekleniyordu)", filename)
+	}
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	data, err := Decode(content, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return data, nil
+}
+
+// Decode, içeriği verilen formatta çözer. Üst seviye değer bir map olmalıdır; boş içerik boş
+// map döndürür.
+//
+// YAML ve TOML için yaygın kullanılan alt küme desteklenir: YAML'da girintili map ve listeler,
+// "- " liste elemanları, flow [a, b] / {a: 1} değerleri, tırnaklı string'ler ve | / > blok
+// metinleri; TOML'da tablolar, tablo dizileri ([[...]]), noktalı anahtarlar, satır içi tablolar,
+// çok satırlı diziler ve string'ler ile tarih/saat değerleri. Tırnaksız tarih ve tarih-saat
+// değerleri (2024-06-13, 2024-06-13T15:04:05Z) iki formatta da time.Time olur. Anchor, tag ve
+// çoklu doküman desteklenmez.
+func Decode(content []byte, format string) (map[string]interface{}, error) {
+	var v interface{}
+	var err error
+	switch format {
+	case JSON:
+		if strings.TrimSpace(string(content)) == "" {
+			return map[string]interface{}{}, nil
+		}
+		err = json.Unmarshal(content, &v)
+	case YAML:
+		v, err = decodeYAML(string(content))
+	case TOML:
+		v, err = decodeTOML(string(content))
+	default:
+		return nil, fmt.Errorf("desteklenmeyen veri formatı: %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return map[string]interface{}{}, nil
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s verisinin üst seviyesi bir map olmalı, %T bulundu", format, v)
+	}
+	return m, nil
+}
+
+// parseDateTime, tarih-saat (RFC 3339, saat dilimi olmadan veya tarih ile saat arasında boşlukla)
+// ve yalnızca tarih değerlerini çözer. YAML ve TOML aynı kuralları kullanır.
+func parseDateTime(s string) (time.Time, bool) {
+	if len(s) < 10 || s[4] != '-' {
+		return time.Time{}, false
+	}
+	s = strings.Replace(s, " ", "T", 1)
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,158 @@
+package data
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecodeYAML(t *testing.T) {
+	src := `# site ayarları
+title: "Merhaba: Dünya"
+count: 3
+price: 9.5
+draft: false
+empty:
+url: https://example.com/a#b # yorum
+tags: [go, "template", 1]
+author: {name: Emre, age: 21}
+user:
+  name: emre
+  roles:
+    - admin
+    - editor
+items:
+- name: Elma
+  price: 10
+- name: 'Armut''lu'
+  price: 12
+body: |
+  Birinci satır
+    girintili
+
+  son
+summary: >-
+  katlanan
+  metin
+`
+	got, err := Decode([]byte(src), YAML)
+	if err != nil {
+		t.Fatalf("Decode error: %v", err)
+	}
+	want := map[string]interface{}{
+		"title":  "Merhaba: Dünya",
+		"count":  3,
+		"price":  9.5,
+		"draft":  false,
+		"empty":  nil,
+		"url":    "https://example.com/a#b",
+		"tags":   []interface{}{"go", "template", 1},
+		"author": map[string]interface{}{"name": "Emre", "age": 21},
+		"user": map[string]interface{}{
+			"name":  "emre",
+			"roles": []interface{}{"admin", "editor"},
+		},
+		"items": []interface{}{
+			map[string]interface{}{"name": "Elma", "price": 10},
+			map[string]interface{}{"name": "Armut'lu", "price": 12},
+		},
+		"body":    "Birinci satır\n  girintili\n\nson\n",
+		"summary": "katlanan metin",
+	}
+	for k, v := range want {
+		if !reflect.DeepEqual(got[k], v) {
+			t.Errorf("%s: got %#v, want %#v", k, got[k], v)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("anahtar sayısı: got %d, want %d", len(got), len(want))
+	}
+
+	_, err = Decode([]byte("a: 1\n  b: 2\n"), YAML)
+	if err == nil || !strings.Contains(err.Error(), "satır 2") {
+		t.Errorf("girinti hatası bekleniyordu, got %v", err)
+	}
+}
+
+func TestDecodeTOML(t *testing.T) {
+	src := `title = "Merhaba \"Dünya\"" # yorum
+count = 1_000
+ratio = 0.25
+enabled = true
+path = 'C:\dosyalar'
+created = 2024-06-13T15:04:05Z
+day = 2024-06-13
+site.name = "hipo"
+tags = [
+  "go",
+  "template", # yorum
+]
+point = { x = 1, y = 2 }
+notes = """
+satır 1
+satır 2"""
+
+[user]
+name = "emre"
+
+[user.address]
+city = "İstanbul"
+
+[[products]]
+name = "Elma"
+
+[[products]]
+name = "Armut"
+`
+	got, err := Decode([]byte(src), TOML)
+	if err != nil {
+		t.Fatalf("Decode error: %v", err)
+	}
+	want := map[string]interface{}{
+		"title":   `Merhaba "Dünya"`,
+		"count":   1000,
+		"ratio":   0.25,
+		"enabled": true,
+		"path":    `C:\dosyalar`,
+		"created": time.Date(2024, 6, 13, 15, 4, 5, 0, time.UTC),
+		"day":     time.Date(2024, 6, 13, 0, 0, 0, 0, time.UTC),
+		"site":    map[string]interface{}{"name": "hipo"},
+		"tags":    []interface{}{"go", "template"},
+		"point":   map[string]interface{}{"x": 1, "y": 2},
+		"notes":   "satır 1\nsatır 2",
+		"user": map[string]interface{}{
+			"name":    "emre",
+			"address": map[string]interface{}{"city": "İstanbul"},
+		},
+		"products": []interface{}{
+			map[string]interface{}{"name": "Elma"},
+			map[string]interface{}{"name": "Armut"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v\nwant %#v", got, want)
+	}
+
+	for _, bad := range []string{"a = 1\na = 2", "[t]\n[t]", "a = \"açık", "a = 1 b"} {
+		if _, err := Decode([]byte(bad), TOML); err == nil {
+			t.Errorf("%q için hata bekleniyordu", bad)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	if got := Format("ctx.YML"); got != YAML {
+		t.Errorf("DataFormat: got %q", got)
+	}
+	if _, err := Load("ctx.txt"); err == nil {
+		t.Error("desteklenmeyen uzantı için hata bekleniyordu")
+	}
+	data, err := Decode([]byte(`{"user": {"name": "emre"}}`), JSON)
+	if err != nil || data["user"].(map[string]interface{})["name"] != "emre" {
+		t.Errorf("JSON: got %v, %v", data, err)
+	}
+	if _, err := Decode([]byte("- a\n- b"), YAML); err == nil {
+		t.Error("üst seviye liste için hata bekleniyordu")
+	}
+}
@@ -0,0 +1,416 @@
+// toml.go
+// Context ve front matter dosyaları için TOML çözücü
+package data
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type tomlParser struct {
+	src string
+	pos int
+	// defined, [tablo] başlığıyla tanımlanmış tablolardır (aynı başlık iki kez kullanılamaz).
+	defined map[string]bool
+}
+
+type tomlError struct {
+	line int
+	msg  string
+}
+
+func (e *tomlError) Error() string {
+	return fmt.Sprintf("toml: satır %d: %s", e.line, e.msg)
+}
+
+// decodeTOML, TOML kaynağını map[string]interface{} olarak çözer. Tam sayılar int, tarih/saat
+// değerleri time.Time olarak döner.
+func decodeTOML(src string) (map[string]interface{}, error) {
+	p := &tomlParser{src: strings.ReplaceAll(src, "\r\n", "\n"), defined: make(map[string]bool)}
+	root := make(map[string]interface{})
+	current := root
+	for {
+		p.skipBlank()
+		if p.pos >= len(p.src) {
+			return root, nil
+		}
+		var err error
+		if p.src[p.pos] == '[' {
+			current, err = p.table(root)
+		} else {
+			err = p.keyValue(current)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := p.endOfLine(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *tomlParser) errorf(format string, args ...interface{}) error {
+	return &tomlError{line: strings.Count(p.src[:p.pos], "\n") + 1, msg: fmt.Sprintf(format, args...)}
+}
+
+// skipSpace, satır içindeki boşlukları atlar.
+func (p *tomlParser) skipSpace() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// skipBlank, boşlukları, satır sonlarını ve yorumları atlar.
+func (p *tomlParser) skipBlank() {
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case ' ', '\t', '\n':
+			p.pos++
+		case '#':
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (p *tomlParser) endOfLine() error {
+	p.skipSpace()
+	if p.pos < len(p.src) && p.src[p.pos] == '#' {
+		for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+			p.pos++
+		}
+	}
+	if p.pos < len(p.src) && p.src[p.pos] != '\n' {
+		return p.errorf("satır sonu bekleniyordu, %q bulundu", p.src[p.pos])
+	}
+	return nil
+}
+
+// table, [a.b] veya [[a.b]] başlığını okur ve sonraki anahtarların yazılacağı tabloyu döndürür.
+func (p *tomlParser) table(root map[string]interface{}) (map[string]interface{}, error) {
+	array := strings.HasPrefix(p.src[p.pos:], "[[")
+	if array {
+		p.pos += 2
+	} else {
+		p.pos++
+	}
+	p.skipSpace()
+	keys, err := p.key()
+	if err != nil {
+		return nil, err
+	}
+	closing := "]"
+	if array {
+		closing = "]]"
+	}
+	if !strings.HasPrefix(p.src[p.pos:], closing) {
+		return nil, p.errorf("tablo başlığında %q bekleniyordu", closing)
+	}
+	p.pos += len(closing)
+	parent, err := p.descend(root, keys[:len(keys)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := keys[len(keys)-1]
+	if array {
+		list, _ := parent[last].([]interface{})
+		if _, exists := parent[last]; exists && list == nil {
+			return nil, p.errorf("%q bir tablo dizisi değil", strings.Join(keys, "."))
+		}
+		table := make(map[string]interface{})
+		parent[last] = append(list, table)
+		return table, nil
+	}
+	path := fmt.Sprintf("%p.%s", parent, last)
+	if p.defined[path] {
+		return nil, p.errorf("tablo %q iki kez tanımlandı", strings.Join(keys, "."))
+	}
+	p.defined[path] = true
+	return p.descend(parent, []string{last})
+}
+
+// descend, noktalı anahtar yolundaki tabloları (yoksa oluşturarak) izler. Yol bir tablo dizisinden
+// geçiyorsa dizinin son elemanı kullanılır.
+func (p *tomlParser) descend(table map[string]interface{}, keys []string) (map[string]interface{}, error) {
+	for _, k := range keys {
+		switch v := table[k].(type) {
+		case nil:
+			next := make(map[string]interface{})
+			table[k] = next
+			table = next
+		case map[string]interface{}:
+			table = v
+		case []interface{}:
+			last, ok := v[len(v)-1].(map[string]interface{})
+			if !ok {
+				return nil, p.errorf("%q bir tablo değil", k)
+			}
+			table = last
+		default:
+			return nil, p.errorf("%q bir tablo değil", k)
+		}
+	}
+	return table, nil
+}
+
+func (p *tomlParser) keyValue(table map[string]interface{}) error {
+	keys, err := p.key()
+	if err != nil {
+		return err
+	}
+	if p.pos >= len(p.src) || p.src[p.pos] != '=' {
+		return p.errorf("%q anahtarından sonra '=' bekleniyordu", strings.Join(keys, "."))
+	}
+	p.pos++
+	p.skipSpace()
+	v, err := p.value()
+	if err != nil {
+		return err
+	}
+	parent, err := p.descend(table, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+	last := keys[len(keys)-1]
+	if _, exists := parent[last]; exists {
+		return p.errorf("tekrarlanan anahtar %q", strings.Join(keys, "."))
+	}
+	parent[last] = v
+	return nil
+}
+
+// key, çıplak veya tırnaklı parçalardan oluşan (noktalı) anahtarı okur.
+func (p *tomlParser) key() ([]string, error) {
+	var keys []string
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.src) {
+			return nil, p.errorf("anahtar bekleniyordu")
+		}
+		switch c := p.src[p.pos]; {
+		case c == '"' || c == '\'':
+			s, err := p.str()
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, s)
+		default:
+			start := p.pos
+			for p.pos < len(p.src) && isTOMLBareKey(p.src[p.pos]) {
+				p.pos++
+			}
+			if start == p.pos {
+				return nil, p.errorf("geçersiz anahtar karakteri %q", c)
+			}
+			keys = append(keys, p.src[start:p.pos])
+		}
+		p.skipSpace()
+		if p.pos >= len(p.src) || p.src[p.pos] != '.' {
+			return keys, nil
+		}
+		p.pos++
+	}
+}
+
+func isTOMLBareKey(c byte) bool {
+	return c == '_' || c == '-' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func (p *tomlParser) value() (interface{}, error) {
+	if p.pos >= len(p.src) {
+		return nil, p.errorf("değer bekleniyordu")
+	}
+	switch c := p.src[p.pos]; c {
+	case '"', '\'':
+		return p.str()
+	case '[':
+		return p.array()
+	case '{':
+		return p.inlineTable()
+	}
+	start := p.pos
+	for p.pos < len(p.src) && !strings.ContainsRune(" \t\n,]}#", rune(p.src[p.pos])) {
+		p.pos++
+	}
+	// "1979-05-27 07:32:00" gibi boşlukla ayrılmış tarih ve saat
+	if p.pos-start == 10 && p.pos+1 < len(p.src) && p.src[p.pos] == ' ' && p.src[p.pos+1] >= '0' && p.src[p.pos+1] <= '9' {
+		p.pos++
+		for p.pos < len(p.src) && !strings.ContainsRune(" \t\n,]}#", rune(p.src[p.pos])) {
+			p.pos++
+		}
+	}
+	tok := p.src[start:p.pos]
+	switch tok {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "inf", "+inf", "-inf", "nan", "+nan", "-nan":
+		f, _ := strconv.ParseFloat(strings.Replace(tok, "inf", "Inf", 1), 64)
+		return f, nil
+	}
+	num := strings.ReplaceAll(tok, "_", "")
+	for prefix, base := range map[string]int{"0x": 16, "0o": 8, "0b": 2} {
+		if strings.HasPrefix(num, prefix) {
+			if n, err := strconv.ParseInt(num[2:], base, 64); err == nil {
+				return int(n), nil
+			}
+		}
+	}
+	if n, err := strconv.ParseInt(num, 10, 64); err == nil {
+		return int(n), nil
+	}
+	if looksNumeric(num) {
+		if f, err := strconv.ParseFloat(num, 64); err == nil {
+			return f, nil
+		}
+	}
+	if t, ok := parseDateTime(tok); ok {
+		return t, nil
+	}
+	if t, err := time.Parse("15:04:05.999999999", tok); err == nil {
+		return t, nil
+	}
+	if tok == "" {
+		return nil, p.errorf("değer bekleniyordu")
+	}
+	return nil, p.errorf("geçersiz değer %q", tok)
+}
+
+// str, temel ("..."), literal ('...') ve çok satırlı (""" / ”') string'leri okur.
+func (p *tomlParser) str() (string, error) {
+	quote := p.src[p.pos]
+	delim := string(quote)
+	if strings.HasPrefix(p.src[p.pos:], strings.Repeat(delim, 3)) {
+		delim = strings.Repeat(delim, 3)
+	}
+	p.pos += len(delim)
+	multi := len(delim) == 3
+	if multi && p.pos < len(p.src) && p.src[p.pos] == '\n' {
+		p.pos++ // açılıştan hemen sonraki satır sonu atlanır
+	}
+	var sb strings.Builder
+	for {
+		if p.pos >= len(p.src) || (!multi && p.src[p.pos] == '\n') {
+			return "", p.errorf("kapatılmamış string")
+		}
+		if strings.HasPrefix(p.src[p.pos:], delim) {
+			p.pos += len(delim)
+			return sb.String(), nil
+		}
+		c := p.src[p.pos]
+		if c != '\\' || quote == '\'' {
+			r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+			sb.WriteRune(r)
+			p.pos += size
+			continue
+		}
+		p.pos++
+		if p.pos >= len(p.src) {
+			return "", p.errorf("kapatılmamış string")
+		}
+		esc := p.src[p.pos]
+		p.pos++
+		switch esc {
+		case 'n':
+			sb.WriteByte('\n')
+		case 't':
+			sb.WriteByte('\t')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'b':
+			sb.WriteByte('\b')
+		case 'f':
+			sb.WriteByte('\f')
+		case '"', '\\':
+			sb.WriteByte(esc)
+		case 'u', 'U':
+			n := 4
+			if esc == 'U' {
+				n = 8
+			}
+			if p.pos+n > len(p.src) {
+				return "", p.errorf("geçersiz unicode kaçışı")
+			}
+			code, err := strconv.ParseUint(p.src[p.pos:p.pos+n], 16, 32)
+			if err != nil {
+				return "", p.errorf("geçersiz unicode kaçışı")
+			}
+			sb.WriteRune(rune(code))
+			p.pos += n
+		case ' ', '\t', '\n':
+			// çok satırlı string'de satır sonu kaçışı: sonraki boşluklar atlanır
+			if !multi {
+				return "", p.errorf("geçersiz kaçış karakteri")
+			}
+			p.pos--
+			for p.pos < len(p.src) && strings.ContainsRune(" \t\n", rune(p.src[p.pos])) {
+				p.pos++
+			}
+		default:
+			return "", p.errorf("geçersiz kaçış karakteri \\%c", esc)
+		}
+	}
+}
+
+func (p *tomlParser) array() (interface{}, error) {
+	p.pos++
+	list := []interface{}{}
+	for {
+		p.skipBlank()
+		if p.pos < len(p.src) && p.src[p.pos] == ']' {
+			p.pos++
+			return list, nil
+		}
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+		p.skipBlank()
+		if p.pos >= len(p.src) {
+			return nil, p.errorf("kapatılmamış dizi")
+		}
+		switch p.src[p.pos] {
+		case ',':
+			p.pos++
+		case ']':
+		default:
+			return nil, p.errorf("dizide ',' veya ']' bekleniyordu")
+		}
+	}
+}
+
+func (p *tomlParser) inlineTable() (interface{}, error) {
+	p.pos++
+	table := make(map[string]interface{})
+	p.skipSpace()
+	if p.pos < len(p.src) && p.src[p.pos] == '}' {
+		p.pos++
+		return table, nil
+	}
+	for {
+		if err := p.keyValue(table); err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.pos >= len(p.src) {
+			return nil, p.errorf("kapatılmamış satır içi tablo")
+		}
+		switch p.src[p.pos] {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return table, nil
+		default:
+			return nil, p.errorf("satır içi tabloda ',' veya '}' bekleniyordu")
+		}
+	}
+}
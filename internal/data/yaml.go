@@ -0,0 +1,414 @@
+// yaml.go
+// Context ve front matter dosyaları için YAML alt kümesi çözücü
+package data
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type yamlParser struct {
+	lines []string
+	i     int
+}
+
+type yamlError struct {
+	line int
+	msg  string
+}
+
+func (e *yamlError) Error() string {
+	return fmt.Sprintf("yaml: satır %d: %s", e.line, e.msg)
+}
+
+// decodeYAML, YAML kaynağını map[string]interface{}, []interface{}, string, int, float64, bool,
+// time.Time (tırnaksız tarihler) veya nil değerlerine çözer.
+func decodeYAML(src string) (interface{}, error) {
+	p := &yamlParser{lines: strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")}
+	v, err := p.value(0)
+	if err != nil {
+		return nil, err
+	}
+	if i, err := p.peek(); err != nil {
+		return nil, err
+	} else if i != -1 {
+		return nil, p.errorf(i, "beklenmeyen içerik: %s", strings.TrimSpace(p.lines[i]))
+	}
+	return v, nil
+}
+
+func (p *yamlParser) errorf(line int, format string, args ...interface{}) error {
+	return &yamlError{line: line + 1, msg: fmt.Sprintf(format, args...)}
+}
+
+// peek, boş ve yorum satırlarını atlayarak sıradaki anlamlı satırın indeksini döndürür; yoksa -1.
+func (p *yamlParser) peek() (int, error) {
+	for ; p.i < len(p.lines); p.i++ {
+		line := p.lines[p.i]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || line == "---" {
+			continue
+		}
+		if line == "..." {
+			p.i = len(p.lines)
+			break
+		}
+		if strings.HasPrefix(strings.TrimLeft(line, " "), "\t") {
+			return -1, p.errorf(p.i, "girintide tab kullanılamaz")
+		}
+		return p.i, nil
+	}
+	return -1, nil
+}
+
+func (p *yamlParser) indent(i int) int {
+	return len(p.lines[i]) - len(strings.TrimLeft(p.lines[i], " "))
+}
+
+func (p *yamlParser) text(i int) string {
+	return strings.TrimSpace(p.lines[i])
+}
+
+func isYAMLSeqItem(t string) bool {
+	return t == "-" || strings.HasPrefix(t, "- ")
+}
+
+// value, en az minIndent girintili sıradaki değeri (map, liste veya skaler) okur.
+func (p *yamlParser) value(minIndent int) (interface{}, error) {
+	i, err := p.peek()
+	if err != nil || i == -1 || p.indent(i) < minIndent {
+		return nil, err
+	}
+	t := p.text(i)
+	if isYAMLSeqItem(t) {
+		return p.seq(p.indent(i))
+	}
+	if _, _, ok := splitYAMLKey(t); ok {
+		return p.mapping(p.indent(i))
+	}
+	p.i++
+	return p.scalar(i, stripYAMLComment(t))
+}
+
+func (p *yamlParser) mapping(indent int) (interface{}, error) {
+	m := make(map[string]interface{})
+	for {
+		i, err := p.peek()
+		if err != nil {
+			return nil, err
+		}
+		if i == -1 || p.indent(i) < indent {
+			return m, nil
+		}
+		if p.indent(i) > indent {
+			return nil, p.errorf(i, "beklenmeyen girinti")
+		}
+		t := p.text(i)
+		if isYAMLSeqItem(t) {
+			return nil, p.errorf(i, "map içinde liste elemanı")
+		}
+		key, rest, ok := splitYAMLKey(t)
+		if !ok {
+			return nil, p.errorf(i, "'anahtar: değer' bekleniyordu: %s", t)
+		}
+		if _, dup := m[key]; dup {
+			return nil, p.errorf(i, "tekrarlanan anahtar %q", key)
+		}
+		p.i++
+		rest = stripYAMLComment(rest)
+		var v interface{}
+		switch {
+		case rest == "":
+			next, err := p.peek()
+			if err != nil {
+				return nil, err
+			}
+			if next != -1 && p.indent(next) > indent {
+				v, err = p.value(p.indent(next))
+			} else if next != -1 && p.indent(next) == indent && isYAMLSeqItem(p.text(next)) {
+				v, err = p.seq(indent)
+			}
+			if err != nil {
+				return nil, err
+			}
+		case rest[0] == '|' || rest[0] == '>':
+			v = p.blockScalar(rest, indent)
+		default:
+			if v, err = p.scalar(i, rest); err != nil {
+				return nil, err
+			}
+		}
+		m[key] = v
+	}
+}
+
+func (p *yamlParser) seq(indent int) (interface{}, error) {
+	list := []interface{}{}
+	for {
+		i, err := p.peek()
+		if err != nil {
+			return nil, err
+		}
+		if i == -1 || p.indent(i) < indent || !isYAMLSeqItem(p.text(i)) {
+			return list, nil
+		}
+		if p.indent(i) > indent {
+			return nil, p.errorf(i, "beklenmeyen girinti")
+		}
+		t := p.text(i)
+		rest := strings.TrimLeft(t[1:], " ")
+		offset := indent + len(t) - len(rest)
+		rest = stripYAMLComment(rest)
+		p.i++
+		var v interface{}
+		switch {
+		case rest == "":
+			v, err = p.value(indent + 1)
+		case isYAMLSeqItem(rest), yamlMapStart(rest):
+			// "- - a" veya "- ad: x": eleman, satırın geri kalanından başlayan girintili blok olarak okunur
+			p.i--
+			p.lines[p.i] = strings.Repeat(" ", offset) + rest
+			v, err = p.value(offset)
+		case rest[0] == '|' || rest[0] == '>':
+			v = p.blockScalar(rest, indent)
+		default:
+			v, err = p.scalar(i, rest)
+		}
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+}
+
+func yamlMapStart(t string) bool {
+	if t[0] == '[' || t[0] == '{' {
+		return false
+	}
+	_, _, ok := splitYAMLKey(t)
+	return ok
+}
+
+// blockScalar, "|" (satırları korur) veya ">" (satırları birleştirir) blok metnini okur. "-" son
+// satır sonlarını siler, "+" korur; varsayılan tek satır sonu bırakır.
+func (p *yamlParser) blockScalar(header string, parentIndent int) string {
+	var lines []string
+	contentIndent := -1
+	for ; p.i < len(p.lines); p.i++ {
+		line := p.lines[p.i]
+		if strings.TrimSpace(line) == "" {
+			lines = append(lines, "")
+			continue
+		}
+		ind := p.indent(p.i)
+		if ind <= parentIndent || (contentIndent != -1 && ind < contentIndent) {
+			break
+		}
+		if contentIndent == -1 {
+			contentIndent = ind
+		}
+		lines = append(lines, line[contentIndent:])
+	}
+	var sb strings.Builder
+	for i, line := range lines {
+		switch {
+		case i == 0:
+		case header[0] == '|' || line == "" || lines[i-1] == "" || strings.HasPrefix(line, " "):
+			sb.WriteByte('\n')
+		default:
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(line)
+	}
+	text := sb.String()
+	switch {
+	case strings.Contains(header, "-"):
+		return strings.TrimRight(text, "\n")
+	case strings.Contains(header, "+"):
+		return text + "\n"
+	}
+	text = strings.TrimRight(text, "\n")
+	if text == "" {
+		return ""
+	}
+	return text + "\n"
+}
+
+func (p *yamlParser) scalar(line int, s string) (interface{}, error) {
+	v, err := parseYAMLScalar(s)
+	if err != nil {
+		return nil, p.errorf(line, "%v", err)
+	}
+	return v, nil
+}
+
+// parseYAMLScalar, tek satırlık bir YAML değerini (flow liste/map dahil) çözer.
+func parseYAMLScalar(s string) (interface{}, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	switch s[0] {
+	case '"':
+		v, err := strconv.Unquote(s)
+		if err != nil {
+			return nil, fmt.Errorf("geçersiz string: %s", s)
+		}
+		return v, nil
+	case '\'':
+		if len(s) < 2 || s[len(s)-1] != '\'' {
+			return nil, fmt.Errorf("kapatılmamış string: %s", s)
+		}
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	case '[':
+		if s[len(s)-1] != ']' {
+			return nil, fmt.Errorf("kapatılmamış liste: %s", s)
+		}
+		list := []interface{}{}
+		for _, item := range splitYAMLFlow(s[1 : len(s)-1]) {
+			v, err := parseYAMLScalar(item)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	case '{':
+		if s[len(s)-1] != '}' {
+			return nil, fmt.Errorf("kapatılmamış map: %s", s)
+		}
+		m := make(map[string]interface{})
+		for _, item := range splitYAMLFlow(s[1 : len(s)-1]) {
+			key, rest, ok := splitYAMLKey(item)
+			if !ok {
+				return nil, fmt.Errorf("'anahtar: değer' bekleniyordu: %s", item)
+			}
+			v, err := parseYAMLScalar(rest)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = v
+		}
+		return m, nil
+	}
+	switch s {
+	case "~", "null", "Null", "NULL":
+		return nil, nil
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	}
+	if n, err := strconv.Atoi(strings.TrimPrefix(s, "+")); err == nil {
+		return n, nil
+	}
+	if looksNumeric(s) {
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f, nil
+		}
+	}
+	if t, ok := parseDateTime(s); ok {
+		return t, nil
+	}
+	return s, nil
+}
+
+// looksNumeric, ParseFloat'ın "inf", "nan" gibi kelimeleri sayı saymasını engeller.
+func looksNumeric(s string) bool {
+	s = strings.TrimLeft(s, "+-")
+	return s != "" && (s[0] >= '0' && s[0] <= '9' || s[0] == '.' && len(s) > 1 && s[1] >= '0' && s[1] <= '9')
+}
+
+// splitYAMLKey, "anahtar: değer" satırını ayırır. Anahtar tırnaklı olabilir; ":" sonrasında boşluk
+// veya satır sonu gelmelidir (ör: "http://..." anahtar sayılmaz).
+func splitYAMLKey(t string) (key, rest string, ok bool) {
+	if t == "" {
+		return "", "", false
+	}
+	if t[0] == '"' || t[0] == '\'' {
+		end := strings.IndexByte(t[1:], t[0])
+		if end == -1 {
+			return "", "", false
+		}
+		after := t[end+2:]
+		if !strings.HasPrefix(after, ":") || (len(after) > 1 && after[1] != ' ') {
+			return "", "", false
+		}
+		v, err := parseYAMLScalar(t[:end+2])
+		if err != nil {
+			return "", "", false
+		}
+		return v.(string), strings.TrimSpace(after[1:]), true
+	}
+	if t[0] == '#' || t[0] == '[' || t[0] == '{' {
+		return "", "", false
+	}
+	for i := 0; i < len(t); i++ {
+		if t[i] == ' ' && i+1 < len(t) && t[i+1] == '#' {
+			return "", "", false
+		}
+		if t[i] == ':' && (i+1 == len(t) || t[i+1] == ' ') {
+			return strings.TrimSpace(t[:i]), strings.TrimSpace(t[i+1:]), true
+		}
+	}
+	return "", "", false
+}
+
+// stripYAMLComment, tırnak dışındaki " #" yorumunu siler.
+func stripYAMLComment(s string) string {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			if i == 0 || strings.ContainsRune(" [{,:", rune(s[i-1])) {
+				quote = c
+			}
+		case c == '#' && (i == 0 || s[i-1] == ' '):
+			return strings.TrimSpace(s[:i])
+		}
+	}
+	return strings.TrimSpace(s)
+}
+
+// splitYAMLFlow, flow liste/map içeriğini tırnak ve parantez dışındaki virgüllerden böler.
+func splitYAMLFlow(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		case c == ',' && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	parts = append(parts, s[start:])
+	var out []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
@@ -0,0 +1,67 @@
+// lexer.go
+// Template kaynağını düz metin ve {{ ... }} tag token'larına ayırır
+package hipoengine
+
+import "strings"
+
+// TokenKind, token türünü belirtir.
+type TokenKind int
+
+const (
+	TokenText TokenKind = iota // tag'lar arasındaki düz metin
+	TokenTag                   // {{ ... }} tag'ı
+)
+
+// Token, template kaynağındaki tek bir metin parçası veya tag'dır.
+type Token struct {
+	Kind    TokenKind
+	Raw     string // kaynaktaki ham hali ({{ ve }} dahil)
+	Content string // tag için trimlenmiş içerik, metin için Raw ile aynı
+	Name    string // tag içeriğinin ilk kelimesi (if, endif, for...)
+	Offset  int    // kaynaktaki başlangıç offset'i
+	Line    int
+	Column  int
+}
+
+// Args, tag adından sonra gelen kısmı döndürür.
+func (t Token) Args() string {
+	return strings.TrimSpace(t.Content[len(t.Name):])
+}
+
+// Tokenize, template kaynağını sıralı token listesine ayırır. Kapanmamış bir tag'dan
+// sonraki tüm içerik tek bir metin token'ı olarak döner.
+func Tokenize(src string) []Token {
+	var tokens []Token
+	pos := 0
+	line, col := 1, 1
+	advance := func(s string) {
+		for _, c := range s {
+			if c == '\n' {
+				line++
+				col = 1
+			} else {
+				col++
+			}
+		}
+	}
+	for pos < len(src) {
+		t, ok := nextTag(src, pos)
+		textEnd := len(src)
+		if ok {
+			textEnd = t.start
+		}
+		if textEnd > pos {
+			text := src[pos:textEnd]
+			tokens = append(tokens, Token{Kind: TokenText, Raw: text, Content: text, Offset: pos, Line: line, Column: col})
+			advance(text)
+		}
+		if !ok {
+			break
+		}
+		raw := src[t.start:t.end]
+		tokens = append(tokens, Token{Kind: TokenTag, Raw: raw, Content: t.content, Name: t.name, Offset: t.start, Line: line, Column: col})
+		advance(raw)
+		pos = t.end
+	}
+	return tokens
+}
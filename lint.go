@@ -0,0 +1,459 @@
+// lint.go
+// Template'ler için statik kontroller (hipo lint ve editör tanılamaları)
+package hipoengine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Lint ve parse tanılamalarının önem dereceleri
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// LintIssue, linter'ın bulduğu tek bir sorundur.
+type LintIssue struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Severity string `json:"severity"`
+	Rule     string `json:"rule"`
+	Message  string `json:"message"`
+
+	EndLine   int `json:"endLine,omitempty"` // sorunlu aralığın bittiği (hariç) konum
+	EndColumn int `json:"endColumn,omitempty"`
+}
+
+func (i LintIssue) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s (%s)", i.File, i.Line, i.Column, i.Severity, i.Message, i.Rule)
+}
+
+// blockEnds, gövde alan built-in tag'ların kapanış tag'larıdır.
+var blockEnds = map[string]string{
+	"if": "endif", "for": "endfor", "with": "endwith", "switch": "endswitch", "macro": "endmacro",
+	"component": "endcomponent", "block": "endblock", "slot": "endslot",
+}
+
+// Lint, dosyayı okuyup LintSource ile kontrol eder.
+func (e *Engine) Lint(filename string) ([]LintIssue, error) {
+	content, err := e.ReadFileCached(filename)
+	if err != nil {
+		return nil, err
+	}
+	return e.LintSource(content, filename), nil
+}
+
+// LintSource, template kaynağını engine'in filtre/fonksiyon/test kayıtlarına ve template arama
+// yollarına göre kontrol eder:
+//   - parse-error, unbalanced-tag: parse hataları ve eşleşmeyen açılış/kapanış tag'ları
+//   - unknown-filter, unknown-function, unknown-test: kayıtlı olmayan isimler
+//   - unresolved-include: bulunamayan include/component/extends hedefleri
+//   - undefined-block: layout'ta olmayan bir bloğu override eden child blokları
+//   - unreachable-branch: önceki bir dalla aynı koşula sahip veya her zaman doğru koşuldan sonra gelen
+//     elif/else ve hiçbir zaman doğru olmayan dallar (iki tarafı literal olan karşılaştırmalar sabit
+//     olarak değerlendirilir)
+//   - unused-set: dosyada ve dosyanın include/extends ile render ettiği template'lerde hiç okunmayan
+//     set değişkenleri
+func (e *Engine) LintSource(src, filename string) []LintIssue {
+	l := &linter{e: e, file: filename}
+	desc, err := ParseSFC(src)
+	if err != nil {
+		l.parseError(err)
+		return l.issues
+	}
+	l.source = desc.Source
+	content := desc.Source
+	if desc.Template != nil {
+		content = desc.Template.Content
+		l.base = desc.Template.ContentStart
+	} else if desc.FrontMatter != nil {
+		content = desc.Source[desc.FrontMatter.End:]
+		l.base = desc.FrontMatter.End
+	}
+	tokens := Tokenize(content)
+	l.checkTags(tokens)
+	if _, err := e.templateParser(desc, filename, false).Parse(); err != nil {
+		l.parseError(err)
+	}
+	l.checkRefs(tokens)
+	sort.SliceStable(l.issues, func(i, j int) bool {
+		if l.issues[i].Line != l.issues[j].Line {
+			return l.issues[i].Line < l.issues[j].Line
+		}
+		return l.issues[i].Column < l.issues[j].Column
+	})
+	return l.issues
+}
+
+type linter struct {
+	e        *Engine
+	file     string
+	source   string
+	base     int
+	issues   []LintIssue
+	reported map[[2]int]bool // unbalanced-tag ile bildirilen tag'ların konumları
+}
+
+func (l *linter) report(tok Token, severity, rule, format string, args ...interface{}) {
+	line, col := getLineCol(l.source, l.base+tok.Offset)
+	l.issues = append(l.issues, LintIssue{File: l.file, Line: line, Column: col, Severity: severity, Rule: rule, Message: fmt.Sprintf(format, args...)})
+}
+
+// unbalanced, unbalanced-tag olarak bildirilen tag'ı (kapanışı eksik açılış tag'ı veya bloğu
+// dışında kalan ara/kapanış tag'ı) işaretler; parser'ın aynı tag için verdiği hata tekrar raporlanmaz.
+func (l *linter) unbalanced(tok Token) {
+	line, col := getLineCol(l.source, l.base+tok.Offset)
+	if l.reported == nil {
+		l.reported = map[[2]int]bool{}
+	}
+	l.reported[[2]int{line, col}] = true
+}
+
+// parseError, parse hatalarını ekler. checkTags'in zaten bildirdiği dengesiz bloklar (ör:
+// kapatılmamış if) tekrar eklenmez.
+func (l *linter) parseError(err error) {
+	errs := TemplateErrors(err)
+	if errs == nil {
+		l.issues = append(l.issues, LintIssue{File: l.file, Line: 1, Column: 1, Severity: SeverityError, Rule: "parse-error", Message: err.Error()})
+		return
+	}
+	for _, te := range errs {
+		if l.reported[[2]int{te.Line, te.Column}] {
+			continue
+		}
+		severity := te.Severity
+		if severity == "" {
+			severity = SeverityError
+		}
+		l.issues = append(l.issues, LintIssue{File: l.file, Line: te.Line, Column: te.Column, Severity: severity, Rule: "parse-error", Message: te.Message, EndLine: te.EndLine, EndColumn: te.EndColumn})
+	}
+}
+
+// openTag, checkTags'in açık blok yığınındaki bir tag'dır.
+type openTag struct {
+	tok    Token
+	end    string
+	conds  map[string]bool // if: önceki dalların koşulları
+	always bool            // if: önceki dallardan biri her zaman doğru
+}
+
+// checkTags, açılış/kapanış tag'larının dengesini ve erişilemeyen if dallarını kontrol eder.
+func (l *linter) checkTags(tokens []Token) {
+	ends := map[string]bool{}
+	for _, end := range blockEnds {
+		ends[end] = true
+	}
+	tags := l.e.tagSpecs()
+	for _, spec := range tags {
+		if spec.endTag != "" {
+			ends[spec.endTag] = true
+		}
+	}
+	var stack []*openTag
+	// top, kapanışı opsiyonel olan slot'ları atlayarak en üstteki açık tag'ı döndürür
+	top := func(name string) *openTag {
+		for i := len(stack) - 1; i >= 0; i-- {
+			if stack[i].tok.Name != "slot" || name == "endslot" {
+				return stack[i]
+			}
+		}
+		return nil
+	}
+	for _, tok := range tokens {
+		if tok.Kind != TokenTag {
+			continue
+		}
+		name := tok.Name
+		end := blockEnds[name]
+		if spec, ok := tags[name]; ok {
+			end = spec.endTag
+		}
+		switch {
+		case end != "":
+			open := &openTag{tok: tok, end: end}
+			if name == "if" {
+				cond := strings.Join(strings.Fields(tok.Args()), " ")
+				open.conds = map[string]bool{cond: true}
+				open.always = l.constant(tok, cond)
+			}
+			stack = append(stack, open)
+		case name == "elif" || name == "else":
+			t := top(name)
+			if t == nil || t.tok.Name != "if" {
+				l.report(tok, SeverityError, "unbalanced-tag", "{{ %s }} bir if bloğu içinde değil", name)
+				l.unbalanced(tok)
+				continue
+			}
+			if t.always {
+				l.report(tok, SeverityWarning, "unreachable-branch", "{{ %s }} dalına hiç ulaşılamaz: önceki koşul her zaman doğru", name)
+				continue
+			}
+			if name == "elif" {
+				cond := strings.Join(strings.Fields(tok.Args()), " ")
+				if t.conds[cond] {
+					l.report(tok, SeverityWarning, "unreachable-branch", "elif dalına hiç ulaşılamaz: '%s' koşulu önceki bir dalda var", cond)
+				}
+				t.conds[cond] = true
+				t.always = l.constant(tok, cond)
+			}
+		case name == "case" || name == "default":
+			if t := top(name); t == nil || t.tok.Name != "switch" {
+				l.report(tok, SeverityError, "unbalanced-tag", "{{ %s }} bir switch bloğu içinde değil", name)
+				l.unbalanced(tok)
+			}
+		case ends[name]:
+			idx := -1
+			for i := len(stack) - 1; i >= 0; i-- {
+				if stack[i].end == name {
+					idx = i
+					break
+				}
+				if stack[i].tok.Name != "slot" {
+					break
+				}
+			}
+			if idx == -1 {
+				if t := top(name); t != nil {
+					l.report(tok, SeverityError, "unbalanced-tag", "{{ %s }} beklenirken {{ %s }} bulundu", t.end, name)
+				} else {
+					l.report(tok, SeverityError, "unbalanced-tag", "{{ %s }} için açılış tag'ı yok", name)
+				}
+				l.unbalanced(tok)
+				// Daha dıştaki eşleşen bloğa kadar olan açık tag'ları kapat
+				for i := len(stack) - 1; i >= 0; i-- {
+					if stack[i].end == name {
+						for _, open := range stack[i+1:] {
+							l.unbalanced(open.tok)
+						}
+						stack = stack[:i]
+						break
+					}
+				}
+				continue
+			}
+			stack = stack[:idx]
+		}
+	}
+	for _, open := range stack {
+		if open.tok.Name == "slot" {
+			continue
+		}
+		l.unbalanced(open.tok)
+		l.report(open.tok, SeverityError, "unbalanced-tag", "{{ %s }} kapatılmamış, {{ %s }} eksik", open.tok.Name, open.end)
+	}
+}
+
+// constant, koşulu engine'in derleme anındaki sabit değerlendirmesiyle kontrol eder: hiçbir zaman
+// doğru olmayan dalı bildirir ve koşulun her zaman doğru olup olmadığını döndürür.
+func (l *linter) constant(tok Token, cond string) bool {
+	res, ok := compileCondition(cond).constant()
+	if ok && !res {
+		l.report(tok, SeverityWarning, "unreachable-branch", "{{ %s }} dalına hiç ulaşılamaz: '%s' koşulu hiçbir zaman doğru değil", tok.Name, cond)
+	}
+	return ok && res
+}
+
+// checkRefs, filtre/fonksiyon/test adlarını, include hedeflerini, extends bloklarını ve set
+// değişkenlerinin kullanımını kontrol eder.
+func (l *linter) checkRefs(tokens []Token) {
+	macros := map[string]bool{"loop": true}
+	for _, tok := range tokens {
+		if tok.Kind == TokenTag && tok.Name == "macro" {
+			if m, err := parseMacroSignature(tok.Args()); err == nil {
+				macros[m.Name] = true
+			}
+		}
+	}
+	var extends string
+	var childBlocks []Token
+	var sets []Token
+	var renders []string // include/extends hedefleri: çağıranın değişkenlerini görürler
+	used := map[string]bool{}
+	for _, tok := range tokens {
+		if tok.Kind != TokenTag {
+			continue
+		}
+		switch tok.Name {
+		case "include", "component", "extends":
+			target := tok.Args()
+			if tok.Name == "component" {
+				if fields := splitFields(target); len(fields) > 0 {
+					target = fields[0]
+				}
+			}
+			target = strings.TrimSpace(strings.Trim(target, `"'`))
+			if tok.Name == "extends" {
+				extends = target
+			}
+			if tok.Name != "component" {
+				renders = append(renders, target)
+			}
+			if _, err := l.e.resolveTemplatePath(target); err != nil {
+				l.report(tok, SeverityError, "unresolved-include", "%s hedefi bulunamadı: %s", tok.Name, target)
+			}
+		case "block":
+			childBlocks = append(childBlocks, tok)
+		case "set":
+			sets = append(sets, tok)
+		}
+		a := tagRefs(tok, l.e.tagSpecs())
+		for _, name := range sortedSet(a.sets["filter"]) {
+			if _, ok := l.e.filters[name]; !ok {
+				l.report(tok, SeverityError, "unknown-filter", "bilinmeyen filtre: %s", name)
+			}
+		}
+		for _, name := range sortedSet(a.sets["func"]) {
+			if _, ok := l.e.funcs[name]; !ok && !macros[name] {
+				l.report(tok, SeverityError, "unknown-function", "bilinmeyen fonksiyon: %s", name)
+			}
+		}
+		for _, name := range sortedSet(a.sets["test"]) {
+			if _, ok := l.e.tests[name]; !ok {
+				l.report(tok, SeverityError, "unknown-test", "bilinmeyen test: %s", name)
+			}
+		}
+		for path := range a.sets["var"] {
+			used[strings.SplitN(path, ".", 2)[0]] = true
+		}
+	}
+	if extends != "" {
+		if ast, err := l.e.ParseFile(extends); err == nil {
+			defined := map[string]bool{}
+			for _, b := range Blocks(ast) {
+				defined[b] = true
+			}
+			for _, tok := range childBlocks {
+				if name := tok.Args(); !defined[name] {
+					l.report(tok, SeverityWarning, "undefined-block", "'%s' bloğu %s içinde tanımlı değil", name, extends)
+				}
+			}
+		}
+	}
+	// Component'ler izole scope'ta render edildiği için yalnızca prop'ları okur; include ve extends
+	// hedeflerinin (ve onların include/extends ettiklerinin) okuduğu değişkenler kullanılmış sayılır
+	if l.readsOf(renders, used) {
+		for _, tok := range sets {
+			args := tok.Args()
+			eq := strings.Index(args, "=")
+			if eq == -1 {
+				continue
+			}
+			if name := strings.TrimSpace(args[:eq]); !used[name] {
+				l.report(tok, SeverityWarning, "unused-set", "'%s' değişkeni atanıyor ancak hiç kullanılmıyor", name)
+			}
+		}
+	}
+}
+
+// readsOf, verilen template'lerin ve onların include/extends ile render ettiği template'lerin
+// okuduğu değişkenleri used'a ekler. Hedeflerden biri bulunamaz veya parse edilemezse okunan
+// değişkenler bilinemeyeceği için false döner.
+func (l *linter) readsOf(files []string, used map[string]bool) bool {
+	seen := map[string]bool{}
+	for len(files) > 0 {
+		file := files[0]
+		files = files[1:]
+		key := l.e.templateKey(file)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		ast, err := l.e.ParseFile(file)
+		if err != nil {
+			return false
+		}
+		info := Analyze(ast)
+		for _, path := range info.Variables {
+			used[strings.SplitN(path, ".", 2)[0]] = true
+		}
+		files = append(files, info.Includes...)
+		files = append(files, info.Extends...)
+	}
+	return true
+}
+
+func sortedSet(set map[string]bool) []string {
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// tagRefs, tek bir tag'ın ifadelerinde geçen değişken, fonksiyon, filtre ve testleri toplar.
+// Scope bilgisi kullanılmaz; for/with/macro ile tanımlanan isimler de değişken olarak döner.
+func tagRefs(tok Token, tags map[string]*tagSpec) *analyzer {
+	a := &analyzer{sets: map[string]map[string]bool{}}
+	s := &analyzeScope{a: a, names: map[string]bool{}}
+	args := tok.Args()
+	switch tok.Name {
+	case "if", "elif":
+		s.cond(args)
+	case "for":
+		inner := strings.TrimSpace(strings.TrimSuffix(args, " recursive"))
+		if idx := indexOutsideQuotes(inner, " if "); idx != -1 {
+			s.cond(strings.TrimSpace(inner[idx+len(" if "):]))
+			inner = inner[:idx]
+		}
+		if parts := strings.Fields(inner); len(parts) == 3 {
+			s.expr(parts[2])
+		} else if len(parts) == 2 {
+			s.expr(parts[0])
+		}
+	case "with":
+		if parts := strings.Fields(args); len(parts) > 0 {
+			s.expr(parts[0])
+		}
+	case "switch":
+		s.expr(args)
+	case "case":
+		for _, v := range splitArgs(args) {
+			s.expr(strings.TrimSpace(v))
+		}
+	case "set":
+		if eq := strings.Index(args, "="); eq != -1 {
+			if rhs := strings.TrimSpace(args[eq+1:]); rhs != "" {
+				s.Visit(parseVariable(rhs))
+			}
+		}
+	case "component":
+		for _, f := range splitFields(args) {
+			if eq := strings.Index(f, "="); eq > 0 {
+				s.expr(f[eq+1:])
+			}
+		}
+	case "macro":
+		if m, err := parseMacroSignature(args); err == nil {
+			for _, p := range m.Params {
+				if p.Default != "" {
+					s.expr(p.Default)
+				}
+			}
+		}
+	default:
+		if builtinTags[tok.Name] || tags[tok.Name] != nil || isEndTag(tok.Name, tags) {
+			break
+		}
+		s.Visit(parseVariable(tok.Content))
+	}
+	return a
+}
+
+// isEndTag, adın bir blok kapanış tag'ı olup olmadığını döndürür.
+func isEndTag(name string, tags map[string]*tagSpec) bool {
+	for _, end := range blockEnds {
+		if end == name {
+			return true
+		}
+	}
+	for _, spec := range tags {
+		if spec.endTag == name {
+			return true
+		}
+	}
+	return false
+}
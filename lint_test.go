@@ -0,0 +1,134 @@
+package hipoengine
+
+import (
+	"reflect"
+	"testing"
+)
+
+func lintRules(issues []LintIssue) []string {
+	var rules []string
+	for _, i := range issues {
+		rules = append(rules, i.Rule)
+	}
+	return rules
+}
+
+func TestLintSource(t *testing.T) {
+	e := NewEngine()
+	e.RegisterFunction("getProducts", func(args ...interface{}) interface{} { return nil })
+	src := `<template>
+{{ set unused = "x" }}{{ set title = page.title|upper }}<h1>{{ title|shout }}</h1>
+{{ macro badge(t) }}<b>{{ t }}</b>{{ endmacro }}{{ badge("a") }}
+{{ for p in getProducts() }}{{ p.name }}{{ endfor }}{{ for p in fetchAll() }}{{ endfor }}
+{{ if user.age > 18 }}a{{ elif user.age > 18 }}b{{ elif 1 == 1 }}c{{ else }}d{{ endif }}
+{{ if user is weird }}x{{ endif }}
+</template>
+<style>p { color: red; }</style>`
+	issues := e.LintSource(src, "page.hipo")
+	want := []LintIssue{
+		{File: "page.hipo", Line: 2, Column: 1, Severity: SeverityWarning, Rule: "unused-set", Message: "'unused' değişkeni atanıyor ancak hiç kullanılmıyor"},
+		{File: "page.hipo", Line: 2, Column: 61, Severity: SeverityError, Rule: "unknown-filter", Message: "bilinmeyen filtre: shout"},
+		{File: "page.hipo", Line: 4, Column: 53, Severity: SeverityError, Rule: "unknown-function", Message: "bilinmeyen fonksiyon: fetchAll"},
+		{File: "page.hipo", Line: 5, Column: 24, Severity: SeverityWarning, Rule: "unreachable-branch", Message: "elif dalına hiç ulaşılamaz: 'user.age > 18' koşulu önceki bir dalda var"},
+		{File: "page.hipo", Line: 5, Column: 67, Severity: SeverityWarning, Rule: "unreachable-branch", Message: "{{ else }} dalına hiç ulaşılamaz: önceki koşul her zaman doğru"},
+		{File: "page.hipo", Line: 6, Column: 1, Severity: SeverityError, Rule: "unknown-test", Message: "bilinmeyen test: weird"},
+	}
+	if !reflect.DeepEqual(issues, want) {
+		t.Errorf("LintSource sonucu farklı:\n%v\nbeklenen:\n%v", issues, want)
+	}
+}
+
+func TestLintUnbalancedTags(t *testing.T) {
+	e := NewEngine()
+	issues := e.LintSource("{{ if a }}\n{{ for x in xs }}{{ x }}{{ endif }}\n{{ endwith }}{{ else }}", "t.hipo")
+	want := []string{"unbalanced-tag", "unbalanced-tag", "unbalanced-tag"}
+	if got := lintRules(issues); !reflect.DeepEqual(got, want) {
+		t.Fatalf("rules: got %v, want %v (%v)", got, want, issues)
+	}
+	if issues[0].Line != 2 || issues[0].Message != "{{ endfor }} beklenirken {{ endif }} bulundu" {
+		t.Errorf("ilk sorun beklenenden farklı: %v", issues[0])
+	}
+	if issues[1].Line != 3 || issues[1].Column != 1 {
+		t.Errorf("endwith konumu hatalı: %v", issues[1])
+	}
+
+	// Kapatılmamış blok ve parse hatası tekrar raporlanmaz
+	issues = e.LintSource("{{ if a }}x", "t.hipo")
+	if len(issues) != 1 || issues[0].Message != "{{ if }} kapatılmamış, {{ endif }} eksik" {
+		t.Errorf("kapatılmamış if: %v", issues)
+	}
+
+	// Kapanışı opsiyonel slot'lar dengesiz sayılmaz
+	if issues := e.LintSource(`<div>{{ slot }}{{ if x }}{{ slot footer }}-{{ endslot }}{{ endif }}</div>`, "c.hipo"); len(issues) != 0 {
+		t.Errorf("slot için beklenmeyen sorunlar: %v", issues)
+	}
+}
+
+func TestLintParseErrors(t *testing.T) {
+	e := NewEngine()
+	issues := e.LintSource("{{ set x }}\n{{ if a }}{{ break }}{{ endif }}", "t.hipo")
+	want := []LintIssue{
+		{File: "t.hipo", Line: 1, Column: 1, Severity: SeverityError, Rule: "parse-error", Message: "set ifadesinde '=' eksik", EndLine: 1, EndColumn: 12},
+		{File: "t.hipo", Line: 2, Column: 11, Severity: SeverityError, Rule: "parse-error", Message: "break outside of for loop", EndLine: 2, EndColumn: 22},
+	}
+	if !reflect.DeepEqual(issues, want) {
+		t.Errorf("parse hataları:\n%v\nbeklenen:\n%v", issues, want)
+	}
+}
+
+func TestLintFiles(t *testing.T) {
+	e := NewEngine()
+	e.AddTemplatePath("testdata/lint")
+	issues, err := e.Lint("child.hipo")
+	if err != nil {
+		t.Fatalf("Lint error: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Rule != "undefined-block" || issues[0].Line != 4 {
+		t.Errorf("undefined-block bekleniyordu: %v", issues)
+	}
+
+	issues = e.LintSource(`{{ include "yok.hipo" }}{{ component "base.hipo" }}{{ endcomponent }}`, "x.hipo")
+	if got := lintRules(issues); !reflect.DeepEqual(got, []string{"unresolved-include"}) {
+		t.Errorf("unresolved-include bekleniyordu: %v", issues)
+	}
+}
+
+func TestLintConstantConditions(t *testing.T) {
+	e := NewEngine()
+	issues := e.LintSource(`{{ if 2 > 3 }}a{{ elif x }}b{{ endif }}{{ if "a" == "a" }}c{{ elif y }}d{{ endif }}`, "t.hipo")
+	want := []LintIssue{
+		{File: "t.hipo", Line: 1, Column: 1, Severity: SeverityWarning, Rule: "unreachable-branch", Message: "{{ if }} dalına hiç ulaşılamaz: '2 > 3' koşulu hiçbir zaman doğru değil"},
+		{File: "t.hipo", Line: 1, Column: 60, Severity: SeverityWarning, Rule: "unreachable-branch", Message: "{{ elif }} dalına hiç ulaşılamaz: önceki koşul her zaman doğru"},
+	}
+	if !reflect.DeepEqual(issues, want) {
+		t.Errorf("sabit koşullar:\n%v\nbeklenen:\n%v", issues, want)
+	}
+}
+
+func TestLintUnusedSetAcrossIncludes(t *testing.T) {
+	e := NewEngine()
+	e.AddTemplatePath("testdata/lint")
+	// title ve subtitle include edilen partial'lar tarafından okunur; component izole scope'ta
+	// çalıştığı için label'ı göremez
+	src := `{{ set title = "a" }}{{ set subtitle = "b" }}{{ set label = "c" }}{{ set unused = "d" }}
+{{ include "partials/outer.hipo" }}{{ component "partials/inner.hipo" }}{{ endcomponent }}`
+	issues := e.LintSource(src, "page.hipo")
+	var names []string
+	for _, i := range issues {
+		if i.Rule != "unused-set" {
+			t.Errorf("beklenmeyen sorun: %v", i)
+			continue
+		}
+		names = append(names, i.Message)
+	}
+	want := []string{"'label' değişkeni atanıyor ancak hiç kullanılmıyor", "'unused' değişkeni atanıyor ancak hiç kullanılmıyor"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("unused-set: got %v, want %v", names, want)
+	}
+
+	// Okunan değişkenler bilinemiyorsa (bulunamayan include) kural çalışmaz
+	issues = e.LintSource(`{{ set x = 1 }}{{ include "yok.hipo" }}`, "page.hipo")
+	if got := lintRules(issues); !reflect.DeepEqual(got, []string{"unresolved-include"}) {
+		t.Errorf("bulunamayan include ile unused-set raporlanmamalı: %v", issues)
+	}
+}
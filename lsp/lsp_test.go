@@ -0,0 +1,198 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"hipoengine"
+)
+
+type message struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *responseError  `json:"error"`
+}
+
+// session, verilen mesajları sunucuya gönderir ve sunucunun yazdığı tüm mesajları döndürür.
+func session(t *testing.T, engine *hipoengine.Engine, msgs ...interface{}) []message {
+	t.Helper()
+	var in bytes.Buffer
+	for _, m := range msgs {
+		if err := writeMessage(&in, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var out bytes.Buffer
+	if err := NewServer(engine).Serve(&in, &out); err != nil {
+		t.Fatalf("Serve error: %v", err)
+	}
+	var got []message
+	r := bufio.NewReader(&out)
+	for {
+		body, err := readMessage(r)
+		if err == io.EOF {
+			return got
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		var m message
+		if err := json.Unmarshal(body, &m); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, m)
+	}
+}
+
+func call(id int, method string, params interface{}) map[string]interface{} {
+	return map[string]interface{}{"jsonrpc": "2.0", "id": id, "method": method, "params": params}
+}
+
+func notice(method string, params interface{}) map[string]interface{} {
+	return map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params}
+}
+
+func at(uri string, line, char int) map[string]interface{} {
+	return map[string]interface{}{"textDocument": map[string]string{"uri": uri}, "position": Position{Line: line, Character: char}}
+}
+
+func result(t *testing.T, msgs []message, id int, v interface{}) {
+	t.Helper()
+	for _, m := range msgs {
+		if m.ID != nil && *m.ID == id {
+			if m.Error != nil {
+				t.Fatalf("istek %d hata döndü: %v", id, m.Error.Message)
+			}
+			if err := json.Unmarshal(m.Result, v); err != nil {
+				t.Fatalf("istek %d sonucu: %v", id, err)
+			}
+			return
+		}
+	}
+	t.Fatalf("istek %d için yanıt yok", id)
+}
+
+func TestServer(t *testing.T) {
+	engine := hipoengine.NewEngine()
+	engine.AddTemplatePath("../testdata/deps")
+	page, _ := filepath.Abs("../testdata/deps/page.hipo")
+	pageURI := pathToURI(page)
+	const doc = "{{ block main }}\n  <p>{{ name|uper }} {{ name|upper }}</p>\n  {{ macro btn(label) }}<b>{{ label }}</b>{{ endmacro }}\n{{ endblock }}\n{{ x| }} {{ "
+	docURI := "file:///tmp/doc.hipo"
+
+	msgs := session(t, engine,
+		call(1, "initialize", map[string]interface{}{"rootUri": nil}),
+		notice("initialized", map[string]interface{}{}),
+		notice("textDocument/didOpen", map[string]interface{}{"textDocument": map[string]string{"uri": docURI, "text": doc}}),
+		call(2, "textDocument/completion", at(docURI, 4, 5)),
+		call(3, "textDocument/completion", at(docURI, 4, 12)),
+		call(4, "textDocument/hover", at(docURI, 1, 31)),
+		call(5, "textDocument/documentSymbol", map[string]interface{}{"textDocument": map[string]string{"uri": docURI}}),
+		call(6, "textDocument/definition", at(pageURI, 2, 50)),
+		call(7, "textDocument/definition", at(pageURI, 2, 11)),
+		call(8, "textDocument/unknown", map[string]interface{}{}),
+		call(9, "shutdown", nil),
+		notice("exit", nil),
+	)
+
+	var caps struct {
+		Capabilities map[string]interface{} `json:"capabilities"`
+	}
+	result(t, msgs, 1, &caps)
+	for _, c := range []string{"completionProvider", "definitionProvider", "hoverProvider", "documentSymbolProvider"} {
+		if caps.Capabilities[c] == nil {
+			t.Errorf("capability eksik: %s", c)
+		}
+	}
+
+	// Tanılama: bilinmeyen filtre, tag aralığıyla
+	var diag publishDiagnosticsParams
+	for _, m := range msgs {
+		if m.Method == "textDocument/publishDiagnostics" {
+			json.Unmarshal(m.Params, &diag)
+		}
+	}
+	found := false
+	for _, d := range diag.Diagnostics {
+		if d.Code == "unknown-filter" {
+			found = true
+			if want := (Range{Start: Position{1, 5}, End: Position{1, 20}}); d.Range != want || d.Severity != severityError {
+				t.Errorf("unknown-filter tanılaması: got %+v", d)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("unknown-filter tanılaması yok: %+v", diag.Diagnostics)
+	}
+
+	var items []CompletionItem
+	result(t, msgs, 2, &items)
+	if !hasLabel(items, "upper") || hasLabel(items, "trans") {
+		t.Errorf("filtre tamamlaması hatalı: %v", items)
+	}
+	items = nil
+	result(t, msgs, 3, &items)
+	if !hasLabel(items, "trans") || hasLabel(items, "upper") {
+		t.Errorf("fonksiyon tamamlaması hatalı: %v", items)
+	}
+
+	var hover Hover
+	result(t, msgs, 4, &hover)
+	if !strings.Contains(hover.Contents.Value, "**upper**") || !strings.Contains(hover.Contents.Value, "büyük harf") {
+		t.Errorf("hover: got %q", hover.Contents.Value)
+	}
+
+	var symbols []DocumentSymbol
+	result(t, msgs, 5, &symbols)
+	if len(symbols) != 1 || symbols[0].Name != "main" || len(symbols[0].Children) != 1 || symbols[0].Children[0].Name != "btn" {
+		t.Fatalf("semboller: got %+v", symbols)
+	}
+	if want := (Range{Start: Position{0, 0}, End: Position{3, 14}}); symbols[0].Range != want {
+		t.Errorf("block aralığı: got %+v, want %+v", symbols[0].Range, want)
+	}
+
+	var loc Location
+	result(t, msgs, 6, &loc)
+	if !strings.HasSuffix(loc.URI, "testdata/deps/partials/footer.hipo") {
+		t.Errorf("include tanımı: got %s", loc.URI)
+	}
+	result(t, msgs, 7, &loc)
+	if !strings.HasSuffix(loc.URI, "testdata/deps/layouts/base.hipo") || loc.Range.Start != (Position{1, 45}) {
+		t.Errorf("block tanımı: got %+v", loc)
+	}
+
+	for _, m := range msgs {
+		if m.ID != nil && *m.ID == 8 && (m.Error == nil || m.Error.Code != codeMethodNotFound) {
+			t.Errorf("bilinmeyen metot: got %+v", m)
+		}
+	}
+}
+
+func hasLabel(items []CompletionItem, label string) bool {
+	for _, item := range items {
+		if item.Label == label {
+			return true
+		}
+	}
+	return false
+}
+
+func TestPositionUTF16(t *testing.T) {
+	text := "şü😀a\nb"
+	if got := positionAt(text, strings.Index(text, "a")); got != (Position{0, 4}) {
+		t.Errorf("positionAt: got %+v", got)
+	}
+	if got := offsetAt(text, Position{0, 4}); got != strings.Index(text, "a") {
+		t.Errorf("offsetAt: got %d", got)
+	}
+	if got := offsetOfLineCol(text, 1, 4); got != strings.Index(text, "a") {
+		t.Errorf("offsetOfLineCol: got %d", got)
+	}
+}
@@ -0,0 +1,522 @@
+// server.go
+// hipo lsp: tanılama, tamamlama, tanıma gitme, hover ve doküman sembolleri sunan dil sunucusu
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"hipoengine"
+)
+
+// Server, stdio üzerinden konuşan bir LSP sunucusudur. Tanılamalar engine.LintSource ile,
+// tamamlama ve hover engine'in filtre/fonksiyon kayıtlarıyla, tanıma gitme ise engine'in template
+// arama yolları ve alias'larıyla üretilir. Mesajlar sırayla işlenir.
+type Server struct {
+	engine   *hipoengine.Engine
+	docs     map[string]string // uri -> açık dokümanın güncel içeriği
+	out      io.Writer
+	shutdown bool
+}
+
+// NewServer, verilen engine'i kullanan bir sunucu oluşturur.
+func NewServer(engine *hipoengine.Engine) *Server {
+	return &Server{engine: engine, docs: make(map[string]string)}
+}
+
+// Serve, in'den gelen mesajları işler ve yanıtları out'a yazar. exit bildirimi veya in'in sonunda
+// döner; exit'ten önce shutdown alınmamışsa hata döner.
+func (s *Server) Serve(in io.Reader, out io.Writer) error {
+	s.out = out
+	r := bufio.NewReader(in)
+	for {
+		body, err := readMessage(r)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var req request
+		if err := json.Unmarshal(body, &req); err != nil {
+			s.replyError(json.RawMessage("null"), codeParseError, err.Error())
+			continue
+		}
+		if req.Method == "exit" {
+			if !s.shutdown {
+				return errors.New("lsp: shutdown almadan exit")
+			}
+			return nil
+		}
+		result, rerr := s.handle(req)
+		if req.ID == nil {
+			continue
+		}
+		if rerr != nil {
+			s.replyError(req.ID, rerr.Code, rerr.Message)
+			continue
+		}
+		if err := writeMessage(s.out, response{JSONRPC: "2.0", ID: req.ID, Result: result}); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) replyError(id json.RawMessage, code int, message string) {
+	writeMessage(s.out, errorResponse{JSONRPC: "2.0", ID: id, Error: responseError{Code: code, Message: message}})
+}
+
+func (s *Server) notify(method string, params interface{}) {
+	writeMessage(s.out, notification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+func (s *Server) handle(req request) (interface{}, *responseError) {
+	if s.shutdown && req.Method != "exit" {
+		return nil, &responseError{Code: codeInvalidRequest, Message: "sunucu kapatılıyor"}
+	}
+	decode := func(v interface{}) *responseError {
+		if err := json.Unmarshal(req.Params, v); err != nil {
+			return &responseError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		return nil
+	}
+	switch req.Method {
+	case "initialize":
+		var p initializeParams
+		if err := decode(&p); err != nil {
+			return nil, err
+		}
+		if root := uriToPath(p.RootURI); root != "" {
+			s.engine.AddTemplatePath(root)
+		}
+		return map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync":       syncFull,
+				"completionProvider":     map[string]interface{}{"triggerCharacters": []string{"|", "{", " "}},
+				"definitionProvider":     true,
+				"hoverProvider":          true,
+				"documentSymbolProvider": true,
+			},
+			"serverInfo": map[string]string{"name": "hipo"},
+		}, nil
+	case "initialized":
+		return nil, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var p didOpenParams
+		if err := decode(&p); err != nil {
+			return nil, err
+		}
+		s.docs[p.TextDocument.URI] = p.TextDocument.Text
+		s.publishDiagnostics(p.TextDocument.URI)
+		return nil, nil
+	case "textDocument/didChange":
+		var p didChangeParams
+		if err := decode(&p); err != nil {
+			return nil, err
+		}
+		if n := len(p.ContentChanges); n > 0 {
+			s.docs[p.TextDocument.URI] = p.ContentChanges[n-1].Text
+		}
+		s.publishDiagnostics(p.TextDocument.URI)
+		return nil, nil
+	case "textDocument/didSave":
+		var p didSaveParams
+		if err := decode(&p); err != nil {
+			return nil, err
+		}
+		if p.Text != nil {
+			s.docs[p.TextDocument.URI] = *p.Text
+		}
+		s.publishDiagnostics(p.TextDocument.URI)
+		return nil, nil
+	case "textDocument/didClose":
+		var p documentParams
+		if err := decode(&p); err != nil {
+			return nil, err
+		}
+		delete(s.docs, p.TextDocument.URI)
+		s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: p.TextDocument.URI, Diagnostics: []Diagnostic{}})
+		return nil, nil
+	case "textDocument/completion":
+		var p positionParams
+		if err := decode(&p); err != nil {
+			return nil, err
+		}
+		return s.completion(p), nil
+	case "textDocument/hover":
+		var p positionParams
+		if err := decode(&p); err != nil {
+			return nil, err
+		}
+		if h := s.hover(p); h != nil {
+			return h, nil
+		}
+		return nil, nil
+	case "textDocument/definition":
+		var p positionParams
+		if err := decode(&p); err != nil {
+			return nil, err
+		}
+		if loc := s.definition(p); loc != nil {
+			return loc, nil
+		}
+		return nil, nil
+	case "textDocument/documentSymbol":
+		var p documentParams
+		if err := decode(&p); err != nil {
+			return nil, err
+		}
+		return s.documentSymbols(p.TextDocument.URI), nil
+	}
+	if req.ID == nil {
+		// Bilinmeyen bildirimler ($/cancelRequest, workspace/didChangeConfiguration...) yok sayılır
+		return nil, nil
+	}
+	return nil, &responseError{Code: codeMethodNotFound, Message: "bilinmeyen metot: " + req.Method}
+}
+
+// document, açık dokümanın içeriğini; açık değilse diskteki halini döndürür.
+func (s *Server) document(uri string) (string, bool) {
+	if text, ok := s.docs[uri]; ok {
+		return text, true
+	}
+	content, err := os.ReadFile(uriToPath(uri))
+	if err != nil {
+		return "", false
+	}
+	return string(content), true
+}
+
+func (s *Server) publishDiagnostics(uri string) {
+	text := s.docs[uri]
+	diags := []Diagnostic{}
+	for _, issue := range s.engine.LintSource(text, uriToPath(uri)) {
+		start := offsetOfLineCol(text, issue.Line, issue.Column)
+		end := diagnosticEnd(text, start)
+		if issue.EndLine > 0 {
+			end = offsetOfLineCol(text, issue.EndLine, issue.EndColumn)
+		}
+		severity := severityWarning
+		if issue.Severity == hipoengine.SeverityError {
+			severity = severityError
+		}
+		diags = append(diags, Diagnostic{
+			Range:    Range{Start: positionAt(text, start), End: positionAt(text, end)},
+			Severity: severity,
+			Code:     issue.Rule,
+			Source:   "hipo",
+			Message:  issue.Message,
+		})
+	}
+	s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: uri, Diagnostics: diags})
+}
+
+// diagnosticEnd, tanılama aralığının sonudur: start bir tag başıysa tag'ın sonu, değilse satır sonu.
+func diagnosticEnd(text string, start int) int {
+	lineEnd := strings.IndexByte(text[start:], '\n')
+	if lineEnd == -1 {
+		lineEnd = len(text)
+	} else {
+		lineEnd += start
+	}
+	if strings.HasPrefix(text[start:], "{{") {
+		if end := strings.Index(text[start:lineEnd], "}}"); end != -1 {
+			return start + end + 2
+		}
+	}
+	return lineEnd
+}
+
+// completion, imleç bir filtre konumundaysa ("|" sonrası) filtreleri, tag içindeyse fonksiyonları önerir.
+func (s *Server) completion(p positionParams) []CompletionItem {
+	items := []CompletionItem{}
+	text, ok := s.document(p.TextDocument.URI)
+	if !ok {
+		return items
+	}
+	offset := offsetAt(text, p.Position)
+	open := strings.LastIndex(text[:offset], "{{")
+	if open == -1 || strings.Contains(text[open:offset], "}}") {
+		return items
+	}
+	before := strings.TrimRight(strings.TrimRightFunc(text[open+2:offset], isIdentRune), " ")
+	if strings.HasSuffix(before, "|") {
+		for _, name := range s.engine.FilterNames() {
+			item := CompletionItem{Label: name, Kind: completionFunction, Detail: "filtre"}
+			if doc := s.engine.FilterDoc(name); doc != "" {
+				item.Documentation = &MarkupContent{Kind: "markdown", Value: doc}
+			}
+			items = append(items, item)
+		}
+		return items
+	}
+	for _, name := range s.engine.FunctionNames() {
+		items = append(items, CompletionItem{Label: name, Kind: completionFunction, Detail: "fonksiyon"})
+	}
+	return items
+}
+
+// hover, imlecin altındaki filtrenin açıklamasını döndürür.
+func (s *Server) hover(p positionParams) *Hover {
+	text, ok := s.document(p.TextDocument.URI)
+	if !ok {
+		return nil
+	}
+	offset := offsetAt(text, p.Position)
+	tok, ok := tagAt(text, offset)
+	if !ok {
+		return nil
+	}
+	start, end := wordAt(text, offset, tok.Offset+2)
+	if start == end || !strings.HasSuffix(strings.TrimRight(text[tok.Offset:start], " "), "|") {
+		return nil
+	}
+	name := text[start:end]
+	names := s.engine.FilterNames()
+	if i := sort.SearchStrings(names, name); i == len(names) || names[i] != name {
+		return nil
+	}
+	doc := s.engine.FilterDoc(name)
+	if doc == "" {
+		doc = "Açıklama yok."
+	}
+	return &Hover{
+		Contents: MarkupContent{Kind: "markdown", Value: "**" + name + "** (filtre)\n\n" + doc},
+		Range:    &Range{Start: positionAt(text, start), End: positionAt(text, end)},
+	}
+}
+
+// definition, include/extends/component tag'larında hedef template'e, block tag'larında extends
+// zincirindeki layout'ta aynı adlı bloğa gider.
+func (s *Server) definition(p positionParams) *Location {
+	text, ok := s.document(p.TextDocument.URI)
+	if !ok {
+		return nil
+	}
+	tok, ok := tagAt(text, offsetAt(text, p.Position))
+	if !ok {
+		return nil
+	}
+	switch tok.Name {
+	case "include", "extends", "component":
+		path, err := s.engine.ResolveTemplatePath(quoted(tok.Args()))
+		if err != nil {
+			return nil
+		}
+		return &Location{URI: pathToURI(path)}
+	case "block":
+		return s.blockDefinition(text, tok.Args())
+	}
+	return nil
+}
+
+func (s *Server) blockDefinition(text, name string) *Location {
+	seen := make(map[string]bool)
+	for {
+		base := ""
+		for _, tok := range hipoengine.Tokenize(text) {
+			if tok.Kind == hipoengine.TokenTag && tok.Name == "extends" {
+				base = quoted(tok.Args())
+				break
+			}
+		}
+		if base == "" {
+			return nil
+		}
+		path, err := s.engine.ResolveTemplatePath(base)
+		if err != nil {
+			return nil
+		}
+		uri := pathToURI(path)
+		if seen[uri] {
+			return nil
+		}
+		seen[uri] = true
+		content, ok := s.document(uri)
+		if !ok {
+			return nil
+		}
+		text = content
+		for _, tok := range hipoengine.Tokenize(text) {
+			if tok.Kind == hipoengine.TokenTag && tok.Name == "block" && tok.Args() == name {
+				return &Location{URI: uri, Range: Range{Start: positionAt(text, tok.Offset), End: positionAt(text, tok.Offset+len(tok.Raw))}}
+			}
+		}
+	}
+}
+
+// documentSymbols, dokümandaki blok ve macro'ları iç içe semboller olarak döndürür.
+func (s *Server) documentSymbols(uri string) []DocumentSymbol {
+	symbols := []DocumentSymbol{}
+	text, ok := s.document(uri)
+	if !ok {
+		return symbols
+	}
+	tree, err := s.engine.ParseSyntax(text, uriToPath(uri))
+	if err != nil {
+		return symbols
+	}
+	return appendSymbols(symbols, text, tree.Nodes)
+}
+
+func appendSymbols(symbols []DocumentSymbol, text string, nodes []*hipoengine.SyntaxNode) []DocumentSymbol {
+	for _, n := range nodes {
+		if n.Kind != hipoengine.SyntaxBlock {
+			symbols = appendSymbols(symbols, text, n.Children)
+			continue
+		}
+		open := n.Children[0].Token
+		last := n.Children[len(n.Children)-1].Token
+		sym := DocumentSymbol{
+			Range:          Range{Start: positionAt(text, open.Offset), End: positionAt(text, last.Offset+len(last.Raw))},
+			SelectionRange: Range{Start: positionAt(text, open.Offset), End: positionAt(text, open.Offset+len(open.Raw))},
+		}
+		switch open.Name {
+		case "block":
+			sym.Name, sym.Kind, sym.Detail = open.Args(), symbolModule, "block"
+		case "macro":
+			sig := open.Args()
+			name := sig
+			if paren := strings.Index(sig, "("); paren != -1 {
+				name = strings.TrimSpace(sig[:paren])
+			}
+			sym.Name, sym.Kind, sym.Detail = name, symbolFunction, "macro "+sig
+		default:
+			symbols = appendSymbols(symbols, text, n.Children[1:])
+			continue
+		}
+		sym.Children = appendSymbols(nil, text, n.Children[1:])
+		symbols = append(symbols, sym)
+	}
+	return symbols
+}
+
+// tagAt, offset'i içeren {{ ... }} tag'ını döndürür.
+func tagAt(text string, offset int) (hipoengine.Token, bool) {
+	for _, tok := range hipoengine.Tokenize(text) {
+		if tok.Offset > offset {
+			break
+		}
+		if tok.Kind == hipoengine.TokenTag && offset < tok.Offset+len(tok.Raw) {
+			return tok, true
+		}
+	}
+	return hipoengine.Token{}, false
+}
+
+// wordAt, offset'teki tanımlayıcının [start, end) aralığını döndürür; min'den geriye gidilmez.
+func wordAt(text string, offset, min int) (int, int) {
+	start, end := offset, offset
+	for start > min {
+		r, size := utf8.DecodeLastRuneInString(text[:start])
+		if !isIdentRune(r) {
+			break
+		}
+		start -= size
+	}
+	for end < len(text) {
+		r, size := utf8.DecodeRuneInString(text[end:])
+		if !isIdentRune(r) {
+			break
+		}
+		end += size
+	}
+	return start, end
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+// quoted, args içindeki ilk tırnaklı değeri döndürür.
+func quoted(args string) string {
+	start := strings.IndexAny(args, `"'`)
+	if start == -1 {
+		return ""
+	}
+	end := strings.IndexByte(args[start+1:], args[start])
+	if end == -1 {
+		return ""
+	}
+	return args[start+1 : start+1+end]
+}
+
+// offsetAt, LSP konumunu (0 tabanlı satır, UTF-16 karakter) byte offset'ine çevirir.
+func offsetAt(text string, pos Position) int {
+	offset := 0
+	for line := 0; line < pos.Line; line++ {
+		nl := strings.IndexByte(text[offset:], '\n')
+		if nl == -1 {
+			return len(text)
+		}
+		offset += nl + 1
+	}
+	for units := 0; units < pos.Character && offset < len(text); {
+		r, size := utf8.DecodeRuneInString(text[offset:])
+		if r == '\n' {
+			break
+		}
+		units += len(utf16.Encode([]rune{r}))
+		offset += size
+	}
+	return offset
+}
+
+// positionAt, byte offset'ini LSP konumuna çevirir.
+func positionAt(text string, offset int) Position {
+	if offset > len(text) {
+		offset = len(text)
+	}
+	lineStart := strings.LastIndexByte(text[:offset], '\n') + 1
+	return Position{
+		Line:      strings.Count(text[:lineStart], "\n"),
+		Character: len(utf16.Encode([]rune(text[lineStart:offset]))),
+	}
+}
+
+// offsetOfLineCol, 1 tabanlı satır ve rune sütununu byte offset'ine çevirir.
+func offsetOfLineCol(text string, line, col int) int {
+	offset := 0
+	for l := 1; l < line; l++ {
+		nl := strings.IndexByte(text[offset:], '\n')
+		if nl == -1 {
+			return len(text)
+		}
+		offset += nl + 1
+	}
+	for c := 1; c < col && offset < len(text) && text[offset] != '\n'; c++ {
+		_, size := utf8.DecodeRuneInString(text[offset:])
+		offset += size
+	}
+	return offset
+}
+
+func uriToPath(uri string) string {
+	if !strings.HasPrefix(uri, "file://") {
+		return uri
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return strings.TrimPrefix(uri, "file://")
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+func pathToURI(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
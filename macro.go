@@ -0,0 +1,66 @@
+// macro.go
+// {{ macro }} tanımları ve template içi çağrılabilirler
+package hipoengine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MacroParam, macro parametresi ve opsiyonel default ifadesidir.
+type MacroParam struct {
+	Name    string
+	Default string // boşsa parametre verilmediğinde nil olur
+}
+
+// MacroNode, {{ macro name(a, b="x") }}...{{ endmacro }} tanımıdır. Çalıştırıldığında macro'yu
+// bulunduğu scope'a ekler; macro kendi gövdesinden recursive olarak çağrılabilir.
+type MacroNode struct {
+	Name   string
+	Params []MacroParam
+	Body   ASTNode
+	Pos    Position
+}
+
+// parseMacroSignature, "name(a, b=1)" imzasını ayrıştırır.
+func parseMacroSignature(sig string) (*MacroNode, error) {
+	open := strings.Index(sig, "(")
+	if open == -1 || !strings.HasSuffix(sig, ")") {
+		return nil, fmt.Errorf("geçersiz macro imzası: %s", sig)
+	}
+	node := &MacroNode{Name: strings.TrimSpace(sig[:open])}
+	if node.Name == "" || strings.ContainsAny(node.Name, " .[") {
+		return nil, fmt.Errorf("geçersiz macro adı: '%s'", node.Name)
+	}
+	for _, param := range splitArgs(sig[open+1 : len(sig)-1]) {
+		param = strings.TrimSpace(param)
+		if param == "" {
+			continue
+		}
+		mp := MacroParam{Name: param}
+		if eq := strings.Index(param, "="); eq != -1 {
+			mp.Name = strings.TrimSpace(param[:eq])
+			mp.Default = strings.TrimSpace(param[eq+1:])
+		}
+		node.Params = append(node.Params, mp)
+	}
+	return node, nil
+}
+
+// Execute, macro'yu mevcut scope'a kaydeder; çıktı üretmez.
+func (n *MacroNode) Execute(ctx *Context) (string, error) {
+	params := make([]string, len(n.Params))
+	defaults := make(map[string]Operand)
+	for i, p := range n.Params {
+		params[i] = p.Name
+		if p.Default != "" {
+			defaults[p.Name] = compileOperand(p.Default)
+		}
+	}
+	ctx.DefineMacro(n.Name, params, defaults, n.Body.Execute)
+	return "", nil
+}
+
+func (n *MacroNode) ExecuteRaw(ctx *Context) (interface{}, error) {
+	return n.Execute(ctx)
+}
@@ -2,10 +2,8 @@ package hipoengine
 
 import (
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
-	"time"
 )
 
 // ASTNode, tüm node türlerinin implement ettiği arayüzdür.
@@ -40,37 +38,35 @@ type VariableNode struct {
 	Filters []FilterCall
 }
 
+var htmlReplacer = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	"\"", "&quot;",
+	"'", "&#39;",
+)
+
 func htmlEscape(s string) string {
-	replacer := strings.NewReplacer(
-		"&", "&amp;",
-		"<", "&lt;",
-		">", "&gt;",
-		"\"", "&quot;",
-		"'", "&#39;",
-	)
-	return replacer.Replace(s)
+	return htmlReplacer.Replace(s)
 }
 
 // Execute, VariableNode'u string olarak render eder (HTML escape ve |safe filtresi uygular).
 func (n *VariableNode) Execute(ctx *Context) (string, error) {
-	val, _ := n.ExecuteRaw(ctx)
-	safe := false
+	val, err := n.ExecuteRaw(ctx)
+	if err != nil {
+		return "", err
+	}
+	return FormatValue(val, n.safe()), nil
+}
+
+// safe, filtre zincirinde |safe olup olmadığını döndürür.
+func (n *VariableNode) safe() bool {
 	for _, filter := range n.Filters {
 		if filter.Name == "safe" {
-			safe = true
+			return true
 		}
 	}
-	if val == nil {
-		return "", nil
-	}
-	if _, ok := val.(map[string]interface{}); ok {
-		return "", nil
-	}
-	str := fmt.Sprintf("%v", val)
-	if !safe {
-		str = htmlEscape(str)
-	}
-	return str, nil
+	return false
 }
 
 // parseFilterArgs, filtre argümanlarını uygun tipe çevirir.
@@ -95,81 +91,30 @@ func (n *VariableNode) ExecuteRaw(ctx *Context) (interface{}, error) {
 	var val interface{}
 
 	// Eğer Name fonksiyon çağrısı ise (ör: trans("cart.items", count))
-	if n.Name != "" && strings.Contains(n.Name, "(") && strings.HasSuffix(n.Name, ")") {
-		openIdx := strings.Index(n.Name, "(")
-		funcName := strings.TrimSpace(n.Name[:openIdx])
-		argsStr := strings.TrimSpace(n.Name[openIdx+1 : len(n.Name)-1])
-		var args []interface{}
-		if argsStr != "" {
-			parts := splitArgs(argsStr)
-			for _, part := range parts {
-				part = strings.TrimSpace(part)
-				if (strings.HasPrefix(part, "\"") && strings.HasSuffix(part, "\"")) || (strings.HasPrefix(part, "'") && strings.HasSuffix(part, "'")) {
-					args = append(args, part[1:len(part)-1])
-				} else {
-					resolved := ctx.Resolve(part)
-					args = append(args, resolved)
-				}
-			}
+	if name, args, ok := parseCall(n.Name); ok {
+		vals := make([]interface{}, len(args))
+		for i, a := range args {
+			vals[i] = a.value(ctx)
 		}
-		if fn, ok := ctx.funcs[funcName]; ok {
-			var fnResult interface{}
-			if ctx.engine != nil && ctx.engine.Profiler != nil {
-				start := time.Now()
-				fnResult = fn(args...)
-				dur := time.Since(start)
-				ctx.engine.Profiler.Add(funcName, "function", dur)
-			} else {
-				fnResult = fn(args...)
-			}
-			val = fnResult
-		} else {
+		res, found, err := ctx.Call(name, vals...)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
 			return "", nil
 		}
+		val = res
 	} else if n.Value != nil {
 		if s, ok := n.Value.(string); ok && strings.Contains(s, "(") {
 			val = ctx.Resolve(s)
 		} else {
 			val = n.Value
 		}
-		for _, filter := range n.Filters {
-			if fn, ok := ctx.filters[filter.Name]; ok {
-				if ctx.engine != nil && ctx.engine.Profiler != nil {
-					start := time.Now()
-					val = fn(val, parseFilterArgs(filter.Args)...)
-					dur := time.Since(start)
-					ctx.engine.Profiler.Add(filter.Name, "filter", dur)
-				} else {
-					val = fn(val, parseFilterArgs(filter.Args)...)
-				}
-			} else {
-				fmt.Fprintf(os.Stderr, "[hipoengine] Uyarı: '%s' isimli filtre bulunamadı.\n", filter.Name)
-				val = fmt.Sprintf("%v [filter %s not found]", val, filter.Name)
-			}
-		}
-		if _, ok := val.(map[string]interface{}); ok && strings.Contains(n.Name, "(") {
-			return "", nil
-		}
-		return val, nil
-	} else if n.Name == "" && n.Value == nil {
-		val = ctx.Resolve("")
 	} else {
 		val = ctx.Resolve(n.Name)
 	}
 	for _, filter := range n.Filters {
-		if fn, ok := ctx.filters[filter.Name]; ok {
-			if ctx.engine != nil && ctx.engine.Profiler != nil {
-				start := time.Now()
-				val = fn(val, parseFilterArgs(filter.Args)...)
-				dur := time.Since(start)
-				ctx.engine.Profiler.Add(filter.Name, "filter", dur)
-			} else {
-				val = fn(val, parseFilterArgs(filter.Args)...)
-			}
-		} else {
-			fmt.Fprintf(os.Stderr, "[hipoengine] Uyarı: '%s' isimli filtre bulunamadı.\n", filter.Name)
-			val = fmt.Sprintf("%v [filter %s not found]", val, filter.Name)
-		}
+		val = ctx.ApplyFilter(filter.Name, val, parseFilterArgs(filter.Args)...)
 	}
 	return val, nil
 }
@@ -214,6 +159,11 @@ func (n *ListNode) Execute(ctx *Context) (string, error) {
 	for _, node := range n.Nodes {
 		out, err := node.Execute(ctx)
 		if err != nil {
+			// break/continue'da o ana kadarki çıktı döngüye iletilir
+			if _, ok := err.(*loopControl); ok {
+				sb.WriteString(out)
+				return sb.String(), err
+			}
 			return "", err
 		}
 		sb.WriteString(out)
@@ -258,6 +208,8 @@ func (n *IfNode) ExecuteRaw(ctx *Context) (interface{}, error) {
 type ForNode struct {
 	VarName    string
 	Collection string
+	Filter     string // opsiyonel: {{ for p in products if p.active }}
+	Recursive  bool   // {{ for node in tree recursive }}: gövdede {{ loop(node.children) }} ile alt seviye render edilir
 	Body       ASTNode
 }
 
@@ -268,34 +220,149 @@ func (n *ForNode) Execute(ctx *Context) (string, error) {
 	if !ok {
 		return "", fmt.Errorf("ForNode: '%s' koleksiyonu []interface{} tipinde değil, değer: %v", n.Collection, col)
 	}
-	var sb strings.Builder
-	for i, item := range arr {
+	return n.render(ctx, arr, 0)
+}
+
+// render, verilen diziyi döngü gövdesiyle render eder. depth, recursive döngülerde seviyeyi tutar.
+func (n *ForNode) render(ctx *Context, arr []interface{}, depth int) (string, error) {
+	items := make([]interface{}, 0, len(arr))
+	for _, item := range arr {
 		if m := toStringMap(item); m != nil {
-			child := ctx.NewChild(map[string]interface{}{n.VarName: m})
-			out, err := n.Body.Execute(child)
-			if err != nil {
-				return "", err
-			}
-			sb.WriteString(out)
-		} else {
-			child := ctx.NewChild(map[string]interface{}{n.VarName: item})
-			out, err := n.Body.Execute(child)
-			if err != nil {
-				return "", err
-			}
-			sb.WriteString(out)
+			item = m
 		}
-		if i != len(arr)-1 {
+		if n.Filter != "" && !evalBool(n.Filter, ctx.NewChild(map[string]interface{}{n.VarName: item})) {
+			continue
+		}
+		items = append(items, item)
+	}
+	var sb strings.Builder
+	for i, item := range items {
+		info := loopInfo(i, len(items))
+		info["depth"] = depth + 1
+		info["depth0"] = depth
+		child := ctx.NewChild(map[string]interface{}{n.VarName: item, "loop": info})
+		if n.Recursive {
+			child.setCallable("loop", func(caller *Context, args ...interface{}) (interface{}, error) {
+				if len(args) == 0 || args[0] == nil || args[0] == "" {
+					return SafeString(""), nil
+				}
+				sub, ok := args[0].([]interface{})
+				if !ok {
+					return nil, fmt.Errorf("ForNode: loop() argümanı []interface{} tipinde değil, değer: %v", args[0])
+				}
+				inner, err := ctx.descend(caller)
+				if err != nil {
+					return nil, err
+				}
+				out, err := n.render(inner, sub, depth+1)
+				return SafeString(out), err
+			})
+		}
+		out, err := n.Body.Execute(child)
+		sb.WriteString(out)
+		if err == errBreak {
+			break
+		}
+		if err != nil && err != errContinue {
+			return "", err
+		}
+		// continue ile çıktı üretmeden kesilen eleman satır sonu eklemez
+		if i != len(items)-1 && !(err == errContinue && out == "") {
 			sb.WriteString("\n")
 		}
 	}
 	return sb.String(), nil
 }
 
+// loopInfo, döngü gövdesinde {{ loop.index }} gibi erişilen döngü bilgisini oluşturur.
+func loopInfo(i, length int) map[string]interface{} {
+	return map[string]interface{}{
+		"index":     i + 1,
+		"index0":    i,
+		"revindex":  length - i,
+		"revindex0": length - i - 1,
+		"first":     i == 0,
+		"last":      i == length-1,
+		"length":    length,
+	}
+}
+
 func (n *ForNode) ExecuteRaw(ctx *Context) (interface{}, error) {
 	return n.Execute(ctx)
 }
 
+// loopControl, break/continue tag'larının döngüye kadar taşınması için kullanılan özel hatadır.
+type loopControl struct {
+	kind string
+}
+
+func (l *loopControl) Error() string {
+	return l.kind + " outside of for loop"
+}
+
+var (
+	errBreak    = &loopControl{kind: "break"}
+	errContinue = &loopControl{kind: "continue"}
+)
+
+// BreakNode, {{ break }} ile en içteki for döngüsünü sonlandırır.
+type BreakNode struct{}
+
+func (n *BreakNode) Execute(ctx *Context) (string, error) {
+	return "", errBreak
+}
+
+func (n *BreakNode) ExecuteRaw(ctx *Context) (interface{}, error) {
+	return n.Execute(ctx)
+}
+
+// ContinueNode, {{ continue }} ile döngünün bir sonraki elemanına geçer.
+type ContinueNode struct{}
+
+func (n *ContinueNode) Execute(ctx *Context) (string, error) {
+	return "", errContinue
+}
+
+func (n *ContinueNode) ExecuteRaw(ctx *Context) (interface{}, error) {
+	return n.Execute(ctx)
+}
+
+// SwitchCase, switch içindeki tek bir case dalı; birden fazla değer virgülle verilebilir.
+type SwitchCase struct {
+	Values []string
+	Body   ASTNode
+}
+
+// SwitchNode, switch/case/default bloklarını tutar.
+type SwitchNode struct {
+	Subject string
+	Cases   []SwitchCase
+	Default ASTNode
+}
+
+// Execute, switch değerini bir kez hesaplar ve eşleşen ilk case gövdesini render eder.
+func (n *SwitchNode) Execute(ctx *Context) (string, error) {
+	subject, err := parseVariable(n.Subject).ExecuteRaw(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, c := range n.Cases {
+		for _, v := range c.Values {
+			if Equal(subject, evalOperand(v, ctx)) {
+				return c.Body.Execute(ctx)
+			}
+		}
+	}
+	if n.Default != nil {
+		return n.Default.Execute(ctx)
+	}
+	return "", nil
+}
+
+func (n *SwitchNode) ExecuteRaw(ctx *Context) (interface{}, error) {
+	return n.Execute(ctx)
+}
+
 // WithNode, with bloğu (alias atanarak yeni context oluşturur).
 type WithNode struct {
 	Expr  string
@@ -317,15 +384,13 @@ func (n *WithNode) ExecuteRaw(ctx *Context) (interface{}, error) {
 // IncludeNode, dosya içeriğini include eder.
 type IncludeNode struct {
 	File string
+	Pos  Position
 }
 
-// Execute, IncludeNode'un dosyasını zincirli context ile render eder.
+// Execute, IncludeNode'un dosyasını çağıranın data'sını paylaşan zincirli context ile render
+// eder (bkz. Context.Include).
 func (n *IncludeNode) Execute(ctx *Context) (string, error) {
-	if ctx.engine == nil {
-		return "", fmt.Errorf("engine not set in context for include")
-	}
-	child := ctx.NewChild(ctx.data)
-	return ctx.engine.RenderFileContext(n.File, child)
+	return ctx.Include(n.File, n.Pos)
 }
 
 func (n *IncludeNode) ExecuteRaw(ctx *Context) (interface{}, error) {
@@ -340,7 +405,7 @@ type BlockNode struct {
 
 // Execute, BlockNode'un gövdesini yeni bir child context ile render eder.
 func (n *BlockNode) Execute(ctx *Context) (string, error) {
-	return n.Body.Execute(ctx.NewChild(nil))
+	return n.Body.Execute(ctx.NewChild(map[string]interface{}{}))
 }
 
 func (n *BlockNode) ExecuteRaw(ctx *Context) (interface{}, error) {
@@ -358,12 +423,21 @@ func (n *ExtendsNode) Execute(ctx *Context) (string, error) {
 	if ctx.engine == nil {
 		return "", fmt.Errorf("engine not set in context for extends")
 	}
+	prev := ctx.files
+	ctx.pushFile(n.BaseFile, true)
+	defer func() { ctx.files = prev }()
+	if cycle := ctx.files.cycle(true); cycle != nil {
+		return "", &CycleError{Cycle: cycle}
+	}
 	baseContent, err := ctx.engine.ReadFileCached(n.BaseFile)
 	if err != nil {
 		return "", err
 	}
-	tpl := extractTemplateBlock(baseContent)
-	baseParser := NewParser(tpl)
+	desc, err := ParseSFC(baseContent)
+	if err != nil {
+		return "", withFile(err, n.BaseFile)
+	}
+	baseParser := ctx.engine.renderParser(desc, n.BaseFile, true)
 	baseAst, err := baseParser.ParseWithBlocks(n.Blocks)
 	if err != nil {
 		return "", err
@@ -387,7 +461,7 @@ func (n *SetNode) Execute(ctx *Context) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	ctx.data[n.VarName] = val
+	ctx.Set(n.VarName, val)
 	return "", nil
 }
 
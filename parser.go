@@ -1,7 +1,9 @@
 package hipoengine
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -9,7 +11,16 @@ import (
 // Parser, template stringini ve opsiyonel dosya adını tutar.
 type Parser struct {
 	template string
-	filename string // opsiyonel, hata mesajı için
+	filename string              // opsiyonel, hata mesajı için
+	source   string              // alt parser'larda kök template (satır/sütun hesabı için)
+	base     int                 // alt parser'ın kök template içindeki başlangıç offset'i
+	nested   bool                // alt parser mı (ör: if/for gövdesi); props yalnızca kökte geçerlidir
+	inLoop   bool                // break/continue yalnızca for gövdesinde geçerlidir
+	closer   string              // kapatılmamış bir bloğun gövdesinde beklenen kapanış tag'ı (hata mesajı için)
+	tags     map[string]*tagSpec // Engine.RegisterTag ile kaydedilen özel tag'lar
+	scope    string              // <style scoped> için HTML elementlerine eklenecek attribute (ör: data-h-1a2b3c4d)
+	cov      *FileCoverage       // coverage modunda dosyanın bölge sayaçları (Engine.EnableCoverage)
+	diags    *[]*TemplateError   // parse sırasında toplanan hatalar; alt parser'larla paylaşılır
 }
 
 // NewParser, template stringiyle yeni bir parser oluşturur.
@@ -34,12 +45,29 @@ type FilterCall struct {
 
 // SetNode: {{ set foo = ... }}
 
-// TemplateError, parse hatalarında satır/sütun/dosya adı ve mesajı tutar.
+// Position, bir node'un kaynak template içindeki konumudur.
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+// position, parser'ın template'i içindeki offset'in konumunu döndürür.
+func (p *Parser) position(offset int) Position {
+	line, col := p.lineCol(offset)
+	return Position{File: p.filename, Line: line, Column: col}
+}
+
+// TemplateError, parse hatalarında satır/sütun/dosya adı ve mesajı tutar. EndLine/EndColumn hatalı
+// aralığın bittiği (hariç) konumdur; bilinmiyorsa 0'dır.
 type TemplateError struct {
-	File    string
-	Line    int
-	Column  int
-	Message string
+	File      string `json:"file"`
+	Line      int    `json:"line"`
+	Column    int    `json:"column"`
+	EndLine   int    `json:"endLine,omitempty"`
+	EndColumn int    `json:"endColumn,omitempty"`
+	Severity  string `json:"severity,omitempty"` // SeverityError veya SeverityWarning; boşsa error
+	Message   string `json:"message"`
 }
 
 func (e *TemplateError) Error() string {
@@ -49,181 +77,316 @@ func (e *TemplateError) Error() string {
 	return fmt.Sprintf("Parse error at line %d, col %d: %s", e.Line, e.Column, e.Message)
 }
 
-// Parse, template'i AST'ye dönüştürür. Hatalı durumda TemplateError döner.
+// ErrorList, parser'ın hatalardan sonra devam ederek topladığı birden fazla tanılamadır; kaynak
+// sırasıyla sıralıdır. errors.As ile ilk TemplateError'a da ulaşılabilir.
+type ErrorList []*TemplateError
+
+func (l ErrorList) Error() string {
+	msgs := make([]string, len(l))
+	for i, e := range l {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (l ErrorList) Unwrap() []error {
+	errs := make([]error, len(l))
+	for i, e := range l {
+		errs[i] = e
+	}
+	return errs
+}
+
+// TemplateErrors, err içindeki tüm TemplateError'ları döndürür (ErrorList, tek bir TemplateError
+// veya bunları sarmalayan bir hata). err bir template hatası değilse nil döner.
+func TemplateErrors(err error) []*TemplateError {
+	var list ErrorList
+	if errors.As(err, &list) {
+		return list
+	}
+	var te *TemplateError
+	if errors.As(err, &te) {
+		return []*TemplateError{te}
+	}
+	return nil
+}
+
+// Parse, template'i AST'ye dönüştürür. Parser bir hatadan sonra durmaz; sonraki tag'lardan devam
+// ederek tüm hataları toplar. Tek hata varsa TemplateError, birden fazlaysa ErrorList döner.
 func (p *Parser) Parse() (ASTNode, error) {
+	return p.collect(p.parse)
+}
+
+// collect, parse fonksiyonunu hataları toplayarak çalıştırır. Başka bir parse sırasında çağrılırsa
+// (ör: TagParser.ParseBody) hatalar dıştaki parse'a eklenir.
+func (p *Parser) collect(parse func() ASTNode) (ASTNode, error) {
+	if p.diags != nil {
+		return parse(), nil
+	}
+	p.diags = &[]*TemplateError{}
+	defer func() { p.diags = nil }()
+	ast := parse()
+	errs := *p.diags
+	switch len(errs) {
+	case 0:
+		return ast, nil
+	case 1:
+		return nil, errs[0]
+	}
+	sort.SliceStable(errs, func(i, j int) bool {
+		if errs[i].Line != errs[j].Line {
+			return errs[i].Line < errs[j].Line
+		}
+		return errs[i].Column < errs[j].Column
+	})
+	return nil, ErrorList(errs)
+}
+
+// errorAt, template'in [start:end) aralığı için konumlu bir TemplateError oluşturur.
+func (p *Parser) errorAt(start, end int, format string, args ...interface{}) *TemplateError {
+	line, col := p.lineCol(start)
+	endLine, endCol := p.lineCol(end)
+	return &TemplateError{File: p.filename, Line: line, Column: col, EndLine: endLine, EndColumn: endCol, Severity: SeverityError, Message: fmt.Sprintf(format, args...)}
+}
+
+// fail, [start:end) aralığındaki hatayı kaydeder; parse bir sonraki tag'dan devam eder.
+func (p *Parser) fail(start, end int, format string, args ...interface{}) {
+	p.record(p.errorAt(start, end, format, args...), start, end)
+}
+
+// record, alt işlemlerden (özel tag, macro imzası, ifade parse'ı) dönen hatayı kaydeder. Konumu
+// olmayan hatalar [start:end) aralığına yerleştirilir.
+func (p *Parser) record(err error, start, end int) {
+	errs := TemplateErrors(err)
+	if errs == nil {
+		errs = []*TemplateError{p.errorAt(start, end, "%s", err.Error())}
+	}
+	for _, te := range errs {
+		if te.Line == 0 {
+			te = p.errorAt(start, end, "%s", te.Message)
+		}
+		if te.File == "" {
+			te.File = p.filename
+		}
+		if te.Severity == "" {
+			te.Severity = SeverityError
+		}
+		*p.diags = append(*p.diags, te)
+	}
+}
+
+// unclosed, kapanış tag'ı bulunamayan t bloğunu hata olarak kaydeder. Açılış tag'ı için zaten
+// hata kaydedildiyse (ör: invalid for syntax) aynı aralığa ikinci bir hata eklenmez. Gövde olarak
+// from'dan template'in sonuna kadarki kısım yine de parse edilerek içindeki hatalar (ör: yanlış
+// kapanış tag'ı) da toplanır; parse'ın devam edeceği offset'i (template'in sonu) döndürür.
+func (p *Parser) unclosed(t tagToken, from int, msg string) int {
+	if !p.reported(t.start, t.end) {
+		p.fail(t.start, t.end, "%s", msg)
+	}
+	body := p.sub(from, len(p.template))
+	body.inLoop = t.name == "for" || (p.inLoop && t.name != "macro")
+	body.closer = blockEnds[t.name]
+	if spec, ok := p.tags[t.name]; ok {
+		body.closer = spec.endTag
+	}
+	body.parse()
+	return len(p.template)
+}
+
+// reported, [start:end) aralığı için daha önce hata kaydedilip kaydedilmediğini döndürür.
+func (p *Parser) reported(start, end int) bool {
+	want := p.errorAt(start, end, "")
+	for _, te := range *p.diags {
+		if te.Line == want.Line && te.Column == want.Column && te.EndLine == want.EndLine && te.EndColumn == want.EndColumn {
+			return true
+		}
+	}
+	return false
+}
+
+// stray, kendi bloğu dışında kalan ara (elif, else, case, default) veya kapanış tag'ını kendi
+// konumunda hata olarak kaydeder.
+func (p *Parser) stray(t tagToken) {
+	switch {
+	case t.name == "elif" || t.name == "else":
+		p.fail(t.start, t.end, "{{ %s }} bir if bloğu içinde değil", t.name)
+	case t.name == "case" || t.name == "default":
+		p.fail(t.start, t.end, "{{ %s }} bir switch bloğu içinde değil", t.name)
+	case p.closer != "":
+		p.fail(t.start, t.end, "{{ %s }} beklenirken {{ %s }} bulundu", p.closer, t.name)
+	default:
+		p.fail(t.start, t.end, "{{ %s }} için açılış tag'ı yok", t.name)
+	}
+}
+
+// parse, template'i AST'ye dönüştürür; hatalar p.diags'e kaydedilir.
+func (p *Parser) parse() ASTNode {
 	tpl := p.template
 	nodes := []ASTNode{}
 
 	trimmed := strings.TrimSpace(tpl)
 	// Extends kontrolü (ilk satır)
 	if strings.HasPrefix(trimmed, "{{ extends ") {
+		lead := len(tpl) - len(strings.TrimLeft(tpl, " \t\r\n"))
 		endIdx := strings.Index(trimmed, "}}")
 		if endIdx == -1 {
-			return nil, &TemplateError{File: p.filename, Line: 1, Column: 1, Message: "unclosed extends tag"}
+			p.fail(lead, len(tpl), "unclosed extends tag")
+			return &ListNode{}
 		}
 		tag := trimmed[:endIdx+2]
 		baseFile := extractExtendsFileName(tag)
 		remain := trimmed[endIdx+2:]
 
 		// Child bloklarını parse et
-		childBlocks, err := ParseBlocks(remain)
+		childBlocks, err := p.parseBlocks(remain, lead+endIdx+2)
 		if err != nil {
-			return nil, err
+			p.record(err, lead, lead+endIdx+2)
 		}
 
-		return &ExtendsNode{BaseFile: baseFile, Blocks: childBlocks}, nil
+		return p.coverFile(&ExtendsNode{BaseFile: baseFile, Blocks: childBlocks})
 	}
 
 	// Template içeriğinde {{ ... }} bloklarını ayrıştır
-	for len(tpl) > 0 {
-		start := strings.Index(tpl, "{{")
+	pos := 0
+	for pos < len(tpl) {
+		start := strings.Index(tpl[pos:], "{{")
 		if start == -1 {
-			if tpl != "" {
-				nodes = append(nodes, &TextNode{Text: tpl})
-			}
+			nodes = append(nodes, p.text(tpl[pos:]))
 			break
 		}
+		start += pos
 		// Öncesindeki metni TextNode olarak ekle
-		if start > 0 {
-			text := tpl[:start]
+		if start > pos {
+			text := tpl[pos:start]
 			if strings.TrimSpace(text) != "" {
-				nodes = append(nodes, &TextNode{Text: text})
+				nodes = append(nodes, p.text(text))
 			}
 		}
-		end := strings.Index(tpl[start:], "}}")
-		if end == -1 {
-			line, col := getLineCol(p.template, len(p.template)-len(tpl)+start)
-			msg := "unclosed variable or block"
-			if p.filename != "" {
-				return nil, &TemplateError{File: p.filename, Line: line, Column: col, Message: msg}
-			}
-			return nil, &TemplateError{File: "", Line: line, Column: col, Message: msg}
+		t, ok := nextTag(tpl, start)
+		if !ok {
+			p.fail(start, len(tpl), "unclosed variable or block")
+			break
 		}
-		tag := strings.TrimSpace(tpl[start+2 : start+end])
+		tag := t.content
+		pos = t.end
 
 		// SET: {{ set foo = ... }}
-		if strings.HasPrefix(tag, "set ") {
-			setExpr := strings.TrimSpace(tag[4:])
+		if t.name == "set" {
+			setExpr := t.args()
 			eqIdx := strings.Index(setExpr, "=")
 			if eqIdx == -1 {
-				return nil, fmt.Errorf("set ifadesinde '=' eksik")
+				p.fail(t.start, t.end, "set ifadesinde '=' eksik")
+				continue
 			}
 			varName := strings.TrimSpace(setExpr[:eqIdx])
 			rhs := strings.TrimSpace(setExpr[eqIdx+1:])
-			// Sağ tarafı yeni bir parser ile parse et (tek bir node beklenir)
-			valAst, err := NewParser(rhs).Parse()
+			valAst, err := p.parseExpr(rhs)
 			if err != nil {
-				return nil, fmt.Errorf("set ifadesi değeri parse edilemedi: %w", err)
-			}
-			// Eğer tek bir node ise, doğrudan onu ata
-			if list, ok := valAst.(*ListNode); ok && len(list.Nodes) == 1 {
-				valAst = list.Nodes[0]
-			}
-			// Eğer node bir TextNode ise ve içeriği değişken/filtre zinciri içeriyorsa tekrar parse et
-			if txt, ok := valAst.(*TextNode); ok {
-				content := strings.TrimSpace(txt.Text)
-				if content != "" {
-					// Eğer content bir literal değilse veya filtre içeriyorsa tekrar parse et
-					if strings.Contains(content, "|") || (!((len(content) > 1 && ((content[0] == '"' && content[len(content)-1] == '"') || (content[0] == '\'' && content[len(content)-1] == '\''))) && !isNumeric(content))) {
-						vnParser := NewParser("{{ " + content + " }}")
-						vnAst, err := vnParser.Parse()
-						if err == nil {
-							if list, ok := vnAst.(*ListNode); ok && len(list.Nodes) == 1 {
-								valAst = list.Nodes[0]
-							} else {
-								valAst = vnAst
-							}
-						}
-					}
+				msg := err.Error()
+				if te, ok := err.(*TemplateError); ok {
+					msg = te.Message
 				}
+				p.fail(t.start, t.end, "set ifadesi değeri parse edilemedi: %s", msg)
+				continue
 			}
 			nodes = append(nodes, &SetNode{VarName: varName, Value: valAst})
-			tpl = tpl[start+end+2:]
 			continue
 		}
 
 		// INCLUDE: {{ include "file" }}
-		if strings.HasPrefix(tag, "include ") {
-			fname := strings.TrimSpace(strings.Trim(tag[8:], `"'`))
-			nodes = append(nodes, &IncludeNode{File: fname})
-			tpl = tpl[start+end+2:]
+		if t.name == "include" {
+			fname := strings.TrimSpace(strings.Trim(t.args(), `"'`))
+			nodes = append(nodes, &IncludeNode{File: fname, Pos: p.position(t.start)})
+			continue
+		}
+
+		// PROPS: {{ props title: string, items: list = [] }} (dosyanın en başında olmalı)
+		if t.name == "props" {
+			if len(nodes) > 0 || p.nested {
+				p.fail(t.start, t.end, "props bildirimi template'in en başında olmalı")
+				continue
+			}
+			decls, err := parseProps(t.args())
+			if err != nil {
+				p.fail(t.start, t.end, "%s", err.Error())
+				continue
+			}
+			nodes = append(nodes, &PropsNode{Props: decls, Pos: p.position(t.start)})
 			continue
 		}
 
 		// IF BLOCK
-		if strings.HasPrefix(tag, "if ") {
+		if t.name == "if" {
 			branches := []IfBranch{}
 			var elseBody ASTNode
-			remain := tpl[start+end+2:]
-			cond := strings.TrimSpace(tag[3:])
-			var ifBody string
-
+			cond := t.args()
+			if cond == "" {
+				p.fail(t.start, t.end, "if ifadesinde koşul eksik")
+				cond = "false"
+			}
+			if err := checkCondition(cond); err != nil {
+				p.fail(t.start, t.end, "%s", err.Error())
+			}
+			kind, branchStart := "if", t.start
 			for {
-				elifIdx := strings.Index(remain, "{{ elif ")
-				elseIdx := strings.Index(remain, "{{ else }}")
-				endifIdx := strings.Index(remain, "{{ endif }}")
-
-				minIdx := -1
-				if endifIdx != -1 {
-					minIdx = endifIdx
-				}
-				if elifIdx != -1 && (minIdx == -1 || elifIdx < minIdx) {
-					minIdx = elifIdx
-				}
-				if elseIdx != -1 && (minIdx == -1 || elseIdx < minIdx) {
-					minIdx = elseIdx
-				}
-				if minIdx == -1 {
-					return nil, fmt.Errorf("unclosed if/elif/else/endif block")
+				next, ok := findBlockTag(tpl, pos, "if", "endif", "elif", "else")
+				if !ok {
+					pos = p.unclosed(t, pos, "unclosed if/elif/else/endif block")
+					break
 				}
-				ifBody = remain[:minIdx]
-				bodyNode, err := NewParser(ifBody).Parse()
-				if err != nil {
-					return nil, err
+				if cond == "" {
+					// else gövdesi: baştaki ve sondaki boşluklar atılır
+					bodyNode := p.subTrimmed(pos, next.start).parse()
+					elseBody = p.cover(bodyNode, kind, branchStart, pos, next.start)
+				} else {
+					bodyNode := p.sub(pos, next.start).parse()
+					branches = append(branches, IfBranch{Condition: cond, Body: p.cover(bodyNode, kind, branchStart, pos, next.start)})
 				}
-				branches = append(branches, IfBranch{Condition: cond, Body: bodyNode})
-
-				if minIdx == elifIdx {
-					remain = remain[elifIdx+len("{{ elif "):]
-					endElif := strings.Index(remain, "}}")
-					if endElif == -1 {
-						return nil, fmt.Errorf("unclosed elif tag")
+				pos = next.end
+				if next.name == "endif" {
+					if elseBody == nil && p.cov != nil {
+						elseBody = p.coverImplicit("else", t.start, next.start)
 					}
-					cond = strings.TrimSpace(remain[:endElif])
-					remain = remain[endElif+2:]
-					continue
+					break
 				}
-
-				if minIdx == elseIdx {
-					remain = remain[elseIdx+len("{{ else }}"):]
-					endifIdx2 := strings.Index(remain, "{{ endif }}")
-					if endifIdx2 == -1 {
-						return nil, fmt.Errorf("unclosed endif after else")
+				if elseBody != nil {
+					p.fail(next.start, next.end, "unclosed endif after else")
+				}
+				kind, branchStart = next.name, next.start
+				if next.name == "elif" {
+					cond = next.args()
+					if cond == "" {
+						p.fail(next.start, next.end, "elif ifadesinde koşul eksik")
+						cond = "false"
 					}
-					elseBodyStr := remain[:endifIdx2]
-					elseBody, err = NewParser(strings.TrimSpace(elseBodyStr)).Parse()
-					if err != nil {
-						return nil, err
+					if err := checkCondition(cond); err != nil {
+						p.fail(next.start, next.end, "%s", err.Error())
 					}
-					remain = remain[endifIdx2+len("{{ endif }}"):]
-					tpl = remain
-					nodes = append(nodes, &IfNode{Branches: branches, ElseBody: elseBody})
-					break
-				}
-
-				if minIdx == endifIdx {
-					remain = remain[endifIdx+len("{{ endif }}"):]
-					tpl = remain
-					nodes = append(nodes, &IfNode{Branches: branches, ElseBody: nil})
-					break
+					continue
 				}
+				// else
+				cond = ""
 			}
+			nodes = append(nodes, &IfNode{Branches: branches, ElseBody: elseBody})
 			continue
 		}
 
-		// FOR BLOCK
-		if strings.HasPrefix(tag, "for ") {
-			inner := strings.TrimSpace(tag[4:])
+		// FOR BLOCK: {{ for item in items }}, {{ for item in items if item.active }}
+		if t.name == "for" {
+			inner := t.args()
+			recursive := false
+			if strings.HasSuffix(inner, " recursive") {
+				recursive = true
+				inner = strings.TrimSpace(strings.TrimSuffix(inner, " recursive"))
+			}
+			filter := ""
+			if idx := indexOutsideQuotes(inner, " if "); idx != -1 {
+				filter = strings.TrimSpace(inner[idx+len(" if "):])
+				inner = strings.TrimSpace(inner[:idx])
+				if err := checkCondition(filter); err != nil {
+					p.fail(t.start, t.end, "%s", err.Error())
+				}
+			}
 			parts := strings.Fields(inner)
 			var varName, colName string
 			if len(parts) == 2 {
@@ -233,25 +396,137 @@ func (p *Parser) Parse() (ASTNode, error) {
 				varName = parts[0]
 				colName = parts[2]
 			} else {
-				return nil, fmt.Errorf("invalid for syntax")
+				p.fail(t.start, t.end, "invalid for syntax")
+			}
+			endfor, ok := findBlockTag(tpl, pos, "for", "endfor")
+			if !ok {
+				pos = p.unclosed(t, pos, "unclosed for block")
+				continue
 			}
-			endforIdx := strings.Index(tpl[start+end+2:], "{{ endfor }}")
-			if endforIdx == -1 {
-				return nil, fmt.Errorf("unclosed for block")
+			body := p.sub(pos, endfor.start)
+			body.inLoop = true
+			forNode := &ForNode{VarName: varName, Collection: colName, Filter: filter, Recursive: recursive, Body: p.cover(body.parse(), "for", t.start, pos, endfor.start)}
+			nodes = append(nodes, p.coverLoop(forNode, t.start, endfor.start))
+			pos = endfor.end
+			continue
+		}
+
+		// BLOCK: {{ block name }}...{{ endblock }} (extends edilmeyen template'lerde varsayılan içerik)
+		if t.name == "block" {
+			name := t.args()
+			if name == "" {
+				p.fail(t.start, t.end, "block ifadesinde isim eksik")
 			}
-			bodyTpl := tpl[start+end+2 : start+end+2+endforIdx]
-			bodyNode, err := NewParser(bodyTpl).Parse()
+			endblock, ok := findBlockTag(tpl, pos, "block", "endblock")
+			if !ok {
+				pos = p.unclosed(t, pos, "unclosed endblock for block: "+name)
+				continue
+			}
+			bodyNode := p.sub(pos, endblock.start).parse()
+			nodes = append(nodes, &BlockNode{Name: name, Body: p.cover(bodyNode, "block", t.start, pos, endblock.start)})
+			pos = endblock.end
+			continue
+		}
+
+		// BREAK / CONTINUE
+		if tag == "break" || tag == "continue" {
+			if !p.inLoop {
+				p.fail(t.start, t.end, "%s outside of for loop", tag)
+				continue
+			}
+			if tag == "break" {
+				nodes = append(nodes, &BreakNode{})
+			} else {
+				nodes = append(nodes, &ContinueNode{})
+			}
+			continue
+		}
+
+		// STYLES / SCRIPTS: toplanan asset'lerin yerleştirileceği yerler
+		if tag == "styles" || tag == "scripts" {
+			nodes = append(nodes, &AssetsNode{Kind: tag})
+			continue
+		}
+
+		// MACRO: {{ macro name(a, b="x") }}...{{ endmacro }}
+		if t.name == "macro" {
+			endmacro, ok := findBlockTag(tpl, pos, "macro", "endmacro")
+			if !ok {
+				pos = p.unclosed(t, pos, "unclosed macro block")
+				continue
+			}
+			node, err := parseMacroSignature(t.args())
 			if err != nil {
-				return nil, err
+				p.fail(t.start, t.end, "%s", err.Error())
+				node = &MacroNode{}
 			}
-			nodes = append(nodes, &ForNode{VarName: varName, Collection: colName, Body: bodyNode})
-			tpl = tpl[start+end+2+endforIdx+len("{{ endfor }}"):]
+			body := p.sub(pos, endmacro.start)
+			body.inLoop = false
+			node.Body = p.cover(body.parse(), "macro", t.start, pos, endmacro.start)
+			node.Pos = p.position(t.start)
+			nodes = append(nodes, node)
+			pos = endmacro.end
+			continue
+		}
+
+		// SWITCH BLOCK: {{ switch x }}{{ case "a", "b" }}...{{ default }}...{{ endswitch }}
+		if t.name == "switch" {
+			subject := t.args()
+			if subject == "" {
+				p.fail(t.start, t.end, "switch ifadesinde değer eksik")
+			}
+			node := &SwitchNode{Subject: subject}
+			next, ok := findBlockTag(tpl, pos, "switch", "endswitch", "case", "default")
+			if !ok {
+				pos = p.unclosed(t, pos, "unclosed switch block")
+				continue
+			}
+			if text := tpl[pos:next.start]; strings.TrimSpace(text) != "" {
+				from := pos + len(text) - len(strings.TrimLeft(text, " \t\r\n"))
+				p.fail(from, pos+len(strings.TrimRight(text, " \t\r\n")), "switch ile ilk case arasında içerik olamaz")
+			}
+			closed := true
+			for next.name != "endswitch" {
+				clause := next
+				next, ok = findBlockTag(tpl, clause.end, "switch", "endswitch", "case", "default")
+				if !ok {
+					pos = p.unclosed(t, clause.end, "unclosed switch block")
+					closed = false
+					break
+				}
+				bodyNode := p.cover(p.sub(clause.end, next.start).parse(), clause.name, clause.start, clause.end, next.start)
+				if clause.name == "default" {
+					if node.Default != nil {
+						p.fail(clause.start, clause.end, "switch içinde birden fazla default")
+					}
+					node.Default = bodyNode
+					continue
+				}
+				var values []string
+				for _, v := range splitArgs(clause.args()) {
+					if v = strings.TrimSpace(v); v != "" {
+						values = append(values, v)
+					}
+				}
+				if len(values) == 0 {
+					p.fail(clause.start, clause.end, "case ifadesinde değer eksik")
+				}
+				node.Cases = append(node.Cases, SwitchCase{Values: values, Body: bodyNode})
+			}
+			if !closed {
+				continue
+			}
+			if node.Default == nil && p.cov != nil {
+				node.Default = p.coverImplicit("default", t.start, next.start)
+			}
+			nodes = append(nodes, node)
+			pos = next.end
 			continue
 		}
 
 		// WITH BLOCK
-		if strings.HasPrefix(tag, "with ") {
-			inner := strings.TrimSpace(tag[5:])
+		if t.name == "with" {
+			inner := t.args()
 			parts := strings.Fields(inner)
 			var expr, alias string
 			if len(parts) == 2 {
@@ -261,127 +536,323 @@ func (p *Parser) Parse() (ASTNode, error) {
 				expr = parts[0]
 				alias = parts[2]
 			} else {
-				return nil, fmt.Errorf("invalid with syntax")
-			}
-			endwithIdx := strings.Index(tpl[start+end+2:], "{{ endwith }}")
-			if endwithIdx == -1 {
-				return nil, fmt.Errorf("unclosed with block")
+				p.fail(t.start, t.end, "invalid with syntax")
 			}
-			bodyTpl := tpl[start+end+2 : start+end+2+endwithIdx]
-			bodyNode, err := NewParser(bodyTpl).Parse()
-			if err != nil {
-				return nil, err
+			endwith, ok := findBlockTag(tpl, pos, "with", "endwith")
+			if !ok {
+				pos = p.unclosed(t, pos, "unclosed with block")
+				continue
 			}
+			bodyNode := p.sub(pos, endwith.start).parse()
 			nodes = append(nodes, &WithNode{Expr: expr, Alias: alias, Body: bodyNode})
-			tpl = tpl[start+end+2+endwithIdx+len("{{ endwith }}"):]
+			pos = endwith.end
 			continue
 		}
 
-		// VARIABLE with filters
-		parts := strings.Split(tag, "|")
-		varName := strings.TrimSpace(parts[0])
-		filters := []FilterCall{}
-		for _, f := range parts[1:] {
-			f = strings.TrimSpace(f)
-			if f == "" {
+		// COMPONENT: {{ component "ui/card.hipo" title="X" }}{{ slot header }}...{{ endslot }}...{{ endcomponent }}
+		if t.name == "component" {
+			endcomp, ok := findBlockTag(tpl, pos, "component", "endcomponent")
+			if !ok {
+				pos = p.unclosed(t, pos, "unclosed component block")
 				continue
 			}
-			fName := f
-			fArgs := []string{}
-			if idx := strings.Index(f, ":"); idx != -1 {
-				fName = strings.TrimSpace(f[:idx])
-				argsStr := strings.TrimSpace(f[idx+1:])
-				for _, arg := range strings.Split(argsStr, ",") {
-					arg = strings.TrimSpace(arg)
-					if arg != "" {
-						fArgs = append(fArgs, arg)
-					}
+			node := p.parseComponent(t, endcomp)
+			node.Pos = p.position(t.start)
+			nodes = append(nodes, node)
+			pos = endcomp.end
+			continue
+		}
+
+		// SLOT (component dosyası içinde): {{ slot }}, {{ slot name }} veya {{ slot name }}varsayılan{{ endslot }}
+		if t.name == "slot" {
+			name := t.args()
+			if name == "" {
+				name = defaultSlot
+			}
+			slot := &SlotNode{Name: name}
+			if next, ok := findBlockTag(tpl, pos, "", "endslot", "slot"); ok && next.name == "endslot" {
+				slot.Fallback = p.sub(pos, next.start).parse()
+				pos = next.end
+			}
+			nodes = append(nodes, slot)
+			continue
+		}
+
+		// Engine.RegisterTag ile kaydedilen özel tag'lar
+		if spec, ok := p.tags[t.name]; ok {
+			var node ASTNode
+			node, pos = p.parseCustomTag(spec, t)
+			if node != nil {
+				nodes = append(nodes, node)
+			}
+			continue
+		}
+
+		// Bloğu dışında kalan ara/kapanış tag'ları değişken olarak okunmaz
+		switch t.name {
+		case "elif", "else", "case", "default":
+			p.stray(t)
+			continue
+		}
+		if isEndTag(t.name, p.tags) {
+			p.stray(t)
+			continue
+		}
+
+		// VARIABLE with filters
+		nodes = append(nodes, parseVariable(tag))
+	}
+
+	return p.coverFile(&ListNode{Nodes: nodes})
+}
+
+// parseVariable, {{ name|filter:arg }} tag içeriğinden VariableNode oluşturur.
+func parseVariable(tag string) *VariableNode {
+	parts := strings.Split(tag, "|")
+	varName := strings.TrimSpace(parts[0])
+	filters := []FilterCall{}
+	for _, f := range parts[1:] {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		fName := f
+		fArgs := []string{}
+		if idx := strings.Index(f, ":"); idx != -1 {
+			fName = strings.TrimSpace(f[:idx])
+			argsStr := strings.TrimSpace(f[idx+1:])
+			for _, arg := range strings.Split(argsStr, ",") {
+				arg = strings.TrimSpace(arg)
+				if arg != "" {
+					fArgs = append(fArgs, arg)
 				}
 			}
-			filters = append(filters, FilterCall{Name: fName, Args: fArgs})
-		}
-		// Fonksiyon çağrısı ise Name'e fonksiyon çağrısı stringini ata, Value nil olsun
-		varName = strings.TrimSpace(varName)
-		if strings.Contains(varName, "(") && strings.HasSuffix(varName, ")") {
-			nodes = append(nodes, &VariableNode{Name: varName, Value: nil, Filters: filters})
-		} else if len(filters) > 0 {
-			var value interface{} = nil
-			if len(varName) > 1 && ((varName[0] == '"' && varName[len(varName)-1] == '"') || (varName[0] == '\'' && varName[len(varName)-1] == '\'')) {
-				value = varName[1 : len(varName)-1]
-				varName = ""
-			} else if ival, err := strconv.Atoi(varName); err == nil {
-				value = ival
-				varName = ""
-			} else if fval, err := strconv.ParseFloat(varName, 64); err == nil {
-				value = fval
-				varName = ""
-			}
-			nodes = append(nodes, &VariableNode{Name: varName, Value: value, Filters: filters})
-		} else {
-			// Sadece değişken veya literal
-			var value interface{} = nil
-			if len(varName) > 1 && ((varName[0] == '"' && varName[len(varName)-1] == '"') || (varName[0] == '\'' && varName[len(varName)-1] == '\'')) {
-				value = varName[1 : len(varName)-1]
-				varName = ""
-			} else if ival, err := strconv.Atoi(varName); err == nil {
-				value = ival
-				varName = ""
-			} else if fval, err := strconv.ParseFloat(varName, 64); err == nil {
-				value = fval
-				varName = ""
-			}
-			if value != nil {
-				nodes = append(nodes, &VariableNode{Name: "", Value: value, Filters: nil})
-			} else {
-				nodes = append(nodes, &VariableNode{Name: varName, Value: nil, Filters: nil})
+		}
+		filters = append(filters, FilterCall{Name: fName, Args: fArgs})
+	}
+	// Fonksiyon çağrısı ise Name'e fonksiyon çağrısı stringini ata, Value nil olsun
+	if strings.Contains(varName, "(") && strings.HasSuffix(varName, ")") {
+		return &VariableNode{Name: varName, Value: nil, Filters: filters}
+	}
+	var value interface{} = nil
+	if len(varName) > 1 && ((varName[0] == '"' && varName[len(varName)-1] == '"') || (varName[0] == '\'' && varName[len(varName)-1] == '\'')) {
+		value = varName[1 : len(varName)-1]
+		varName = ""
+	} else if ival, err := strconv.Atoi(varName); err == nil {
+		value = ival
+		varName = ""
+	} else if fval, err := strconv.ParseFloat(varName, 64); err == nil {
+		value = fval
+		varName = ""
+	}
+	if len(filters) > 0 {
+		return &VariableNode{Name: varName, Value: value, Filters: filters}
+	}
+	// Sadece değişken veya literal
+	if value != nil {
+		return &VariableNode{Name: "", Value: value, Filters: nil}
+	}
+	return &VariableNode{Name: varName, Value: nil, Filters: nil}
+}
+
+// parseExpr, {{ set x = ... }} sağ tarafıyla aynı gramerde bir ifadeyi (literal, değişken,
+// filtre zinciri veya fonksiyon çağrısı) tek bir node'a çevirir.
+func (p *Parser) parseExpr(expr string) (ASTNode, error) {
+	// İfadeyi yeni bir parser ile parse et (tek bir node beklenir)
+	ep := p.derive(expr)
+	ep.scope = ""
+	valAst, err := ep.Parse()
+	if err != nil {
+		return nil, err
+	}
+	// Eğer tek bir node ise, doğrudan onu ata
+	if list, ok := valAst.(*ListNode); ok && len(list.Nodes) == 1 {
+		valAst = list.Nodes[0]
+	}
+	// Eğer node bir TextNode ise ve içeriği değişken/filtre zinciri içeriyorsa tekrar parse et
+	if txt, ok := valAst.(*TextNode); ok {
+		content := strings.TrimSpace(txt.Text)
+		if content != "" {
+			// Eğer content bir literal değilse veya filtre içeriyorsa tekrar parse et
+			if strings.Contains(content, "|") || (!((len(content) > 1 && ((content[0] == '"' && content[len(content)-1] == '"') || (content[0] == '\'' && content[len(content)-1] == '\''))) && !isNumeric(content))) {
+				vnParser := ep.derive("{{ " + content + " }}")
+				vnAst, err := vnParser.Parse()
+				if err == nil {
+					if list, ok := vnAst.(*ListNode); ok && len(list.Nodes) == 1 {
+						valAst = list.Nodes[0]
+					} else {
+						valAst = vnAst
+					}
+				}
 			}
 		}
-		tpl = tpl[start+end+2:]
 	}
+	return valAst, nil
+}
+
+// sub, template'in [start:end) aralığı için konum bilgisini koruyan bir alt parser oluşturur.
+func (p *Parser) sub(start, end int) *Parser {
+	source := p.source
+	if source == "" {
+		source = p.template
+	}
+	return &Parser{
+		template: p.template[start:end],
+		filename: p.filename,
+		source:   source,
+		base:     p.base + start,
+		nested:   true,
+		inLoop:   p.inLoop,
+		tags:     p.tags,
+		scope:    p.scope,
+		cov:      p.cov,
+		diags:    p.diags,
+	}
+}
 
-	return &ListNode{Nodes: nodes}, nil
+// derive, template'ten bağımsız bir kaynak (ör: set ifadesinin sağ tarafı) için
+// aynı dosya adı ve özel tag'larla yeni bir parser oluşturur.
+func (p *Parser) derive(src string) *Parser {
+	return &Parser{template: src, filename: p.filename, tags: p.tags, scope: p.scope}
 }
 
-// ParseWithBlocks, override edilen bloklarla birlikte template'i AST'ye dönüştürür.
+// text, düz metin için TextNode oluşturur; scoped template'lerde elementlere scope attribute'u eklenir.
+func (p *Parser) text(s string) *TextNode {
+	if p.scope != "" {
+		s = scopeHTML(s, p.scope)
+	}
+	return &TextNode{Text: s}
+}
+
+// subTrimmed, sub gibi çalışır ancak aralığın başındaki ve sonundaki boşlukları atar.
+func (p *Parser) subTrimmed(start, end int) *Parser {
+	for start < end && strings.ContainsRune(" \t\r\n", rune(p.template[start])) {
+		start++
+	}
+	for end > start && strings.ContainsRune(" \t\r\n", rune(p.template[end-1])) {
+		end--
+	}
+	return p.sub(start, end)
+}
+
+// lineCol, parser'ın template'i içindeki offset'i kaynak dosyadaki satır/sütuna çevirir.
+func (p *Parser) lineCol(offset int) (int, int) {
+	if p.source == "" {
+		return getLineCol(p.template, offset)
+	}
+	return getLineCol(p.source, p.base+offset)
+}
+
+// tagToken, template içindeki tek bir {{ ... }} tag'ını temsil eder.
+type tagToken struct {
+	start   int    // "{{" indexi
+	end     int    // "}}" sonrası index
+	content string // trimlenmiş tag içeriği
+	name    string // tag içeriğinin ilk kelimesi (if, endif, for...)
+}
+
+// args, tag adından sonra gelen kısmı döndürür.
+func (t tagToken) args() string {
+	return strings.TrimSpace(t.content[len(t.name):])
+}
+
+// nextTag, tpl içinde from indexinden itibaren ilk tag'ı bulur.
+func nextTag(tpl string, from int) (tagToken, bool) {
+	start := strings.Index(tpl[from:], "{{")
+	if start == -1 {
+		return tagToken{}, false
+	}
+	start += from
+	end := strings.Index(tpl[start:], "}}")
+	if end == -1 {
+		return tagToken{}, false
+	}
+	content := strings.TrimSpace(tpl[start+2 : start+end])
+	name := content
+	if i := strings.IndexAny(content, " \t\r\n"); i != -1 {
+		name = content[:i]
+	}
+	return tagToken{start: start, end: start + end + 2, content: content, name: name}, true
+}
+
+// findBlockTag, from indexinden itibaren iç içe open/end bloklarını atlayarak aynı seviyedeki
+// ilk kapanış (end) veya ara (ör: elif, else) tag'ını bulur.
+func findBlockTag(tpl string, from int, open, end string, middles ...string) (tagToken, bool) {
+	depth := 0
+	for {
+		t, ok := nextTag(tpl, from)
+		if !ok {
+			return tagToken{}, false
+		}
+		from = t.end
+		switch {
+		case open != "" && t.name == open:
+			depth++
+		case t.name == end:
+			if depth == 0 {
+				return t, true
+			}
+			depth--
+		case depth == 0:
+			for _, m := range middles {
+				if t.name == m {
+					return t, true
+				}
+			}
+		}
+	}
+}
+
+// ParseWithBlocks, override edilen bloklarla birlikte template'i AST'ye dönüştürür. Hatalar Parse'taki
+// gibi toplanır.
 func (p *Parser) ParseWithBlocks(override map[string]ASTNode) (ASTNode, error) {
+	return p.collect(func() ASTNode { return p.parseWithBlocks(override) })
+}
+
+func (p *Parser) parseWithBlocks(override map[string]ASTNode) ASTNode {
 	tpl := p.template
 	nodes := []ASTNode{}
-	for len(tpl) > 0 {
-		start := strings.Index(tpl, "{{ block ")
+	// Blokların dışındaki layout metni de (include, değişkenler, asset marker'ları) parse edilir
+	layout := func(start, end int) {
+		if start < end {
+			nodes = append(nodes, p.sub(start, end).parse())
+		}
+	}
+	pos := 0
+	for pos < len(tpl) {
+		start := strings.Index(tpl[pos:], "{{ block ")
 		if start == -1 {
-			if tpl != "" {
-				nodes = append(nodes, &TextNode{Text: tpl})
-			}
+			layout(pos, len(tpl))
 			break
 		}
-		if start > 0 {
-			nodes = append(nodes, &TextNode{Text: tpl[:start]})
-		}
+		start += pos
+		layout(pos, start)
 		endBlockName := strings.Index(tpl[start:], "}}")
 		if endBlockName == -1 {
-			return nil, fmt.Errorf("unclosed block tag")
+			p.fail(start, len(tpl), "unclosed block tag")
+			break
 		}
 		name := strings.TrimSpace(tpl[start+len("{{ block ") : start+endBlockName])
 		after := tpl[start+endBlockName+2:]
 		endBlock := "{{ endblock }}"
 		endIdx := strings.Index(after, endBlock)
 		if endIdx == -1 {
-			return nil, fmt.Errorf("unclosed endblock for block: %s", name)
+			p.fail(start, start+endBlockName+2, "unclosed endblock for block: %s", name)
+			break
 		}
 		if override != nil {
+			bodyStart := start + endBlockName + 2
 			if overrideAst, ok := override[name]; ok {
+				p.coverSkipped("block", start, bodyStart, bodyStart+endIdx)
 				nodes = append(nodes, &BlockNode{Name: name, Body: overrideAst})
 			} else {
-				ast, err := NewParser(after[:endIdx]).Parse()
-				if err != nil {
-					return nil, err
-				}
-				nodes = append(nodes, &BlockNode{Name: name, Body: ast})
+				ast := p.sub(bodyStart, bodyStart+endIdx).parse()
+				nodes = append(nodes, &BlockNode{Name: name, Body: p.cover(ast, "block", start, bodyStart, bodyStart+endIdx)})
 			}
 		}
-		tpl = after[endIdx+len(endBlock):]
+		pos = len(tpl) - len(after) + endIdx + len(endBlock)
 	}
-	return &ListNode{Nodes: nodes}, nil
+	return p.coverFile(&ListNode{Nodes: nodes})
 }
 
 // getLineCol, tpl içindeki offset'i satır/sütun olarak bulur.
@@ -0,0 +1,110 @@
+package hipoengine
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParseCollectsErrors(t *testing.T) {
+	src := "<p>{{ set x }}</p>\n{{ if }}a{{ elif }}b{{ endif }}\n{{ break }}{{ with a b c d }}{{ endwith }}\n{{ x"
+	_, err := NewParserWithFile(src, "page.hipo").Parse()
+	list, ok := err.(ErrorList)
+	if !ok {
+		t.Fatalf("ErrorList bekleniyordu, gerçek: %T %v", err, err)
+	}
+	var got []string
+	for _, te := range list {
+		if te.Severity != SeverityError || te.File != "page.hipo" {
+			t.Errorf("beklenmeyen önem derecesi veya dosya: %+v", te)
+		}
+		got = append(got, fmt.Sprintf("%d:%d-%d:%d %s", te.Line, te.Column, te.EndLine, te.EndColumn, te.Message))
+	}
+	want := []string{
+		"1:4-1:15 set ifadesinde '=' eksik",
+		"2:1-2:9 if ifadesinde koşul eksik",
+		"2:10-2:20 elif ifadesinde koşul eksik",
+		"3:1-3:12 break outside of for loop",
+		"3:12-3:30 invalid with syntax",
+		"4:1-4:5 unclosed variable or block",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("hatalar:\n%v\nbeklenen:\n%v", got, want)
+	}
+}
+
+func TestParseUnclosedBlockKeepsParsing(t *testing.T) {
+	// Kapatılmamış bloğun geri kalanı gövde olarak parse edilir; içindeki hatalar da toplanır
+	_, err := NewParser("{{ for x in xs }}\n{{ continue }}{{ macro m }}{{ endmacro }}").Parse()
+	errs := TemplateErrors(fmt.Errorf("render: %w", err))
+	var got []string
+	for _, te := range errs {
+		got = append(got, fmt.Sprintf("%d:%d %s", te.Line, te.Column, te.Message))
+	}
+	want := []string{"1:1 unclosed for block", "2:15 geçersiz macro imzası: m"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("hatalar %v, beklenen %v", got, want)
+	}
+
+	// Tek hata TemplateError olarak döner
+	_, err = NewParser("a\n  {{ switch x }}{{ case 1 }}b").Parse()
+	te, ok := err.(*TemplateError)
+	if !ok || te.Line != 2 || te.Column != 3 || te.EndColumn != 17 || te.Message != "unclosed switch block" {
+		t.Errorf("kapatılmamış switch hatası bekleniyordu, gerçek: %#v", err)
+	}
+	if TemplateErrors(fmt.Errorf("düz hata")) != nil {
+		t.Error("template hatası olmayan hata için nil bekleniyordu")
+	}
+}
+
+func TestBlockTagMatching(t *testing.T) {
+	// before: kapanış tag'larının ilk "{{ endif }}" metin eşleşmesiyle arandığı eski davranış.
+	// Kapanış tag'ı artık iç içe blokları atlayarak ve tag içindeki boşluktan bağımsız bulunur.
+	data := map[string]interface{}{"a": true, "b": false, "xs": []interface{}{1, 2}, "u": map[string]interface{}{"n": "N"}}
+	cases := []struct{ tpl, before, want string }{
+		{`{{ if a }}{{ if b }}x{{ endif }}y{{ endif }}`, "hata: unclosed if/elif/else/endif block", "y"},
+		{`{{ if a }}x{{ else }}{{ if b }}y{{ endif }}z{{ endif }}`, "hata: unclosed if/elif/else/endif block", "x"},
+		{`{{ if a }}x{{endif}}`, "hata: unclosed if/elif/else/endif block", "x"},
+		{`{{ if b }}x{{else}}y{{ endif }}`, "", "y"},
+		{`{{ for x in xs }}{{ for y in xs }}{{ y }}{{ endfor }}{{ endfor }}`, "hata: unclosed for block", "1\n2\n1\n2"},
+		{`{{ for x in xs }}{{ x }}{{endfor}}`, "hata: unclosed for block", "1\n2"},
+		{`{{ with u as v }}{{ with u as w }}{{ w.n }}{{ endwith }}{{ v.n }}{{ endwith }}`, "hata: unclosed with block", "NN"},
+		{`{{ with u as v }}{{ v.n }}{{endwith}}`, "hata: unclosed with block", "N"},
+		{`{{ set s = "a=b" }}{{ s }}`, "a=b", "a=b"},
+		{`{{ if a }}"}}"{{ endif }}`, `"}}"`, `"}}"`},
+	}
+	e := NewEngine()
+	for _, c := range cases {
+		out, err := e.Render(c.tpl, data)
+		if err != nil {
+			t.Errorf("%s: Render error: %v (eski: %q)", c.tpl, err, c.before)
+			continue
+		}
+		if out != c.want {
+			t.Errorf("%s: beklenen %q (eski: %q), gerçek %q", c.tpl, c.want, c.before, out)
+		}
+	}
+}
+
+func TestParseReportsStrayTags(t *testing.T) {
+	cases := map[string][]string{
+		// Yanlış kapanış tag'ı kendi konumunda bildirilir
+		"{{ if x }}a{{ endfor }}b":                 {"1:1 unclosed if/elif/else/endif block", "1:12 {{ endif }} beklenirken {{ endfor }} bulundu"},
+		"{{ for x in xs }}{{ endif }}{{ endfor }}": {"1:18 {{ endif }} için açılış tag'ı yok"},
+		"a\n{{ endwith }}{{ else }}{{ case 1 }}":   {"2:1 {{ endwith }} için açılış tag'ı yok", "2:14 {{ else }} bir if bloğu içinde değil", "2:24 {{ case }} bir switch bloğu içinde değil"},
+		// Açılış tag'ı parse edilemediyse "unclosed" hatası eklenmez
+		"{{ for }}a":            {"1:1 invalid for syntax"},
+		"{{ with a b c d }}a":   {"1:1 invalid with syntax"},
+		"{{ if }}a{{ endfor }}": {"1:1 if ifadesinde koşul eksik", "1:10 {{ endif }} beklenirken {{ endfor }} bulundu"},
+	}
+	for src, want := range cases {
+		_, err := NewParser(src).Parse()
+		var got []string
+		for _, te := range TemplateErrors(err) {
+			got = append(got, fmt.Sprintf("%d:%d %s", te.Line, te.Column, te.Message))
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%q: hatalar %v, beklenen %v", src, got, want)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// props.go
+// {{ props }} bildirimi ve include/component çağrılarında prop doğrulama
+package hipoengine
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// propTypes, props bildiriminde kullanılabilecek tip adlarıdır.
+var propTypes = map[string]bool{
+	"any": true, "string": true, "int": true, "float": true, "number": true,
+	"bool": true, "list": true, "map": true,
+}
+
+// PropDecl, {{ props }} içindeki tek bir prop bildirimidir: name: type = default
+type PropDecl struct {
+	Name       string
+	Type       string
+	Default    string // ham default ifadesi
+	HasDefault bool
+}
+
+// PropsNode, template'in beklediği prop'ları bildirir; render sırasında eksik prop'lara
+// default değer atar, zorunlu prop eksikse veya tip uyuşmuyorsa TemplateError döner. Include
+// edilen dosyada bildirilirse dosya çağıranın data'sını paylaşmayı bırakır.
+type PropsNode struct {
+	Props []PropDecl
+	Pos   Position
+}
+
+// parseProps, {{ props title: string, items: list = [] }} tag içeriğini ayrıştırır.
+func parseProps(args string) ([]PropDecl, error) {
+	var decls []PropDecl
+	for _, part := range splitTopLevel(args, ',') {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		decl := PropDecl{Type: "any"}
+		if eq := indexOutsideQuotes(part, "="); eq != -1 {
+			decl.Default = strings.TrimSpace(part[eq+1:])
+			decl.HasDefault = true
+			part = strings.TrimSpace(part[:eq])
+			if _, ok := parsePropDefault(decl.Default); !ok {
+				return nil, fmt.Errorf("props: '%s' için geçersiz default değer: %s", part, decl.Default)
+			}
+		}
+		if colon := strings.Index(part, ":"); colon != -1 {
+			decl.Type = strings.TrimSpace(part[colon+1:])
+			part = strings.TrimSpace(part[:colon])
+		}
+		decl.Name = part
+		if decl.Name == "" || strings.ContainsAny(decl.Name, " .[(") {
+			return nil, fmt.Errorf("props: geçersiz prop adı: '%s'", decl.Name)
+		}
+		if !propTypes[decl.Type] {
+			return nil, fmt.Errorf("props: '%s' için bilinmeyen tip: %s", decl.Name, decl.Type)
+		}
+		decls = append(decls, decl)
+	}
+	return decls, nil
+}
+
+// parsePropDefault, default ifadesini değere çevirir: literal'ler, [] / [..] listeleri ve {} map'i.
+func parsePropDefault(expr string) (interface{}, bool) {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "[") && strings.HasSuffix(expr, "]") {
+		list := []interface{}{}
+		for _, item := range splitTopLevel(expr[1:len(expr)-1], ',') {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+			v, ok := parsePropDefault(item)
+			if !ok {
+				return nil, false
+			}
+			list = append(list, v)
+		}
+		return list, true
+	}
+	if expr == "{}" {
+		return map[string]interface{}{}, true
+	}
+	return parseLiteral(expr)
+}
+
+// propTypeMatches, değerin bildirilen tiple uyumlu olup olmadığını kontrol eder.
+func propTypeMatches(typ string, val interface{}) bool {
+	if typ == "any" {
+		return true
+	}
+	if val == nil {
+		return false
+	}
+	kind := reflect.ValueOf(val).Kind()
+	switch typ {
+	case "string":
+		return kind == reflect.String
+	case "int":
+		_, ok := toInt(val)
+		return ok && isNumber(val)
+	case "float", "number":
+		return isNumber(val)
+	case "bool":
+		return kind == reflect.Bool
+	case "list":
+		return kind == reflect.Slice || kind == reflect.Array
+	case "map":
+		return kind == reflect.Map || kind == reflect.Struct || (kind == reflect.Ptr && reflect.ValueOf(val).Elem().Kind() == reflect.Struct)
+	}
+	return false
+}
+
+// Execute, prop'ları doğrular ve eksik olanlara default değer atar. Çıktı üretmez.
+func (n *PropsNode) Execute(ctx *Context) (string, error) {
+	if ctx.shared {
+		// Props bildiren partial component gibi izole çalışır: default'lar ve set atamaları
+		// çağıranın değişkenlerini ezmez (çağıranın değişkenleri parent üzerinden okunur)
+		ctx.data = make(map[string]interface{})
+		ctx.shared = false
+	}
+	for _, decl := range n.Props {
+		val, found := ctx.lookup(decl.Name)
+		if !found || val == nil {
+			if !decl.HasDefault {
+				return "", n.errorf(ctx, "zorunlu prop '%s' (%s) verilmedi", decl.Name, decl.Type)
+			}
+			// Her render için yeni default değer (liste/map'ler paylaşılmasın)
+			val, _ = parsePropDefault(decl.Default)
+			ctx.Set(decl.Name, val)
+			continue
+		}
+		if !propTypeMatches(decl.Type, val) {
+			return "", n.errorf(ctx, "prop '%s' %s tipinde olmalı, verilen: %T", decl.Name, decl.Type, val)
+		}
+	}
+	return "", nil
+}
+
+func (n *PropsNode) ExecuteRaw(ctx *Context) (interface{}, error) {
+	return n.Execute(ctx)
+}
+
+// errorf, hatayı çağıranın (include/component tag'ının) dosya ve satırıyla raporlar.
+// Çağıran yoksa props bildiriminin konumu kullanılır.
+func (n *PropsNode) errorf(ctx *Context, format string, args ...interface{}) error {
+	msg := fmt.Sprintf(format, args...)
+	if n.Pos.File != "" {
+		msg = n.Pos.File + ": " + msg
+	}
+	pos := n.Pos
+	if ctx.caller != nil {
+		pos = *ctx.caller
+	}
+	return &TemplateError{File: pos.File, Line: pos.Line, Column: pos.Column, Message: msg}
+}
+
+// splitTopLevel, string'i köşeli/süslü parantez ve tırnak dışındaki ayraçlardan böler.
+func splitTopLevel(s string, sep byte) []string {
+	var parts []string
+	depth := 0
+	quote := byte(0)
+	last := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if quote != 0 {
+			if c == quote && s[i-1] != '\\' {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '"', '\'':
+			quote = c
+		case '[', '{', '(':
+			depth++
+		case ']', '}', ')':
+			depth--
+		case sep:
+			if depth == 0 {
+				parts = append(parts, s[last:i])
+				last = i + 1
+			}
+		}
+	}
+	return append(parts, s[last:])
+}
@@ -0,0 +1,70 @@
+package hipoengine
+
+import (
+	"strings"
+	"testing"
+)
+
+func treeContext() map[string]interface{} {
+	return map[string]interface{}{
+		"tree": []interface{}{
+			map[string]interface{}{"name": "A", "children": []interface{}{
+				map[string]interface{}{"name": "A1"},
+				map[string]interface{}{"name": "A2"},
+			}},
+			map[string]interface{}{"name": "B"},
+		},
+	}
+}
+
+func TestRecursiveFor(t *testing.T) {
+	e := NewEngine()
+	tpl := `{{ for node in tree recursive }}<li>{{ node.name }}@{{ loop.depth }}{{ if node.children }}<ul>{{ loop(node.children) }}</ul>{{ endif }}</li>{{ endfor }}`
+	out, err := e.Render(tpl, treeContext())
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	want := "<li>A@1<ul><li>A1@2</li>\n<li>A2@2</li></ul></li>\n<li>B@1</li>"
+	if out != want {
+		t.Errorf("Beklenen: %q, Gerçek: %q", want, out)
+	}
+}
+
+func TestRecursiveMacro(t *testing.T) {
+	e := NewEngine()
+	tpl := `{{ macro item(node, prefix="-") }}{{ prefix }}{{ node.name }};{{ if node.children }}{{ for c in node.children }}{{ item(c, "--") }}{{ endfor }}{{ endif }}{{ endmacro }}{{ for n in tree }}{{ item(n) }}{{ endfor }}`
+	out, err := e.Render(tpl, treeContext())
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	want := "-A;--A1;\n--A2;\n-B;"
+	if out != want {
+		t.Errorf("Beklenen: %q, Gerçek: %q", want, out)
+	}
+}
+
+func TestMacroOutputIsNotEscaped(t *testing.T) {
+	e := NewEngine()
+	out, err := e.Render(`{{ macro b(text) }}<b>{{ text }}</b>{{ endmacro }}{{ b(html) }}`, map[string]interface{}{"html": "<i>"})
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if out != "<b>&lt;i&gt;</b>" {
+		t.Errorf("Beklenen: '<b>&lt;i&gt;</b>', Gerçek: '%s'", out)
+	}
+}
+
+func TestMaxRecursionDepth(t *testing.T) {
+	e := NewEngine()
+	e.SetMaxRecursionDepth(5)
+	_, err := e.Render(`{{ macro f(n) }}{{ f(n) }}{{ endmacro }}{{ f(1) }}`, map[string]interface{}{})
+	if err == nil || !strings.Contains(err.Error(), "recursion") {
+		t.Errorf("Sonsuz macro recursion'ı için hata bekleniyordu, gerçek: %v", err)
+	}
+
+	e.AddTemplatePath("testdata/components")
+	_, err = e.Render(`{{ include "self_include.hipo" }}`, map[string]interface{}{})
+	if err == nil || !strings.Contains(err.Error(), "recursion") {
+		t.Errorf("Kendini include eden partial için hata bekleniyordu, gerçek: %v", err)
+	}
+}
@@ -0,0 +1,157 @@
+// runtime.go
+// Node'ların, bytecode VM'in ve hipo compile ile üretilen Go kodunun ortak çalışma zamanı yardımcıları
+package hipoengine
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// FormatValue, bir değeri {{ }} çıktısına çevirir: SafeString olduğu gibi yazılır, nil ve map
+// değerleri boş çıktı üretir, diğer değerler safe değilse HTML escape edilir.
+func FormatValue(val interface{}, safe bool) string {
+	if s, ok := val.(SafeString); ok {
+		return string(s)
+	}
+	if val == nil {
+		return ""
+	}
+	if _, ok := val.(map[string]interface{}); ok {
+		return ""
+	}
+	str := fmt.Sprintf("%v", val)
+	if !safe {
+		str = htmlEscape(str)
+	}
+	return str
+}
+
+// ApplyFilter, kayıtlı filtreyi değere uygular. Filtre bulunamazsa uyarı yazılır ve değerin
+// yanına "[filter ad not found]" notu eklenir.
+func (ctx *Context) ApplyFilter(name string, val interface{}, args ...interface{}) interface{} {
+	fn, ok := ctx.filters[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "[hipoengine] Uyarı: '%s' isimli filtre bulunamadı.\n", name)
+		return fmt.Sprintf("%v [filter %s not found]", val, name)
+	}
+	if ctx.engine != nil && ctx.engine.Profiler != nil {
+		start := time.Now()
+		val = fn(val, args...)
+		ctx.engine.Profiler.Add(name, "filter", time.Since(start))
+		return val
+	}
+	return fn(val, args...)
+}
+
+// Call, template içinde tanımlanan (macro, recursive loop) veya engine'e kayıtlı fonksiyonu
+// çağırır. Bu isimde bir fonksiyon yoksa ikinci değer false döner.
+func (ctx *Context) Call(name string, args ...interface{}) (interface{}, bool, error) {
+	if call, ok := ctx.lookupCallable(name); ok {
+		val, err := call(ctx, args...)
+		return val, true, err
+	}
+	fn, ok := ctx.funcs[name]
+	if !ok {
+		return nil, false, nil
+	}
+	if ctx.engine != nil && ctx.engine.Profiler != nil {
+		start := time.Now()
+		val := fn(args...)
+		ctx.engine.Profiler.Add(name, "function", time.Since(start))
+		return val, true, nil
+	}
+	return fn(args...), true, nil
+}
+
+// parseCall, "ad(a, "b")" biçimindeki çağrıyı fonksiyon adı ve derlenmiş argümanlara ayırır.
+func parseCall(expr string) (string, []Operand, bool) {
+	if !strings.Contains(expr, "(") || !strings.HasSuffix(expr, ")") {
+		return "", nil, false
+	}
+	open := strings.Index(expr, "(")
+	var args []Operand
+	if inner := strings.TrimSpace(expr[open+1 : len(expr)-1]); inner != "" {
+		for _, part := range splitArgs(inner) {
+			args = append(args, compileOperand(part))
+		}
+	}
+	return strings.TrimSpace(expr[:open]), args, true
+}
+
+// LoopItems, for döngüsünün koleksiyonunu önceden bölünmüş path ile çözer. Koleksiyon
+// []interface{} değilse hata döner; map elemanları map[string]interface{}'ye çevrilir.
+func (ctx *Context) LoopItems(collection string, parts ...string) ([]interface{}, error) {
+	col := ctx.resolveParts(parts)
+	arr, ok := col.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("ForNode: '%s' koleksiyonu []interface{} tipinde değil, değer: %v", collection, col)
+	}
+	items := make([]interface{}, len(arr))
+	for i, item := range arr {
+		if m := toStringMap(item); m != nil {
+			item = m
+		}
+		items[i] = item
+	}
+	return items, nil
+}
+
+// LoopInfo, recursive olmayan bir döngünün i. elemanı için {{ loop }} bilgisini oluşturur.
+func LoopInfo(i, length int) map[string]interface{} {
+	info := loopInfo(i, length)
+	info["depth"] = 1
+	info["depth0"] = 0
+	return info
+}
+
+// Include, dosyayı {{ include }} ile aynı şekilde render eder: dosya çağıranın değişkenlerini
+// görür ve set ile atadıkları çağırana döner. {{ props }} bildiren dosyalar ise component gibi
+// kendi scope'unda çalışır. pos, prop hataları için çağrı konumudur.
+func (ctx *Context) Include(file string, pos Position) (string, error) {
+	if ctx.engine == nil {
+		return "", fmt.Errorf("engine not set in context for include")
+	}
+	child, err := ctx.descend(ctx)
+	if err != nil {
+		return "", err
+	}
+	// Include edilen dosya çağıranın data'sını paylaşır; içinde set ile atananlar çağırana döner.
+	// Props bildiren dosyalar ise PropsNode ile kendi scope'larına geçer.
+	if ctx.data == nil {
+		ctx.data = make(map[string]interface{})
+	}
+	child.data = ctx.data
+	child.shared = true
+	child.caller = &pos
+	return ctx.engine.RenderFileContext(file, child)
+}
+
+// DefineMacro, bir macro'yu mevcut scope'a ekler. Gövde macro'nun tanımlandığı scope'ta
+// (çağıranın değişkenlerini görmeden) çalışır; verilmeyen parametreler defaults'tan, orada da
+// yoksa nil olarak atanır. Macro çıktısı SafeString olarak döner.
+func (ctx *Context) DefineMacro(name string, params []string, defaults map[string]Operand, body func(ctx *Context) (string, error)) {
+	ctx.setCallable(name, func(caller *Context, args ...interface{}) (interface{}, error) {
+		child, err := ctx.descend(caller)
+		if err != nil {
+			return nil, err
+		}
+		data := make(map[string]interface{}, len(params))
+		for i, p := range params {
+			if i < len(args) {
+				data[p] = args[i]
+			} else if d, ok := defaults[p]; ok {
+				data[p] = d.value(ctx)
+			} else {
+				data[p] = nil
+			}
+		}
+		child.data = data
+		out, err := body(child)
+		if err != nil {
+			return nil, err
+		}
+		return SafeString(out), nil
+	})
+}
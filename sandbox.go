@@ -5,6 +5,7 @@ package hipoengine
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 )
 
@@ -15,6 +16,45 @@ type RenderOptions struct {
 	MaxSteps int           // Maksimum node/fonksiyon/filtre adımı
 }
 
+// DefaultMaxRecursionDepth: recursive döngü, macro, include ve component zincirleri için varsayılan derinlik limiti
+const DefaultMaxRecursionDepth = 64
+
+// SetMaxRecursionDepth, recursive render derinliğini sınırlar (0 veya negatif: varsayılan limit).
+func (e *Engine) SetMaxRecursionDepth(depth int) {
+	e.MaxRecursionDepth = depth
+}
+
+// checkDepth, context'in recursion derinliği limiti aşıyorsa hata döner.
+func (ctx *Context) checkDepth() error {
+	limit := DefaultMaxRecursionDepth
+	if ctx.engine != nil && ctx.engine.MaxRecursionDepth > 0 {
+		limit = ctx.engine.MaxRecursionDepth
+	}
+	if ctx.depth > limit {
+		return &depthError{limit: limit}
+	}
+	return nil
+}
+
+// depthError, recursion derinliği limitinin aşıldığını bildirir.
+type depthError struct {
+	limit int
+}
+
+func (e *depthError) Error() string {
+	return fmt.Sprintf("Maksimum recursion derinliği aşıldı (%d): sonsuz recursive include/macro/loop koruması", e.limit)
+}
+
+// descend, ctx'in child'ını çağıranın bir seviye altında oluşturur ve derinlik limitini kontrol eder.
+func (ctx *Context) descend(caller *Context) (*Context, error) {
+	child := ctx.NewChild(nil)
+	child.depth = caller.depth + 1
+	if err := child.checkDepth(); err != nil {
+		return nil, err
+	}
+	return child, nil
+}
+
 // Render adım sayacı (Context'e entegre edilebilir)
 type RenderStepCounter struct {
 	Steps int
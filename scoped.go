@@ -0,0 +1,257 @@
+// scoped.go
+// <style scoped> desteği: CSS seçicilerini ve template elementlerini dosyaya özel attribute ile işaretler
+package hipoengine
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
+// ScopeID, dosya adından scoped CSS için kullanılan attribute adını üretir (ör: data-h-1a2b3c4d).
+func ScopeID(filename string) string {
+	h := fnv.New32a()
+	h.Write([]byte(filename))
+	return fmt.Sprintf("data-h-%08x", h.Sum32())
+}
+
+// scopeFor, dosyada <style scoped> bloğu varsa scope attribute'unu, yoksa boş string döndürür.
+func scopeFor(styles []*SFCBlock, filename string) string {
+	for _, s := range styles {
+		if s.HasAttr("scoped") {
+			return ScopeID(filename)
+		}
+	}
+	return ""
+}
+
+// scopedStyles, scoped style bloklarının seçicilerini attr ile yeniden yazar ve scoped attribute'unu
+// çıkarır. Diğer bloklar olduğu gibi döner.
+func scopedStyles(blocks []*SFCBlock, attr string) []*SFCBlock {
+	if attr == "" {
+		return blocks
+	}
+	out := make([]*SFCBlock, 0, len(blocks))
+	for _, b := range blocks {
+		if !b.HasAttr("scoped") {
+			out = append(out, b)
+			continue
+		}
+		cp := *b
+		cp.Attrs = nil
+		for _, a := range b.Attrs {
+			if !strings.EqualFold(a.Name, "scoped") {
+				cp.Attrs = append(cp.Attrs, a)
+			}
+		}
+		cp.Content = ScopeCSS(b.Content, attr)
+		out = append(out, &cp)
+	}
+	return out
+}
+
+// ScopeCSS, CSS kurallarındaki her seçicinin son parçasına [attr] ekler. @media, @supports,
+// @container ve @layer içindeki kurallar da işlenir; @keyframes, @font-face gibi at-rule'lar
+// olduğu gibi bırakılır. :deep(...) içindeki seçiciler scope dışında tutulur.
+func ScopeCSS(css, attr string) string {
+	var sb strings.Builder
+	i := 0
+	for i < len(css) {
+		if strings.HasPrefix(css[i:], "/*") {
+			end := strings.Index(css[i+2:], "*/")
+			if end == -1 {
+				sb.WriteString(css[i:])
+				break
+			}
+			sb.WriteString(css[i : i+2+end+2])
+			i += 2 + end + 2
+			continue
+		}
+		open := indexCSS(css, i, '{')
+		semi := indexCSS(css, i, ';')
+		if open == -1 || (semi != -1 && semi < open) {
+			// @import gibi gövdesiz ifadeler veya kalan metin
+			end := len(css)
+			if semi != -1 {
+				end = semi + 1
+			}
+			sb.WriteString(css[i:end])
+			i = end
+			continue
+		}
+		closeIdx := matchingBrace(css, open)
+		if closeIdx == -1 {
+			sb.WriteString(css[i:])
+			break
+		}
+		prelude := css[i:open]
+		body := css[open+1 : closeIdx]
+		trimmed := strings.TrimSpace(prelude)
+		switch {
+		case strings.HasPrefix(trimmed, "@"):
+			name := strings.ToLower(strings.Fields(trimmed)[0])
+			switch name {
+			case "@media", "@supports", "@container", "@layer":
+				body = ScopeCSS(body, attr)
+			}
+			sb.WriteString(prelude + "{" + body + "}")
+		default:
+			lead := prelude[:len(prelude)-len(strings.TrimLeft(prelude, " \t\r\n"))]
+			trail := prelude[len(strings.TrimRight(prelude, " \t\r\n")):]
+			sb.WriteString(lead + scopeSelectors(trimmed, attr) + trail + "{" + body + "}")
+		}
+		i = closeIdx + 1
+	}
+	return sb.String()
+}
+
+// scopeSelectors, virgülle ayrılmış seçici listesindeki her seçiciyi scope'lar.
+func scopeSelectors(selectors, attr string) string {
+	parts := splitTopLevel(selectors, ',')
+	for i, sel := range parts {
+		parts[i] = scopeSelector(strings.TrimSpace(sel), attr)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// scopeSelector, tek bir seçicinin son bileşik parçasına [attr] ekler (pseudo-class ve
+// pseudo-element'lerden önce). ".a :deep(.b)" => ".a[attr] .b"
+func scopeSelector(sel, attr string) string {
+	if idx := strings.Index(sel, ":deep("); idx != -1 {
+		end := strings.LastIndex(sel, ")")
+		if end > idx {
+			before := strings.TrimSpace(sel[:idx])
+			inner := strings.TrimSpace(sel[idx+len(":deep(") : end])
+			if before == "" {
+				return "[" + attr + "] " + inner
+			}
+			return scopeSelector(before, attr) + " " + inner
+		}
+	}
+	// Son bileşik seçicinin başlangıcını bul (boşluk, >, +, ~ kombinatörlerinden sonra)
+	depth := 0
+	start := 0
+	for i := 0; i < len(sel); i++ {
+		switch c := sel[i]; c {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+		case ' ', '>', '+', '~':
+			if depth == 0 {
+				start = i + 1
+			}
+		}
+	}
+	insert := len(sel)
+	depth = 0
+	for i := start; i < len(sel); i++ {
+		c := sel[i]
+		if c == '(' || c == '[' {
+			depth++
+		} else if c == ')' || c == ']' {
+			depth--
+		} else if c == ':' && depth == 0 {
+			insert = i
+			break
+		}
+	}
+	return sel[:insert] + "[" + attr + "]" + sel[insert:]
+}
+
+// indexCSS, from'dan itibaren tırnak dışındaki ilk c karakterinin indexini döndürür.
+func indexCSS(css string, from int, c byte) int {
+	quote := byte(0)
+	for i := from; i < len(css); i++ {
+		ch := css[i]
+		if quote != 0 {
+			if ch == quote && css[i-1] != '\\' {
+				quote = 0
+			}
+			continue
+		}
+		if ch == '"' || ch == '\'' {
+			quote = ch
+			continue
+		}
+		if ch == c {
+			return i
+		}
+	}
+	return -1
+}
+
+// matchingBrace, open'daki '{' ile eşleşen '}' indexini döndürür.
+func matchingBrace(css string, open int) int {
+	depth := 0
+	quote := byte(0)
+	for i := open; i < len(css); i++ {
+		ch := css[i]
+		if quote != 0 {
+			if ch == quote && css[i-1] != '\\' {
+				quote = 0
+			}
+			continue
+		}
+		switch ch {
+		case '"', '\'':
+			quote = ch
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// scopeHTML, HTML metnindeki her açılış tag'ına tag adından hemen sonra attr ekler.
+// Kapanış tag'ları, yorumlar ve <script>/<style> içerikleri değiştirilmez.
+func scopeHTML(html, attr string) string {
+	var sb strings.Builder
+	i := 0
+	for i < len(html) {
+		lt := strings.IndexByte(html[i:], '<')
+		if lt == -1 {
+			sb.WriteString(html[i:])
+			break
+		}
+		lt += i
+		sb.WriteString(html[i:lt])
+		if strings.HasPrefix(html[lt:], "<!--") {
+			end := strings.Index(html[lt+4:], "-->")
+			if end == -1 {
+				sb.WriteString(html[lt:])
+				break
+			}
+			sb.WriteString(html[lt : lt+4+end+3])
+			i = lt + 4 + end + 3
+			continue
+		}
+		j := lt + 1
+		for j < len(html) && isTagNameChar(html[j], j == lt+1) {
+			j++
+		}
+		if j == lt+1 {
+			sb.WriteByte('<')
+			i = lt + 1
+			continue
+		}
+		name := strings.ToLower(html[lt+1 : j])
+		sb.WriteString(html[lt:j])
+		if name != "template" && name != "slot" {
+			sb.WriteString(" " + attr)
+		}
+		i = j
+		if name == "script" || name == "style" {
+			if _, closeEnd := findRawClose(html, j, name); closeEnd != -1 {
+				sb.WriteString(html[j:closeEnd])
+				i = closeEnd
+			}
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,46 @@
+package hipoengine
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScopeCSS(t *testing.T) {
+	css := `.a p:hover, .b > .c::before { color: red; }
+@media (max-width: 600px) { .a { padding: 0; } }
+@keyframes fade { from { opacity: 0; } to { opacity: 1; } }
+.a :deep(.x) { color: blue; }`
+	got := ScopeCSS(css, "data-h-1")
+	for _, want := range []string{
+		".a p[data-h-1]:hover, .b > .c[data-h-1]::before {",
+		"@media (max-width: 600px) { .a[data-h-1] {",
+		"@keyframes fade { from { opacity: 0; } to { opacity: 1; } }",
+		".a[data-h-1] .x {",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("'%s' bekleniyordu:\n%s", want, got)
+		}
+	}
+}
+
+func TestScopedStyleRender(t *testing.T) {
+	e := NewEngine()
+	e.AddTemplatePath("testdata/scoped")
+	out, err := e.RenderFile("card.hipo", map[string]interface{}{"text": "Merhaba"})
+	if err != nil {
+		t.Fatalf("RenderFile error: %v", err)
+	}
+	id := ScopeID("card.hipo")
+	for _, want := range []string{
+		`<div ` + id + ` class="card"><p ` + id + `>Merhaba</p></div>`,
+		`.card p[` + id + `]:hover`,
+		"<style>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Çıktıda '%s' bekleniyordu:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "scoped") {
+		t.Errorf("scoped attribute'u çıktıdan çıkarılmalı:\n%s", out)
+	}
+}
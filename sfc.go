@@ -0,0 +1,410 @@
+// sfc.go
+// .hipo single-file component (SFC) dosyalarını bloklarına ayıran parser
+package hipoengine
+
+import (
+	"fmt"
+	"strings"
+
+	"hipoengine/internal/data"
+)
+
+// SFCAttr, blok açılış tag'ındaki tek bir attribute'tur.
+type SFCAttr struct {
+	Name     string
+	Value    string
+	HasValue bool // <style scoped> gibi değersiz attribute'lar için false
+}
+
+// SFCBlock, .hipo dosyasındaki üst seviye bir bloktur (<template>, <script>, <style> veya <i18n>, <docs> gibi özel bloklar).
+type SFCBlock struct {
+	Type         string    // tag adı (küçük harf)
+	Attrs        []SFCAttr // kaynaktaki sırasıyla attribute'lar
+	Content      string    // açılış ve kapanış tag'ları arasındaki ham içerik
+	Start        int       // açılış tag'ının ("<") offset'i
+	End          int       // kapanış tag'ından sonraki offset
+	ContentStart int       // içeriğin başladığı offset
+	Line         int       // açılış tag'ının satırı
+	Column       int       // açılış tag'ının sütunu
+}
+
+// Attr, attribute değerini ve var olup olmadığını döndürür.
+func (b *SFCBlock) Attr(name string) (string, bool) {
+	for _, a := range b.Attrs {
+		if strings.EqualFold(a.Name, name) {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+// HasAttr, blokta verilen attribute'un olup olmadığını kontrol eder.
+func (b *SFCBlock) HasAttr(name string) bool {
+	_, ok := b.Attr(name)
+	return ok
+}
+
+// AttrString, attribute'ları kaynaktaki sırayla ` name="value"` şeklinde döndürür.
+func (b *SFCBlock) AttrString() string {
+	var sb strings.Builder
+	for _, a := range b.Attrs {
+		sb.WriteString(" " + a.Name)
+		if a.HasValue {
+			sb.WriteString(`="` + strings.ReplaceAll(a.Value, `"`, "&quot;") + `"`)
+		}
+	}
+	return sb.String()
+}
+
+// SFCDescriptor, ayrıştırılmış bir .hipo dosyasının bloklarını tutar.
+type SFCDescriptor struct {
+	Source       string
+	Template     *SFCBlock
+	Scripts      []*SFCBlock
+	Styles       []*SFCBlock
+	CustomBlocks []*SFCBlock
+	Blocks       []*SFCBlock // tüm bloklar kaynaktaki sırasıyla
+	FrontMatter  *SFCBlock   // dosya başındaki "---" (YAML) veya "+++" (TOML) bloğu; lang attribute'u formatı verir
+}
+
+// FrontMatterData, front matter içeriğini çözer. Front matter yoksa boş map döner.
+func (d *SFCDescriptor) FrontMatterData() (map[string]interface{}, error) {
+	if d.FrontMatter == nil {
+		return map[string]interface{}{}, nil
+	}
+	lang, _ := d.FrontMatter.Attr("lang")
+	return data.Decode([]byte(d.FrontMatter.Content), lang)
+}
+
+// FrontMatter, template dosyasının front matter verisini döndürür ("---" ile YAML, "+++" ile
+// TOML). Front matter template'in parçası sayılmaz; RenderFile ve diğer render metotları onu atlar.
+//
+//	---
+//	title: Hakkımızda
+//	layout: _layouts/base.hipo
+//	---
+//	<template>...</template>
+func (e *Engine) FrontMatter(filename string) (map[string]interface{}, error) {
+	content, err := e.ReadFileCached(filename)
+	if err != nil {
+		return nil, err
+	}
+	desc, err := ParseSFC(content)
+	if err != nil {
+		return nil, withFile(err, filename)
+	}
+	fm, err := desc.FrontMatterData()
+	if err != nil {
+		return nil, fmt.Errorf("%s: front matter: %w", filename, err)
+	}
+	return fm, nil
+}
+
+// parseFrontMatter, kaynağın ilk satırı "---" veya "+++" ise aynı ayraçla kapanan front matter
+// bloğunu döndürür. Blok, kapanış satırının sonundaki satır sonunu da kapsar.
+func parseFrontMatter(src string) *SFCBlock {
+	for _, fm := range []struct{ delim, lang string }{{"---", data.YAML}, {"+++", data.TOML}} {
+		if !strings.HasPrefix(src, fm.delim+"\n") && !strings.HasPrefix(src, fm.delim+"\r\n") {
+			continue
+		}
+		contentStart := strings.IndexByte(src, '\n') + 1
+		for pos := contentStart; pos < len(src); {
+			next := len(src)
+			lineEnd := strings.IndexByte(src[pos:], '\n')
+			if lineEnd != -1 {
+				next = pos + lineEnd + 1
+			}
+			if strings.TrimRight(src[pos:next], "\r\n") == fm.delim {
+				return &SFCBlock{
+					Type:         "frontmatter",
+					Attrs:        []SFCAttr{{Name: "lang", Value: fm.lang, HasValue: true}},
+					Content:      src[contentStart:pos],
+					Start:        0,
+					End:          next,
+					ContentStart: contentStart,
+					Line:         1,
+					Column:       1,
+				}
+			}
+			pos = next
+		}
+	}
+	return nil
+}
+
+// ParseSFC, .hipo kaynağını üst seviye bloklarına ayırır. Kaynak front matter ile başlıyorsa
+// bloklar front matter'dan sonra aranır. Hata durumunda o ana kadar bulunan bloklarla birlikte
+// TemplateError döner.
+func ParseSFC(src string) (*SFCDescriptor, error) {
+	desc := &SFCDescriptor{Source: src}
+	pos := 0
+	if fm := parseFrontMatter(src); fm != nil {
+		desc.FrontMatter = fm
+		pos = fm.End
+	}
+	for pos < len(src) {
+		lt := strings.IndexByte(src[pos:], '<')
+		if lt == -1 {
+			break
+		}
+		pos += lt
+		if strings.HasPrefix(src[pos:], "<!--") {
+			end := strings.Index(src[pos+4:], "-->")
+			if end == -1 {
+				break
+			}
+			pos += 4 + end + 3
+			continue
+		}
+		name, attrs, tagEnd, selfClosing, ok := scanOpenTag(src, pos)
+		if !ok {
+			pos++
+			continue
+		}
+		block := &SFCBlock{Type: strings.ToLower(name), Attrs: attrs, Start: pos, ContentStart: tagEnd}
+		block.Line, block.Column = getLineCol(src, pos)
+		if selfClosing {
+			block.End = tagEnd
+		} else {
+			var closeStart, closeEnd int
+			if block.Type == "template" {
+				closeStart, closeEnd = findTemplateClose(src, tagEnd)
+			} else {
+				closeStart, closeEnd = findRawClose(src, tagEnd, block.Type)
+			}
+			if closeStart == -1 {
+				switch block.Type {
+				case "template", "script", "style":
+					return desc, &TemplateError{Line: block.Line, Column: block.Column, Message: fmt.Sprintf("<%s> bloğu kapatılmamış", block.Type)}
+				}
+				// Kapanmayan bilinmeyen üst seviye tag'lar blok sayılmaz
+				pos = tagEnd
+				continue
+			}
+			block.Content = src[tagEnd:closeStart]
+			block.End = closeEnd
+		}
+		switch block.Type {
+		case "template":
+			if desc.Template != nil {
+				return desc, &TemplateError{Line: block.Line, Column: block.Column, Message: "birden fazla <template> bloğu"}
+			}
+			desc.Template = block
+		case "script":
+			desc.Scripts = append(desc.Scripts, block)
+		case "style":
+			desc.Styles = append(desc.Styles, block)
+		default:
+			desc.CustomBlocks = append(desc.CustomBlocks, block)
+		}
+		desc.Blocks = append(desc.Blocks, block)
+		pos = block.End
+	}
+	return desc, nil
+}
+
+// scanOpenTag, pos'taki "<name attr=...>" açılış tag'ını okur.
+func scanOpenTag(src string, pos int) (name string, attrs []SFCAttr, end int, selfClosing bool, ok bool) {
+	i := pos + 1
+	for i < len(src) && isTagNameChar(src[i], i == pos+1) {
+		i++
+	}
+	if i == pos+1 {
+		return "", nil, 0, false, false
+	}
+	name = src[pos+1 : i]
+	for i < len(src) {
+		for i < len(src) && isSpace(src[i]) {
+			i++
+		}
+		if i >= len(src) {
+			return "", nil, 0, false, false
+		}
+		switch {
+		case src[i] == '>':
+			return name, attrs, i + 1, false, true
+		case strings.HasPrefix(src[i:], "/>"):
+			return name, attrs, i + 2, true, true
+		}
+		start := i
+		for i < len(src) && !isSpace(src[i]) && src[i] != '=' && src[i] != '>' && !strings.HasPrefix(src[i:], "/>") {
+			i++
+		}
+		attr := SFCAttr{Name: src[start:i]}
+		if attr.Name == "" {
+			return "", nil, 0, false, false
+		}
+		j := i
+		for j < len(src) && isSpace(src[j]) {
+			j++
+		}
+		if j < len(src) && src[j] == '=' {
+			j++
+			for j < len(src) && isSpace(src[j]) {
+				j++
+			}
+			if j < len(src) && (src[j] == '"' || src[j] == '\'') {
+				q := src[j]
+				closeIdx := strings.IndexByte(src[j+1:], q)
+				if closeIdx == -1 {
+					return "", nil, 0, false, false
+				}
+				attr.Value = src[j+1 : j+1+closeIdx]
+				i = j + 1 + closeIdx + 1
+			} else {
+				vs := j
+				for j < len(src) && !isSpace(src[j]) && src[j] != '>' {
+					j++
+				}
+				attr.Value = src[vs:j]
+				i = j
+			}
+			attr.HasValue = true
+		}
+		attrs = append(attrs, attr)
+	}
+	return "", nil, 0, false, false
+}
+
+// findTemplateClose, <template> içeriğinde eşleşen </template>'i bulur. İç içe <template>
+// elementleri, {{ ... }} ifadeleri, HTML yorumları, attribute değerleri ve script/style
+// içerikleri atlanır.
+func findTemplateClose(src string, from int) (int, int) {
+	depth := 0
+	i := from
+	for i < len(src) {
+		switch {
+		case strings.HasPrefix(src[i:], "{{"):
+			end := strings.Index(src[i+2:], "}}")
+			if end == -1 {
+				return -1, -1
+			}
+			i += 2 + end + 2
+		case strings.HasPrefix(src[i:], "<!--"):
+			end := strings.Index(src[i+4:], "-->")
+			if end == -1 {
+				return -1, -1
+			}
+			i += 4 + end + 3
+		case strings.HasPrefix(src[i:], "</"):
+			j := i + 2
+			for j < len(src) && isTagNameChar(src[j], j == i+2) {
+				j++
+			}
+			gt := strings.IndexByte(src[j:], '>')
+			if gt == -1 {
+				return -1, -1
+			}
+			if strings.EqualFold(src[i+2:j], "template") {
+				if depth == 0 {
+					return i, j + gt + 1
+				}
+				depth--
+			}
+			i = j + gt + 1
+		case src[i] == '<':
+			name, _, end, selfClosing, ok := scanOpenTag(src, i)
+			if !ok {
+				i++
+				continue
+			}
+			i = end
+			lower := strings.ToLower(name)
+			if selfClosing {
+				continue
+			}
+			if lower == "template" {
+				depth++
+			} else if lower == "script" || lower == "style" {
+				_, closeEnd := findRawClose(src, end, lower)
+				if closeEnd == -1 {
+					return -1, -1
+				}
+				i = closeEnd
+			}
+		default:
+			i++
+		}
+	}
+	return -1, -1
+}
+
+// findRawClose, ham içerikli bloklarda (script, style, özel bloklar) ilk </name> tag'ını bulur.
+func findRawClose(src string, from int, name string) (int, int) {
+	lower := strings.ToLower(src[from:])
+	idx := strings.Index(lower, "</"+name)
+	for idx != -1 {
+		j := from + idx + 2 + len(name)
+		for j < len(src) && isSpace(src[j]) {
+			j++
+		}
+		if j < len(src) && src[j] == '>' {
+			return from + idx, j + 1
+		}
+		next := strings.Index(lower[idx+1:], "</"+name)
+		if next == -1 {
+			break
+		}
+		idx += 1 + next
+	}
+	return -1, -1
+}
+
+func isTagNameChar(c byte, first bool) bool {
+	if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' {
+		return true
+	}
+	return !first && (c >= '0' && c <= '9' || c == '-' || c == '_' || c == ':')
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+// joinBlockContents, blok içeriklerini kırpıp satır sonuyla birleştirir.
+func joinBlockContents(blocks []*SFCBlock) string {
+	var parts []string
+	for _, b := range blocks {
+		if c := strings.TrimSpace(b.Content); c != "" {
+			parts = append(parts, c)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// assetTags, script/style bloklarını HTML tag'ları olarak üretir. Aynı attribute'lara sahip
+// bloklar ilk görüldükleri sırada tek bir tag içinde birleştirilir; <script type="module">
+// gibi attribute'lar korunur.
+func assetTags(tag string, blocks []*SFCBlock) string {
+	var order []string
+	groups := make(map[string][]*SFCBlock)
+	for _, b := range blocks {
+		key := b.AttrString()
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], b)
+	}
+	var out []string
+	for _, attrs := range order {
+		content := joinBlockContents(groups[attrs])
+		if content == "" {
+			if attrs != "" {
+				out = append(out, "<"+tag+attrs+"></"+tag+">")
+			}
+			continue
+		}
+		out = append(out, "<"+tag+attrs+">\n"+content+"\n</"+tag+">")
+	}
+	return strings.Join(out, "\n")
+}
+
+// withFile, dosya adı içermeyen TemplateError'lara dosya adını ekler.
+func withFile(err error, filename string) error {
+	for _, te := range TemplateErrors(err) {
+		if te.File == "" {
+			te.File = filename
+		}
+	}
+	return err
+}
@@ -0,0 +1,148 @@
+package hipoengine
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseSFCBlocks(t *testing.T) {
+	src := `<template>
+  <template v-if="x"><i>{{ "</template>" }}</i></template>
+  <b title="</template>">x</b>
+</template>
+<script type="module">import a from "./a.js"</script>
+<script>console.log(1)</script>
+<style scoped>p { color: red; }</style>
+<i18n lang="json">{"tr": {}}</i18n>
+<docs>Belgeler</docs>`
+	desc, err := ParseSFC(src)
+	if err != nil {
+		t.Fatalf("ParseSFC error: %v", err)
+	}
+	if desc.Template == nil || !strings.Contains(desc.Template.Content, `<b title="</template>">x</b>`) {
+		t.Fatalf("Template bloğu hatalı: %#v", desc.Template)
+	}
+	if len(desc.Scripts) != 2 || len(desc.Styles) != 1 || len(desc.CustomBlocks) != 2 || len(desc.Blocks) != 6 {
+		t.Fatalf("Blok sayıları hatalı: %d script, %d style, %d özel", len(desc.Scripts), len(desc.Styles), len(desc.CustomBlocks))
+	}
+	if v, _ := desc.Scripts[0].Attr("type"); v != "module" {
+		t.Errorf("Script type attribute'u 'module' olmalıydı, gerçek: '%s'", v)
+	}
+	if !desc.Styles[0].HasAttr("scoped") {
+		t.Errorf("Style bloğunda scoped attribute'u bekleniyordu")
+	}
+	if desc.CustomBlocks[0].Type != "i18n" || desc.CustomBlocks[1].Content != "Belgeler" {
+		t.Errorf("Özel bloklar hatalı: %#v", desc.CustomBlocks)
+	}
+	if desc.Scripts[0].Line != 5 || desc.Scripts[0].Column != 1 {
+		t.Errorf("Script konumu 5:1 olmalıydı, gerçek: %d:%d", desc.Scripts[0].Line, desc.Scripts[0].Column)
+	}
+}
+
+func TestParseSFCUnclosedBlock(t *testing.T) {
+	_, err := ParseSFC("<template>\n<p>x</p>\n\n<script>\nvar a;</script>")
+	te, ok := err.(*TemplateError)
+	if !ok || te.Line != 1 {
+		t.Fatalf("Satır 1'de kapatılmamış template hatası bekleniyordu, gerçek: %v", err)
+	}
+}
+
+func TestRenderFileKeepsBlockAttributes(t *testing.T) {
+	e := NewEngine()
+	e.AddTemplatePath("testdata/sfc")
+	out, err := e.RenderFile("module.hipo", map[string]interface{}{"name": "Emre"})
+	if err != nil {
+		t.Fatalf("RenderFile error: %v", err)
+	}
+	for _, want := range []string{
+		"<p>&lt;/template&gt;Emre</p>",
+		`<script type="module">`,
+		`console.log("ikinci");`,
+		`<style media="print">`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Çıktıda '%s' bekleniyordu:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Modül örneği") {
+		t.Errorf("Özel bloklar çıktıya eklenmemeli:\n%s", out)
+	}
+}
+
+func TestParseFileUsesTemplateBlock(t *testing.T) {
+	e := NewEngine()
+	e.AddTemplatePath("testdata/sfc")
+	ast, err := e.ParseFile("module.hipo")
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	out, err := ast.Execute(NewContext(map[string]interface{}{"name": "Emre"}, e.funcs, e.filters, e))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "<p>&lt;/template&gt;Emre</p>") {
+		t.Errorf("Template bloğu render edilmeliydi:\n%s", out)
+	}
+	for _, unwanted := range []string{"<template>", "console.log", "Modül örneği"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("ParseFile yalnızca <template> bloğunu parse etmeli, çıktıda %q var:\n%s", unwanted, out)
+		}
+	}
+	// Hata satırları dosyanın tamamına göre verilir
+	if _, err := e.ParseFile("broken.hipo"); err == nil {
+		t.Error("broken.hipo için parse hatası bekleniyordu")
+	} else if te, ok := err.(*TemplateError); !ok || te.Line != 3 {
+		t.Errorf("Hata satır 3'te olmalıydı, gerçek: %v", err)
+	}
+}
+
+func TestTemplateErrorUsesFileLine(t *testing.T) {
+	e := NewEngine()
+	e.AddTemplatePath("testdata/sfc")
+	_, err := e.RenderFileContext("broken.hipo", NewContext(map[string]interface{}{}, e.funcs, e.filters, e))
+	te, ok := err.(*TemplateError)
+	if !ok {
+		t.Fatalf("TemplateError bekleniyordu, gerçek: %v", err)
+	}
+	if te.File != "broken.hipo" || te.Line != 3 {
+		t.Errorf("Hata broken.hipo satır 3'te olmalıydı, gerçek: %s:%d", te.File, te.Line)
+	}
+}
+
+func TestFrontMatter(t *testing.T) {
+	e := NewEngine()
+	e.AddTemplatePath("testdata/frontmatter")
+	fm, err := e.FrontMatter("page.hipo")
+	if err != nil {
+		t.Fatalf("FrontMatter error: %v", err)
+	}
+	if fm["title"] != "Hakkımızda" || len(fm["tags"].([]interface{})) != 2 {
+		t.Errorf("YAML front matter hatalı: %#v", fm)
+	}
+	out, err := e.RenderFile("page.hipo", fm)
+	if err != nil {
+		t.Fatalf("RenderFile error: %v", err)
+	}
+	if strings.Contains(out, "---") || !strings.Contains(out, "<h1>Hakkımızda</h1>") {
+		t.Errorf("front matter çıktıya karışmamalı: %q", out)
+	}
+
+	fm, err = e.FrontMatter("post.hipo")
+	if err != nil || fm["layout"] != "_layouts/base.hipo" {
+		t.Fatalf("TOML front matter hatalı: %#v, %v", fm, err)
+	}
+	// Layout'taki blok dışı içerik (değişkenler, include) de render edilir
+	out, err = e.RenderWithLayout("post.hipo", fm["layout"].(string), fm)
+	if err != nil {
+		t.Fatalf("RenderWithLayout error: %v", err)
+	}
+	if want := "<html><head><title>Yazı</title></head><body><nav>Menü</nav><article>Yazı</article></body></html>"; strings.TrimSpace(out) != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+
+	// Hata satırları front matter dahil dosyaya göre hesaplanır
+	_, err = e.RenderFile("broken.hipo", nil)
+	if issues, _ := e.Lint("broken.hipo"); len(issues) == 0 || issues[0].Line != 5 {
+		t.Errorf("lint satırı 5 olmalıydı: %v (render: %v)", issues, err)
+	}
+}
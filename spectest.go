@@ -0,0 +1,385 @@
+// spectest.go
+// Go kodu yazmadan template testi: spec dosyalarını okuma, çalıştırma ve golden dosyaları güncelleme
+package hipoengine
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"hipoengine/internal/data"
+)
+
+// TestSpec, bir template için test senaryolarını içeren spec dosyasıdır. Spec dosyaları test
+// edilen template'in yanında <ad>.test.yaml, <ad>.test.json veya <ad>.test.toml adıyla durur:
+//
+//	template: index.hipo        # verilmezse spec adından: index.test.yaml → index.hipo
+//	cases:
+//	  - name: misafir
+//	    locale: tr
+//	    context:
+//	      user: {name: Ali}
+//	    expect: |
+//	      <h1>Merhaba Ali</h1>
+//	  - name: liste
+//	    data: fixtures/products.json   # context dosyası; context alanı üzerine yazılır
+//	    golden: index.liste.html       # beklenen çıktı dosyası (-update ile yazılır)
+//	    contains: ["<li>Kalem</li>"]
+//	    not_contains: ["Stokta yok"]
+//	  - name: hatalı veri
+//	    error: "outside of for loop"   # render hatasının içermesi gereken metin
+//
+// Yollar spec dosyasının dizinine göre çözülür.
+type TestSpec struct {
+	File     string
+	Template string
+	Cases    []TestCase
+}
+
+// TestCase, tek bir render senaryosudur. Beklenti verilmeyen bir senaryo, template'in hatasız
+// render edilmesini kontrol eder.
+type TestCase struct {
+	Name        string
+	Locale      string
+	Context     map[string]interface{}
+	Data        string
+	Expect      *string // tam çıktı; baştaki ve sondaki boşluklar karşılaştırılmaz
+	Golden      string
+	Contains    []string
+	NotContains []string
+	Error       string
+}
+
+// TestResult, bir senaryonun sonucudur. Message başarısızlığın nedenini (çıktı farklıysa satır
+// farkıyla birlikte) içerir.
+type TestResult struct {
+	Spec    string `json:"spec"`
+	Case    string `json:"case"`
+	Passed  bool   `json:"passed"`
+	Updated bool   `json:"updated,omitempty"` // golden dosyası yazıldı
+	Message string `json:"message,omitempty"`
+}
+
+// TestOptions, RunTestSpec ayarlarıdır.
+type TestOptions struct {
+	Update bool // golden dosyalarını gerçek çıktıyla yeniden yaz
+}
+
+// IsTestSpec, dosya adının bir spec dosyası (*.test.yaml, *.test.yml, *.test.json, *.test.toml)
+// olup olmadığını döndürür.
+func IsTestSpec(filename string) bool {
+	ext := filepath.Ext(filename)
+	return data.Format(filename) != "" && strings.HasSuffix(strings.TrimSuffix(filename, ext), ".test")
+}
+
+// LoadTestSpec, spec dosyasını okur ve alanlarını doğrular.
+func LoadTestSpec(filename string) (*TestSpec, error) {
+	fields, err := data.Load(filename)
+	if err != nil {
+		return nil, err
+	}
+	spec := &TestSpec{File: filename}
+	dir := filepath.Dir(filename)
+	base := filepath.Base(filename)
+	spec.Template = filepath.Join(dir, strings.TrimSuffix(base, ".test"+filepath.Ext(base))+".hipo")
+	for key, v := range fields {
+		switch key {
+		case "template":
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: template bir string olmalı", filename)
+			}
+			spec.Template = filepath.Join(dir, s)
+		case "cases":
+			list, ok := v.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s: cases bir liste olmalı", filename)
+			}
+			for i, item := range list {
+				c, err := parseTestCase(item, dir)
+				if err != nil {
+					return nil, fmt.Errorf("%s: cases[%d]: %w", filename, i, err)
+				}
+				if c.Name == "" {
+					c.Name = fmt.Sprint(i + 1)
+				}
+				spec.Cases = append(spec.Cases, c)
+			}
+		default:
+			return nil, fmt.Errorf("%s: bilinmeyen alan: %s", filename, key)
+		}
+	}
+	if len(spec.Cases) == 0 {
+		return nil, fmt.Errorf("%s: hiç senaryo (cases) yok", filename)
+	}
+	return spec, nil
+}
+
+func parseTestCase(v interface{}, dir string) (TestCase, error) {
+	var c TestCase
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return c, fmt.Errorf("senaryo bir map olmalı")
+	}
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		v := m[key]
+		var err error
+		switch key {
+		case "name":
+			c.Name = fmt.Sprint(v)
+		case "locale":
+			c.Locale, err = specString(key, v)
+		case "context":
+			var ok bool
+			if c.Context, ok = v.(map[string]interface{}); !ok {
+				err = fmt.Errorf("context bir map olmalı")
+			}
+		case "data":
+			c.Data, err = specString(key, v)
+			c.Data = filepath.Join(dir, c.Data)
+		case "expect":
+			var s string
+			s, err = specString(key, v)
+			c.Expect = &s
+		case "golden":
+			c.Golden, err = specString(key, v)
+			c.Golden = filepath.Join(dir, c.Golden)
+		case "contains", "not_contains":
+			var list []string
+			switch v := v.(type) {
+			case string:
+				list = []string{v}
+			case []interface{}:
+				for _, item := range v {
+					list = append(list, fmt.Sprint(item))
+				}
+			default:
+				err = fmt.Errorf("%s bir string veya liste olmalı", key)
+			}
+			if key == "contains" {
+				c.Contains = list
+			} else {
+				c.NotContains = list
+			}
+		case "error":
+			c.Error, err = specString(key, v)
+		default:
+			err = fmt.Errorf("bilinmeyen alan: %s", key)
+		}
+		if err != nil {
+			return c, err
+		}
+	}
+	return c, nil
+}
+
+func specString(key string, v interface{}) (string, error) {
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("%s bir string olmalı", key)
+	}
+	return s, nil
+}
+
+// FindTestSpecs, argümanlardaki dizinleri içlerindeki spec dosyalarıyla değiştirir ve sonucu
+// sıralı döndürür.
+func FindTestSpecs(paths ...string) ([]string, error) {
+	var specs []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			specs = append(specs, path)
+			continue
+		}
+		err = filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() && IsTestSpec(p) {
+				specs = append(specs, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(specs)
+	return specs, nil
+}
+
+// RunTestSpec, spec'in senaryolarını sırayla render edip beklentilerle karşılaştırır. Her
+// senaryo kendi locale'i ile render edilir; engine'in locale'i sonunda eski haline döner.
+func (e *Engine) RunTestSpec(spec *TestSpec, opts TestOptions) []TestResult {
+	locale := e.currentLocale
+	defer func() { e.currentLocale = locale }()
+	var results []TestResult
+	for _, c := range spec.Cases {
+		e.currentLocale = locale
+		if c.Locale != "" {
+			e.currentLocale = c.Locale
+		}
+		r := TestResult{Spec: spec.File, Case: c.Name}
+		r.Message, r.Updated = e.runTestCase(spec, c, opts)
+		r.Passed = r.Message == ""
+		results = append(results, r)
+	}
+	return results
+}
+
+// runTestCase, senaryonun başarısızlık mesajını (başarılıysa "") döndürür.
+func (e *Engine) runTestCase(spec *TestSpec, c TestCase, opts TestOptions) (string, bool) {
+	ctx := map[string]interface{}{}
+	if c.Data != "" {
+		loaded, err := data.Load(c.Data)
+		if err != nil {
+			return err.Error(), false
+		}
+		for k, v := range loaded {
+			ctx[k] = v
+		}
+	}
+	for k, v := range c.Context {
+		ctx[k] = v
+	}
+	out, err := e.RenderFile(spec.Template, ctx)
+	if c.Error != "" {
+		switch {
+		case err == nil:
+			return fmt.Sprintf("%q içeren bir hata bekleniyordu, render başarılı oldu", c.Error), false
+		case !strings.Contains(err.Error(), c.Error):
+			return fmt.Sprintf("hata %q içermiyor: %v", c.Error, err), false
+		}
+		return "", false
+	}
+	if err != nil {
+		return "render hatası: " + err.Error(), false
+	}
+	var failures []string
+	if c.Expect != nil {
+		if diff := diffOutput(*c.Expect, out); diff != "" {
+			failures = append(failures, "çıktı beklenenden farklı:\n"+diff)
+		}
+	}
+	updated := false
+	if c.Golden != "" {
+		expected, err := os.ReadFile(c.Golden)
+		switch {
+		case opts.Update && (err != nil || diffOutput(string(expected), out) != ""):
+			if err := writeGolden(c.Golden, out); err != nil {
+				return err.Error(), false
+			}
+			updated = true
+		case err != nil && os.IsNotExist(err):
+			failures = append(failures, fmt.Sprintf("golden dosyası yok: %s (oluşturmak için güncelleme modunda çalıştırın)", c.Golden))
+		case err != nil:
+			failures = append(failures, err.Error())
+		default:
+			if diff := diffOutput(string(expected), out); diff != "" {
+				failures = append(failures, fmt.Sprintf("çıktı %s ile farklı:\n%s", c.Golden, diff))
+			}
+		}
+	}
+	for _, s := range c.Contains {
+		if !strings.Contains(out, s) {
+			failures = append(failures, fmt.Sprintf("çıktı %q içermiyor", s))
+		}
+	}
+	for _, s := range c.NotContains {
+		if strings.Contains(out, s) {
+			failures = append(failures, fmt.Sprintf("çıktı %q içeriyor", s))
+		}
+	}
+	if len(failures) > 0 && c.Expect == nil && c.Golden == "" {
+		failures = append(failures, "çıktı:\n"+out)
+	}
+	return strings.Join(failures, "\n"), updated
+}
+
+func writeGolden(filename, out string) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filename, []byte(strings.TrimSpace(out)+"\n"), 0644)
+}
+
+// diffContext, satır farkında değişikliklerin çevresinde gösterilen değişmemiş satır sayısıdır.
+const diffContext = 2
+
+// diffOutput, iki çıktıyı baştaki ve sondaki boşlukları yok sayarak karşılaştırır; farklıysa
+// "-" beklenen, "+" gerçek satırları gösteren bir satır farkı döndürür.
+func diffOutput(expected, actual string) string {
+	expected, actual = strings.TrimSpace(expected), strings.TrimSpace(actual)
+	if expected == actual {
+		return ""
+	}
+	a, b := strings.Split(expected, "\n"), strings.Split(actual, "\n")
+	// lcs[i][j]: a[i:] ve b[j:] için en uzun ortak alt dizi uzunluğu
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	type diffLine struct {
+		op   byte
+		text string
+	}
+	var lines []diffLine
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i]})
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] > lcs[i+1][j]):
+			lines = append(lines, diffLine{'+', b[j]})
+			j++
+		default:
+			lines = append(lines, diffLine{'-', a[i]})
+			i++
+		}
+	}
+	var sb strings.Builder
+	sb.WriteString("--- beklenen\n+++ gerçek\n")
+	skipped := false
+	for k, l := range lines {
+		near := false
+		for d := k - diffContext; d <= k+diffContext; d++ {
+			if d >= 0 && d < len(lines) && lines[d].op != ' ' {
+				near = true
+				break
+			}
+		}
+		if !near {
+			if !skipped {
+				sb.WriteString("  ...\n")
+			}
+			skipped = true
+			continue
+		}
+		skipped = false
+		fmt.Fprintf(&sb, "%c %s\n", l.op, l.text)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -0,0 +1,77 @@
+package hipoengine
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func specEngine(t *testing.T) *Engine {
+	e := NewEngine()
+	if err := e.SetTranslationsFromDir("testdata/spectest/locale"); err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestRunTestSpec(t *testing.T) {
+	spec, err := LoadTestSpec("testdata/spectest/greeting.test.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.Template != filepath.Join("testdata", "spectest", "greeting.hipo") || len(spec.Cases) != 4 {
+		t.Fatalf("spec yanlış okundu: %+v", spec)
+	}
+	e := specEngine(t)
+	for _, r := range e.RunTestSpec(spec, TestOptions{}) {
+		if !r.Passed {
+			t.Errorf("%s/%s başarısız: %s", r.Spec, r.Case, r.Message)
+		}
+	}
+	if e.currentLocale != "" {
+		t.Errorf("locale geri alınmadı: %q", e.currentLocale)
+	}
+
+	dir := t.TempDir()
+	expect := "<h1>Merhaba, Ali</h1>\n<ul><li>Kalem</li></ul>\n<p>son</p>"
+	spec.Cases = []TestCase{
+		{Name: "fark", Locale: "tr", Context: map[string]interface{}{"user": map[string]interface{}{"name": "Ali"}, "products": []interface{}{"Silgi"}}, Expect: &expect},
+		{Name: "hata", Error: "koleksiyonu", Context: map[string]interface{}{"user": map[string]interface{}{}, "products": []interface{}{}}},
+		{Name: "golden", Locale: "tr", Context: map[string]interface{}{"user": map[string]interface{}{"name": "Veli"}, "products": []interface{}{}}, Golden: filepath.Join(dir, "out.html")},
+	}
+	results := e.RunTestSpec(spec, TestOptions{})
+	wantDiff := "çıktı beklenenden farklı:\n--- beklenen\n+++ gerçek\n  <h1>Merhaba, Ali</h1>\n- <ul><li>Kalem</li></ul>\n- <p>son</p>\n+ <ul><li>Silgi</li></ul>"
+	if results[0].Passed || results[0].Message != wantDiff {
+		t.Errorf("fark mesajı:\n%s\nbeklenen:\n%s", results[0].Message, wantDiff)
+	}
+	if results[1].Passed || !strings.Contains(results[1].Message, "hata bekleniyordu") {
+		t.Errorf("hata senaryosu: %+v", results[1])
+	}
+	if results[2].Passed || !strings.Contains(results[2].Message, "golden dosyası yok") {
+		t.Errorf("golden senaryosu: %+v", results[2])
+	}
+
+	results = e.RunTestSpec(spec, TestOptions{Update: true})
+	if !results[2].Passed || !results[2].Updated {
+		t.Errorf("güncelleme modunda golden yazılmalı: %+v", results[2])
+	}
+	if got, _ := os.ReadFile(filepath.Join(dir, "out.html")); !strings.HasPrefix(string(got), "<h1>Merhaba, Veli</h1>") {
+		t.Errorf("golden içeriği: %q", got)
+	}
+	if results := e.RunTestSpec(spec, TestOptions{}); !results[2].Passed || results[2].Updated {
+		t.Errorf("golden güncellendikten sonra: %+v", results[2])
+	}
+}
+
+func TestDiffOutput(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9"
+	b := "1\n2\n3\n4\n5\n6\n7\nsekiz\n9\n10"
+	want := "--- beklenen\n+++ gerçek\n  ...\n  6\n  7\n- 8\n+ sekiz\n  9\n+ 10"
+	if got := diffOutput(a, b); got != want {
+		t.Errorf("diffOutput:\n%s\nbeklenen:\n%s", got, want)
+	}
+	if got := diffOutput("a\n", "  a"); got != "" {
+		t.Errorf("baştaki/sondaki boşluklar yok sayılmalı: %q", got)
+	}
+}
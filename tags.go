@@ -0,0 +1,122 @@
+// tags.go
+// Özel tag (custom tag) kayıt ve parse altyapısı
+package hipoengine
+
+import "fmt"
+
+// TagParseFunc, özel bir tag'ı kendi ASTNode implementasyonuna dönüştüren fonksiyondur.
+type TagParseFunc func(t *TagParser) (ASTNode, error)
+
+// tagSpec, RegisterTag ile kaydedilen bir tag'ın tanımıdır.
+type tagSpec struct {
+	endTag string
+	parse  TagParseFunc
+}
+
+// builtinTags, parser'ın kendi işlediği ve özel tag olarak kaydedilemeyen isimlerdir.
+var builtinTags = map[string]bool{
+	"set": true, "include": true, "extends": true, "block": true, "endblock": true, "embed": true,
+	"if": true, "elif": true, "else": true, "endif": true,
+	"for": true, "endfor": true, "break": true, "continue": true,
+	"with": true, "endwith": true,
+	"switch": true, "case": true, "default": true, "endswitch": true,
+	"component": true, "endcomponent": true, "slot": true, "endslot": true,
+	"props": true, "macro": true, "endmacro": true,
+	"styles": true, "scripts": true,
+}
+
+// TagParser, özel tag parse fonksiyonuna tag bilgisi, token akışı ve gövde parse imkânı sunar.
+type TagParser struct {
+	Name   string // tag adı
+	Args   string // tag adından sonraki ham içerik (ör: {{ feature "x" }} için `"x"`)
+	Body   string // açılış ve kapanış tag'ları arasındaki ham içerik (end tag yoksa boş)
+	Line   int
+	Column int
+
+	parser     *Parser
+	start, end int // açılış tag'ının offset aralığı
+	bodyStart  int
+	bodyEnd    int
+}
+
+// ArgTokens, tag argümanlarını boşluklardan ayırır (tırnak içindeki boşluklar korunur).
+func (t *TagParser) ArgTokens() []string {
+	return splitFields(t.Args)
+}
+
+// Tokens, tag gövdesinin token akışını döndürür.
+func (t *TagParser) Tokens() []Token {
+	return Tokenize(t.Body)
+}
+
+// ParseBody, tag gövdesini parser'ın tüm tag'larıyla (özel tag'lar dahil) parse eder.
+func (t *TagParser) ParseBody() (ASTNode, error) {
+	return t.parser.sub(t.bodyStart, t.bodyEnd).Parse()
+}
+
+// ParseTemplate, verilen kaynağı aynı parser ayarlarıyla parse eder.
+func (t *TagParser) ParseTemplate(src string) (ASTNode, error) {
+	return t.parser.derive(src).Parse()
+}
+
+// Errorf, açılış tag'ının konumunu ve aralığını içeren bir TemplateError döndürür.
+func (t *TagParser) Errorf(format string, args ...interface{}) error {
+	return t.parser.errorAt(t.start, t.end, format, args...)
+}
+
+// NodeFunc, basit özel tag'lar için bir fonksiyonu ASTNode olarak kullanmayı sağlar.
+type NodeFunc func(ctx *Context) (string, error)
+
+func (f NodeFunc) Execute(ctx *Context) (string, error) {
+	return f(ctx)
+}
+
+func (f NodeFunc) ExecuteRaw(ctx *Context) (interface{}, error) {
+	return f(ctx)
+}
+
+// RegisterTag, yeni bir tag kaydeder. endTag boşsa tag gövdesizdir ({{ name args }}),
+// değilse {{ name args }}...{{ endTag }} şeklinde gövde alır. Daha önce parse edilip cache'lenen
+// template'lerin yeni tag'ı görmesi için cache'ler temizlenir (ClearCache).
+func (e *Engine) RegisterTag(name, endTag string, parse TagParseFunc) error {
+	if name == "" || parse == nil {
+		return fmt.Errorf("tag adı ve parse fonksiyonu zorunludur")
+	}
+	if builtinTags[name] || builtinTags[endTag] {
+		return fmt.Errorf("'%s' built-in bir tag, özel tag olarak kaydedilemez", name)
+	}
+	e.cacheMu.Lock()
+	defer e.cacheMu.Unlock()
+	// Harita kopyalanarak değiştirilir; o sırada parse eden parser'lar eski haritayı okumaya devam eder
+	tags := make(map[string]*tagSpec, len(e.tags)+1)
+	for k, v := range e.tags {
+		tags[k] = v
+	}
+	tags[name] = &tagSpec{endTag: endTag, parse: parse}
+	e.tags = tags
+	e.clearCaches()
+	return nil
+}
+
+// parseCustomTag, kayıtlı özel tag'ı parse eder ve tag'ın bittiği offset'i döndürür. Hata
+// durumunda hata kaydedilir ve node nil döner.
+func (p *Parser) parseCustomTag(spec *tagSpec, t tagToken) (ASTNode, int) {
+	line, col := p.lineCol(t.start)
+	tp := &TagParser{Name: t.name, Args: t.args(), Line: line, Column: col, parser: p, start: t.start, end: t.end}
+	end := t.end
+	if spec.endTag != "" {
+		closing, ok := findBlockTag(p.template, t.end, t.name, spec.endTag)
+		if !ok {
+			return nil, p.unclosed(t, t.end, fmt.Sprintf("unclosed %s block", t.name))
+		}
+		tp.bodyStart, tp.bodyEnd = t.end, closing.start
+		tp.Body = p.template[t.end:closing.start]
+		end = closing.end
+	}
+	node, err := spec.parse(tp)
+	if err != nil {
+		p.record(err, t.start, t.end)
+		return nil, end
+	}
+	return node, end
+}
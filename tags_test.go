@@ -0,0 +1,127 @@
+package hipoengine
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRegisterTagWithBody(t *testing.T) {
+	e := NewEngine()
+	err := e.RegisterTag("feature", "endfeature", func(tp *TagParser) (ASTNode, error) {
+		args := tp.ArgTokens()
+		if len(args) != 1 {
+			return nil, tp.Errorf("feature tag'ı tek argüman alır")
+		}
+		name := strings.Trim(args[0], `"'`)
+		body, err := tp.ParseBody()
+		if err != nil {
+			return nil, err
+		}
+		return NodeFunc(func(ctx *Context) (string, error) {
+			flags, _ := ctx.Get("features")
+			if m, ok := flags.(map[string]interface{}); ok && m[name] == true {
+				return body.Execute(ctx)
+			}
+			return "", nil
+		}), nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterTag error: %v", err)
+	}
+	tpl := `{{ feature "beta" }}Beta: {{ user }}{{ endfeature }}{{ feature "old" }}Eski{{ endfeature }}`
+	ctx := map[string]interface{}{
+		"user":     "Emre",
+		"features": map[string]interface{}{"beta": true},
+	}
+	out, err := e.Render(tpl, ctx)
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if out != "Beta: Emre" {
+		t.Errorf("Beklenen: 'Beta: Emre', Gerçek: '%s'", out)
+	}
+
+	_, err = e.Render(`{{ feature }}x{{ endfeature }}`, ctx)
+	if err == nil {
+		t.Error("Argümansız feature tag'ı için hata bekleniyordu")
+	}
+}
+
+func TestRegisterTagWithoutBody(t *testing.T) {
+	e := NewEngine()
+	e.RegisterTag("counter", "", func(tp *TagParser) (ASTNode, error) {
+		name := tp.Args
+		return NodeFunc(func(ctx *Context) (string, error) {
+			v, _ := ctx.Get(name)
+			n, _ := v.(int)
+			ctx.Set(name, n+1)
+			return "", nil
+		}), nil
+	})
+	out, err := e.Render(`{{ counter hits }}{{ counter hits }}{{ hits }}`, map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if out != "2" {
+		t.Errorf("Beklenen: '2', Gerçek: '%s'", out)
+	}
+}
+
+func TestRegisterTagRejectsBuiltin(t *testing.T) {
+	e := NewEngine()
+	if err := e.RegisterTag("if", "endif", func(tp *TagParser) (ASTNode, error) { return nil, nil }); err == nil {
+		t.Error("Built-in tag adı için hata bekleniyordu")
+	}
+}
+
+func TestRegisterTagClearsCache(t *testing.T) {
+	e := NewEngine()
+	e.SetBytecodeMode(true)
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "page.hipo"), []byte("<template>[{{ hello }}]</template>"), 0644)
+	e.AddTemplatePath(dir)
+	if got, err := e.RenderFile("page.hipo", nil); err != nil || got != "[]" {
+		t.Fatalf("Kayıttan önce: %q, %v", got, err)
+	}
+	e.RegisterTag("hello", "", func(tp *TagParser) (ASTNode, error) {
+		return NodeFunc(func(ctx *Context) (string, error) { return "merhaba", nil }), nil
+	})
+	if got, err := e.RenderFile("page.hipo", nil); err != nil || got != "[merhaba]" {
+		t.Errorf("Cache'lenmiş template yeni tag'ı görmeli: %q, %v", got, err)
+	}
+}
+
+func TestRegisterTagWhileRendering(t *testing.T) {
+	// go test -race ile RegisterTag ve render arasında veri yarışı olmamalı
+	e := NewEngine()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for j := 0; j < 50; j++ {
+			e.Render(`{{ if x }}{{ x }}{{ endif }}`, map[string]interface{}{"x": j})
+		}
+	}()
+	for _, name := range []string{"a", "b", "c", "d"} {
+		e.RegisterTag(name, "", func(tp *TagParser) (ASTNode, error) {
+			return NodeFunc(func(*Context) (string, error) { return "", nil }), nil
+		})
+	}
+	wg.Wait()
+}
+
+func TestTokenize(t *testing.T) {
+	tokens := Tokenize("a {{ if x }}\nb{{endif}}")
+	if len(tokens) != 4 {
+		t.Fatalf("Beklenen 4 token, gerçek %d", len(tokens))
+	}
+	if tokens[1].Kind != TokenTag || tokens[1].Name != "if" || tokens[1].Args() != "x" {
+		t.Errorf("if token'ı yanlış: %#v", tokens[1])
+	}
+	if tokens[3].Name != "endif" || tokens[3].Line != 2 || tokens[3].Column != 2 {
+		t.Errorf("endif token'ı yanlış: %#v", tokens[3])
+	}
+}
@@ -0,0 +1,160 @@
+// tests.go
+// Built-in "is" testleri ve test yönetimi
+package hipoengine
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// TestFunc, {{ if x is name }} ifadelerinde kullanılan test fonksiyonudur.
+type TestFunc func(val interface{}, args ...interface{}) bool
+
+// DefaultTests: built-in testlerin listesi
+var DefaultTests = map[string]TestFunc{
+	// defined/undefined: {{ if x is defined }} ifadelerinde değişkenin context zincirinde bulunup
+	// bulunmadığına bakılır (değeri nil olsa bile bulunan değişken tanımlıdır); bu fonksiyonlar
+	// yalnızca doğrudan çağrıldıklarında değere göre karar verir.
+	"defined": func(val interface{}, args ...interface{}) bool {
+		return val != nil
+	},
+	"undefined": func(val interface{}, args ...interface{}) bool {
+		return val == nil
+	},
+	"none": func(val interface{}, args ...interface{}) bool {
+		return val == nil
+	},
+	"even": func(val interface{}, args ...interface{}) bool {
+		i, ok := toInt(val)
+		return ok && i%2 == 0
+	},
+	"odd": func(val interface{}, args ...interface{}) bool {
+		i, ok := toInt(val)
+		return ok && i%2 != 0
+	},
+	"divisibleby": func(val interface{}, args ...interface{}) bool {
+		if len(args) == 0 {
+			return false
+		}
+		i, ok := toInt(val)
+		d, dok := toInt(args[0])
+		if !ok || !dok || d == 0 {
+			return false
+		}
+		return i%d == 0
+	},
+	"iterable": func(val interface{}, args ...interface{}) bool {
+		if val == nil {
+			return false
+		}
+		switch reflect.ValueOf(val).Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map, reflect.String:
+			return true
+		}
+		return false
+	},
+	"mapping": func(val interface{}, args ...interface{}) bool {
+		return val != nil && reflect.ValueOf(val).Kind() == reflect.Map
+	},
+	"string": func(val interface{}, args ...interface{}) bool {
+		_, ok := val.(string)
+		return ok
+	},
+	"number": func(val interface{}, args ...interface{}) bool {
+		return isNumber(val)
+	},
+	"sameas": func(val interface{}, args ...interface{}) bool {
+		if len(args) == 0 {
+			return false
+		}
+		return sameAs(val, args[0])
+	},
+}
+
+// isNumber, değerin Go sayısal tiplerinden biri olup olmadığını kontrol eder.
+func isNumber(val interface{}) bool {
+	if val == nil {
+		return false
+	}
+	switch reflect.ValueOf(val).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// toInt, tam sayıya çevrilebilen değerleri int olarak döndürür.
+func toInt(val interface{}) (int, bool) {
+	switch v := val.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		if v == float64(int(v)) {
+			return int(v), true
+		}
+	case float32:
+		if v == float32(int(v)) {
+			return int(v), true
+		}
+	case string:
+		if i, err := strconv.Atoi(v); err == nil {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// sameAs, iki değerin aynı nesne olup olmadığını kontrol eder (map/slice/pointer için referans eşitliği).
+func sameAs(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	ra, rb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if ra.Kind() != rb.Kind() {
+		return false
+	}
+	switch ra.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Ptr, reflect.Func, reflect.Chan:
+		return ra.Pointer() == rb.Pointer()
+	}
+	if ra.Type().Comparable() && ra.Type() == rb.Type() {
+		return a == b
+	}
+	return false
+}
+
+// splitIsExpr, "x is not divisibleby 3" ifadesini sol taraf, test adı, argümanlar ve negate olarak ayırır.
+func splitIsExpr(expr string) (left, name string, args []string, negate, ok bool) {
+	idx := indexOutsideQuotes(expr, " is ")
+	if idx == -1 {
+		return "", "", nil, false, false
+	}
+	left = strings.TrimSpace(expr[:idx])
+	rest := strings.TrimSpace(expr[idx+len(" is "):])
+	if strings.HasPrefix(rest, "not ") {
+		negate = true
+		rest = strings.TrimSpace(rest[4:])
+	}
+	if rest == "" {
+		return "", "", nil, false, false
+	}
+	// divisibleby(3) veya divisibleby 3 şeklinde argüman desteği
+	if open := strings.Index(rest, "("); open != -1 && strings.HasSuffix(rest, ")") {
+		name = strings.TrimSpace(rest[:open])
+		for _, a := range splitArgs(rest[open+1 : len(rest)-1]) {
+			if a = strings.TrimSpace(a); a != "" {
+				args = append(args, a)
+			}
+		}
+	} else {
+		fields := splitFields(rest)
+		name = fields[0]
+		args = fields[1:]
+	}
+	return left, name, args, negate, true
+}
@@ -0,0 +1,98 @@
+package hipoengine
+
+import (
+	"testing"
+)
+
+func TestIsBuiltinTests(t *testing.T) {
+	e := NewEngine()
+	ctx := map[string]interface{}{
+		"n":     4,
+		"name":  "Emre",
+		"items": []interface{}{1, 2},
+		"user":  map[string]interface{}{"age": 21, "phone": nil},
+		"empty": nil,
+	}
+	cases := map[string]string{
+		`{{ if n is even }}evet{{ else }}hayır{{ endif }}`:              "evet",
+		`{{ if n is odd }}evet{{ else }}hayır{{ endif }}`:               "hayır",
+		`{{ if n is divisibleby 2 }}evet{{ else }}hayır{{ endif }}`:     "evet",
+		`{{ if n is divisibleby(3) }}evet{{ else }}hayır{{ endif }}`:    "hayır",
+		`{{ if name is string }}evet{{ else }}hayır{{ endif }}`:         "evet",
+		`{{ if n is number }}evet{{ else }}hayır{{ endif }}`:            "evet",
+		`{{ if items is iterable }}evet{{ else }}hayır{{ endif }}`:      "evet",
+		`{{ if user is mapping }}evet{{ else }}hayır{{ endif }}`:        "evet",
+		`{{ if missing is defined }}evet{{ else }}hayır{{ endif }}`:     "hayır",
+		`{{ if missing is not defined }}evet{{ else }}hayır{{ endif }}`: "evet",
+		`{{ if user.age is defined }}evet{{ else }}hayır{{ endif }}`:    "evet",
+		`{{ if user.city is none }}evet{{ else }}hayır{{ endif }}`:      "evet",
+		`{{ if empty is defined }}evet{{ else }}hayır{{ endif }}`:       "evet",
+		`{{ if empty is none }}evet{{ else }}hayır{{ endif }}`:          "evet",
+		`{{ if empty is undefined }}evet{{ else }}hayır{{ endif }}`:     "hayır",
+		`{{ if user.phone is defined }}evet{{ else }}hayır{{ endif }}`:  "evet",
+		`{{ if user.city is defined }}evet{{ else }}hayır{{ endif }}`:   "hayır",
+		`{{ if items is sameas items }}evet{{ else }}hayır{{ endif }}`:  "evet",
+	}
+	for tpl, want := range cases {
+		out, err := e.Render(tpl, ctx)
+		if err != nil {
+			t.Fatalf("Render error (%s): %v", tpl, err)
+		}
+		if out != want {
+			t.Errorf("%s: beklenen '%s', gerçek '%s'", tpl, want, out)
+		}
+	}
+}
+
+func TestRegisterTestAndWhitelist(t *testing.T) {
+	e := NewEngine()
+	e.RegisterTest("adult", func(val interface{}, args ...interface{}) bool {
+		return toFloat(val) >= 18
+	})
+	tpl := `{{ if user.age is adult }}Yetişkin{{ else }}Çocuk{{ endif }}`
+	ctx := map[string]interface{}{"user": map[string]interface{}{"age": 21}}
+	out, err := e.Render(tpl, ctx)
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if out != "Yetişkin" {
+		t.Errorf("Beklenen: 'Yetişkin', Gerçek: '%s'", out)
+	}
+
+	e.SetAllowedTests([]string{"even"})
+	out, err = e.Render(tpl, ctx)
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if out != "Çocuk" {
+		t.Errorf("Whitelist dışı test false dönmeliydi, gerçek: '%s'", out)
+	}
+}
+
+func TestIsRejectsTrailingTokens(t *testing.T) {
+	e := NewEngine()
+	e.RegisterTest("between", func(val interface{}, args ...interface{}) bool {
+		return len(args) == 2 && toFloat(val) >= toFloat(args[0]) && toFloat(val) <= toFloat(args[1])
+	})
+	for _, tpl := range []string{
+		`{{ if x is string and y is even }}a{{ endif }}`,
+		`{{ if x is even or y }}a{{ endif }}`,
+		`{{ if x is even }}a{{ elif x is odd 3 }}b{{ endif }}`,
+		`{{ if x is divisibleby 2 3 }}a{{ endif }}`,
+		`{{ for i in xs if i is between 1 and 3 }}{{ i }}{{ endfor }}`,
+	} {
+		if _, err := e.Render(tpl, map[string]interface{}{"x": "a", "y": 2}); err == nil {
+			t.Errorf("%s: fazladan token için hata bekleniyordu", tpl)
+		}
+	}
+	if _, err := e.EvalBool(`x is string and y is even`, nil); err == nil {
+		t.Error("EvalBool: fazladan token için hata bekleniyordu")
+	}
+	out, err := e.Render(`{{ for i in xs if i is between 2 3 }}{{ i }}{{ endfor }}`, map[string]interface{}{"xs": []interface{}{1, 2, 3, 4}})
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if out != "2\n3" {
+		t.Errorf("Kayıtlı test birden fazla argüman alabilmeli, gerçek: %q", out)
+	}
+}
@@ -1 +1,4 @@
 package hipoengine
+
+// SafeString, HTML escape uygulanmadan çıktıya yazılan string'dir (ör: macro ve recursive loop çıktıları).
+type SafeString string
@@ -3,7 +3,7 @@ package hipoengine
 
 import (
 	"fmt"
-	"regexp"
+	"reflect"
 	"strconv"
 	"strings"
 )
@@ -12,139 +12,134 @@ type FileBlocks struct {
 	Template string
 	Script   string
 	Style    string
+	Scripts  []*SFCBlock // attribute'larıyla birlikte tüm <script> blokları
+	Styles   []*SFCBlock // attribute'larıyla birlikte tüm <style> blokları
 }
 
-// HTML dosyasındaki ana blokları ayırır
+// HTML dosyasındaki ana blokları ayırır (ayrıntılı bilgi için ParseSFC kullanılabilir)
 func SplitBlocks(content string) FileBlocks {
-	reTemplate := regexp.MustCompile(`(?s)<template>(.*?)</template>`)
-	reScript := regexp.MustCompile(`(?s)<script>(.*?)</script>`)
-	reStyle := regexp.MustCompile(`(?s)<style>(.*?)</style>`)
+	desc, _ := ParseSFC(content)
 
 	var blocks FileBlocks
-
-	if match := reTemplate.FindStringSubmatch(content); len(match) > 1 {
-		blocks.Template = match[1]
-	}
-	if match := reScript.FindStringSubmatch(content); len(match) > 1 {
-		blocks.Script = match[1]
-	}
-	if match := reStyle.FindStringSubmatch(content); len(match) > 1 {
-		blocks.Style = match[1]
+	if desc.Template != nil {
+		blocks.Template = desc.Template.Content
 	}
+	blocks.Scripts = desc.Scripts
+	blocks.Styles = desc.Styles
+	blocks.Script = joinBlockContents(desc.Scripts)
+	blocks.Style = joinBlockContents(desc.Styles)
 	return blocks
 }
 
 // Sadece <template> içeriğini çıkarır (block/extends için)
 func extractTemplateBlock(content string) string {
-	re := regexp.MustCompile(`(?s)<template>(.*?)</template>`)
-	matches := re.FindStringSubmatch(content)
-	if len(matches) > 1 {
+	desc, _ := ParseSFC(content)
+	if desc.Template != nil {
 		// Hem baş hem son boşluk ve satır sonlarını sil!
-		return strings.Trim(matches[1], "\r\n\t ")
+		return strings.Trim(desc.Template.Content, "\r\n\t ")
 	}
 	return content
 }
 
-// Mantıksal karşılaştırmaları çalıştırır (if, elif vs)
-func evalBool(expr string, ctx *Context) bool {
-	expr = strings.TrimSpace(expr)
-	if strings.Contains(expr, ">=") {
-		parts := strings.Split(expr, ">=")
-		left := strings.TrimSpace(parts[0])
-		right := strings.TrimSpace(parts[1])
-		lval := ctx.Resolve(left)
-		rval := ctx.Resolve(right)
-		li, lok := lval.(int)
-		ri, rok := rval.(int)
-		if !lok {
-			li, _ = strconv.Atoi(fmt.Sprintf("%v", lval))
-		}
-		if !rok {
-			ri, _ = strconv.Atoi(fmt.Sprintf("%v", rval))
-		}
-		return li >= ri
-	}
-	if strings.Contains(expr, "<=") {
-		parts := strings.Split(expr, "<=")
-		left := strings.TrimSpace(parts[0])
-		right := strings.TrimSpace(parts[1])
-		lval := ctx.Resolve(left)
-		rval := ctx.Resolve(right)
-		li, lok := lval.(int)
-		ri, rok := rval.(int)
-		if !lok {
-			li, _ = strconv.Atoi(fmt.Sprintf("%v", lval))
-		}
-		if !rok {
-			ri, _ = strconv.Atoi(fmt.Sprintf("%v", rval))
-		}
-		return li <= ri
-	}
-	if strings.Contains(expr, ">") {
-		parts := strings.Split(expr, ">")
-		left := strings.TrimSpace(parts[0])
-		right := strings.TrimSpace(parts[1])
-		lval := ctx.Resolve(left)
-		rval := ctx.Resolve(right)
-		li, lok := lval.(int)
-		ri, rok := rval.(int)
-		if !lok {
-			li, _ = strconv.Atoi(fmt.Sprintf("%v", lval))
-		}
-		if !rok {
-			ri, _ = strconv.Atoi(fmt.Sprintf("%v", rval))
+// parseLiteral, tırnaklı string, sayı, true/false ve none/nil literal'lerini değere çevirir.
+func parseLiteral(s string) (interface{}, bool) {
+	if len(s) > 1 && ((s[0] == '"' && s[len(s)-1] == '"') || (s[0] == '\'' && s[len(s)-1] == '\'')) {
+		return s[1 : len(s)-1], true
+	}
+	if i, err := strconv.Atoi(s); err == nil {
+		return i, true
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f, true
+	}
+	switch s {
+	case "true", "True":
+		return true, true
+	case "false", "False":
+		return false, true
+	case "none", "None", "nil":
+		return nil, true
+	}
+	return nil, false
+}
+
+// indexOutsideQuotes, substr'ın tırnak dışındaki ilk geçtiği indexi döndürür.
+func indexOutsideQuotes(s, substr string) int {
+	quote := byte(0)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if quote != 0 {
+			if c == quote && s[i-1] != '\\' {
+				quote = 0
+			}
+			continue
 		}
-		return li > ri
-	}
-	if strings.Contains(expr, "<") {
-		parts := strings.Split(expr, "<")
-		left := strings.TrimSpace(parts[0])
-		right := strings.TrimSpace(parts[1])
-		lval := ctx.Resolve(left)
-		rval := ctx.Resolve(right)
-		li, lok := lval.(int)
-		ri, rok := rval.(int)
-		if !lok {
-			li, _ = strconv.Atoi(fmt.Sprintf("%v", lval))
+		if c == '"' || c == '\'' {
+			quote = c
+			continue
 		}
-		if !rok {
-			ri, _ = strconv.Atoi(fmt.Sprintf("%v", rval))
+		if strings.HasPrefix(s[i:], substr) {
+			return i
 		}
-		return li < ri
-	}
-	if strings.Contains(expr, "==") {
-		parts := strings.Split(expr, "==")
-		left := strings.TrimSpace(parts[0])
-		right := strings.TrimSpace(parts[1])
-		lval := ctx.Resolve(left)
-		rval := ctx.Resolve(right)
-		if rval == nil && strings.HasPrefix(right, "\"") && strings.HasSuffix(right, "\"") {
-			rval = strings.Trim(right, "\"")
+	}
+	return -1
+}
+
+// splitFields, string'i boşluklardan ayırır, tırnak içindeki boşlukları korur.
+func splitFields(s string) []string {
+	var fields []string
+	var buf strings.Builder
+	quote := byte(0)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if quote != 0 {
+			buf.WriteByte(c)
+			if c == quote && s[i-1] != '\\' {
+				quote = 0
+			}
+			continue
 		}
-		return fmt.Sprintf("%v", lval) == fmt.Sprintf("%v", rval)
-	}
-	if strings.Contains(expr, "!=") {
-		parts := strings.Split(expr, "!=")
-		left := strings.TrimSpace(parts[0])
-		right := strings.TrimSpace(parts[1])
-		lval := ctx.Resolve(left)
-		rval := ctx.Resolve(right)
-		if rval == nil && strings.HasPrefix(right, "\"") && strings.HasSuffix(right, "\"") {
-			rval = strings.Trim(right, "\"")
+		switch c {
+		case '"', '\'':
+			quote = c
+			buf.WriteByte(c)
+		case ' ', '\t', '\n', '\r':
+			if buf.Len() > 0 {
+				fields = append(fields, buf.String())
+				buf.Reset()
+			}
+		default:
+			buf.WriteByte(c)
 		}
-		return fmt.Sprintf("%v", lval) != fmt.Sprintf("%v", rval)
 	}
-	val := ctx.Resolve(expr)
-	if b, ok := val.(bool); ok {
-		return b
+	if buf.Len() > 0 {
+		fields = append(fields, buf.String())
 	}
-	if s, ok := val.(string); ok {
-		return s != "" && s != "0"
+	return fields
+}
+
+// evalOperand, karşılaştırma operandını çözer: literal ise değeri, değilse context'teki değişkeni döndürür.
+func evalOperand(expr string, ctx *Context) interface{} {
+	if val, ok := parseLiteral(expr); ok {
+		return val
 	}
-	if val != nil {
-		return true
+	return ctx.Resolve(expr)
+}
+
+// Equal, iki değeri switch/case kurallarıyla tipine göre karşılaştırır: sayılar sayısal olarak,
+// diğerleri aynı tipteyse doğrudan eşitlikle karşılaştırılır ("1" ile 1 eşit değildir).
+func Equal(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if isNumber(a) && isNumber(b) {
+		return toFloat(a) == toFloat(b)
+	}
+	ra, rb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if ra.Type() != rb.Type() || !ra.Type().Comparable() {
+		return false
 	}
-	return false
+	return a == b
 }
 
 // Her türlü map'i map[string]interface{}'ye çevirir
@@ -177,6 +172,15 @@ func toFloat(val interface{}) float64 {
 		f, _ := strconv.ParseFloat(v, 64)
 		return f
 	}
+	if val != nil {
+		rv := reflect.ValueOf(val)
+		switch rv.Kind() {
+		case reflect.Int8, reflect.Int16, reflect.Int32:
+			return float64(rv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return float64(rv.Uint())
+		}
+	}
 	return 0
 }
 
@@ -0,0 +1,427 @@
+// vm.go
+// AST'yi bytecode'a derleyen derleyici ve onu çalıştıran sanal makine
+package hipoengine
+
+import "strings"
+
+type opcode uint8
+
+const (
+	opText        opcode = iota // a: sabit metni yaz
+	opVar                       // a: önceden çözümlenmiş değişkeni yaz
+	opNode                      // a: node'u tree-walking ile çalıştır (for recursive, include, set...), b: içinde bulunduğu döngü (-1: yok)
+	opIfTruthy                  // a: operand; değer doğru değilse b'ye atla
+	opIfGe                      // a >= c değilse b'ye atla (a, c: operand)
+	opIfLe                      // a <= c değilse b'ye atla
+	opIfGt                      // a > c değilse b'ye atla
+	opIfLt                      // a < c değilse b'ye atla
+	opIfEq                      // a == c değilse b'ye atla
+	opIfNe                      // a != c değilse b'ye atla
+	opIfTest                    // a: is testi; test false ise b'ye atla
+	opJump                      // a: hedef adres
+	opPushScope                 // yeni child context aç (block)
+	opPopScope                  // child context'i kapat
+	opForBegin                  // a: döngü, b: koleksiyon boşsa atlanacak adres
+	opForNext                   // a: döngü, b: gövde başlangıcı (sonraki eleman varsa)
+	opForContinue               // a: döngü; elemanı continue ile kesilmiş işaretleyip opForNext'e atla
+	opForEnd                    // a: döngü; döngü çerçevesini kapat
+)
+
+// compareOps, karşılaştırma operatörlerinin opcode karşılıklarıdır.
+var compareOps = map[string]opcode{">=": opIfGe, "<=": opIfLe, ">": opIfGt, "<": opIfLt, "==": opIfEq, "!=": opIfNe}
+
+// instr, tek bir bytecode komutudur.
+type instr struct {
+	op      opcode
+	a, b, c int
+}
+
+// vmLoop, derleme anında belirlenen döngü bilgisidir.
+type vmLoop struct {
+	varName    string
+	collection []string // önceden bölünmüş koleksiyon path'i
+	rawColl    string
+	filter     *condition // for ... if koşulu (yoksa nil)
+	breakAddr  int        // opForEnd adresi
+	nextAddr   int        // opForNext adresi
+	breaks     []int      // derleme sırasında hedefi breakAddr'e bağlanacak atlamalar
+}
+
+// vmOperand, karşılaştırma operandıdır. Literal operandların sayı ve metin halleri derleme
+// anında hesaplanır.
+type vmOperand struct {
+	Operand
+	num int
+	str string
+}
+
+func (o *vmOperand) asInt(ctx *Context) int {
+	if o.Path == nil {
+		return o.num
+	}
+	return AsInt(ctx.resolveParts(o.Path))
+}
+
+func (o *vmOperand) asString(ctx *Context) string {
+	if o.Path == nil {
+		return o.str
+	}
+	return AsString(ctx.resolveParts(o.Path))
+}
+
+// vmVar, filtre argümanları derleme anında çözülmüş bir değişken ifadesidir.
+type vmVar struct {
+	node    *VariableNode
+	parts   []string        // değişken path'i (fonksiyon çağrısı veya literal değilse)
+	args    [][]interface{} // her filtre için parse edilmiş argümanlar
+	safe    bool            // |safe filtresi var mı
+	literal bool            // Value kullanılır (path çözülmez)
+}
+
+// Program, bir template'in bytecode'a derlenmiş halidir. ASTNode arayüzünü uyguladığı için
+// parse edilmiş AST yerine kullanılabilir; çıktısı Execute ile birebir aynıdır.
+type Program struct {
+	code     []instr
+	texts    []string
+	operands []*vmOperand
+	tests    []*condition
+	vars     []*vmVar
+	nodes    []ASTNode
+	loops    []*vmLoop
+	label    int // son atlama hedefi; bu adrese yazılan metin öncekiyle birleştirilmez
+}
+
+// CompileProgram, parse edilmiş bir template'i bytecode programına derler. Literal değişkenler
+// metne katlanır, değişken path'leri, filtre argümanları ve koşullar derleme anında çözülür;
+// iki tarafı literal olan karşılaştırmalar sabit olarak değerlendirilir. Metin, if, block ve
+// recursive olmayan for yapıları doğrudan komutlara dönüşür; diğer node'lar VM içinden
+// çalıştırılır.
+func CompileProgram(node ASTNode) *Program {
+	p := &Program{label: -1}
+	p.compile(node, -1)
+	return p
+}
+
+func (p *Program) emit(op opcode, a, b, c int) int {
+	p.code = append(p.code, instr{op: op, a: a, b: b, c: c})
+	return len(p.code) - 1
+}
+
+// here, bir sonraki komutun adresini atlama hedefi olarak işaretleyip döndürür.
+func (p *Program) here() int {
+	p.label = len(p.code)
+	return p.label
+}
+
+func (p *Program) text(s string) {
+	// Ardışık metinler, ikincisi bir atlama hedefi değilse tek komutta birleştirilir
+	if n := len(p.code); n > 0 && p.code[n-1].op == opText && p.label != n {
+		p.texts[p.code[n-1].a] += s
+		return
+	}
+	p.texts = append(p.texts, s)
+	p.emit(opText, len(p.texts)-1, 0, 0)
+}
+
+// compile, node'u komutlara çevirir. loop, içinde bulunulan (derlenmiş) döngünün indexidir (-1: yok).
+func (p *Program) compile(node ASTNode, loop int) {
+	switch n := node.(type) {
+	case *ListNode:
+		for _, child := range n.Nodes {
+			p.compile(child, loop)
+		}
+	case *TextNode:
+		p.text(n.Text)
+	case *VariableNode:
+		if n.Name == "" && n.Value != nil && len(n.Filters) == 0 {
+			if s, ok := n.Value.(string); !ok || !strings.Contains(s, "(") {
+				// Sabit literal: derleme anında render edilir
+				out, _ := n.Execute(nil)
+				p.text(out)
+				return
+			}
+		}
+		p.vars = append(p.vars, newVMVar(n))
+		p.emit(opVar, len(p.vars)-1, 0, 0)
+	case *IfNode:
+		var ends []int
+		taken := false
+		for _, branch := range n.Branches {
+			c := compileCondition(branch.Condition)
+			if res, ok := c.constant(); ok {
+				if !res {
+					continue
+				}
+				// Her zaman doğru olan dal: sonraki dallar ve else hiç çalışmaz
+				p.compile(branch.Body, loop)
+				taken = true
+				break
+			}
+			jf := p.jumpUnless(c)
+			p.compile(branch.Body, loop)
+			ends = append(ends, p.emit(opJump, 0, 0, 0))
+			p.code[jf].b = p.here()
+		}
+		if !taken && n.ElseBody != nil {
+			p.compile(n.ElseBody, loop)
+		}
+		end := p.here()
+		for _, j := range ends {
+			p.code[j].a = end
+		}
+	case *BlockNode:
+		if loop >= 0 {
+			// Döngü içindeki block'larda break/continue scope'u kapatmadan atlayacağı için tree-walking kullanılır
+			p.node(n, loop)
+			return
+		}
+		p.emit(opPushScope, 0, 0, 0)
+		p.compile(n.Body, loop)
+		p.emit(opPopScope, 0, 0, 0)
+	case *ForNode:
+		if n.Recursive {
+			p.node(n, loop)
+			return
+		}
+		l := &vmLoop{varName: n.VarName, collection: splitPathWithBrackets(n.Collection), rawColl: n.Collection}
+		if n.Filter != "" {
+			l.filter = compileCondition(n.Filter)
+		}
+		p.loops = append(p.loops, l)
+		id := len(p.loops) - 1
+		begin := p.emit(opForBegin, id, 0, 0)
+		body := p.here()
+		p.compile(n.Body, id)
+		l.nextAddr = p.emit(opForNext, id, body, 0)
+		l.breakAddr = p.emit(opForEnd, id, 0, 0)
+		p.code[begin].b = p.here()
+		for _, j := range l.breaks {
+			p.code[j].a = l.breakAddr
+		}
+	case *BreakNode:
+		if loop >= 0 {
+			l := p.loops[loop]
+			l.breaks = append(l.breaks, p.emit(opJump, 0, 0, 0))
+			return
+		}
+		p.node(n, loop)
+	case *ContinueNode:
+		if loop >= 0 {
+			p.emit(opForContinue, loop, 0, 0)
+			return
+		}
+		p.node(n, loop)
+	default:
+		p.node(n, loop)
+	}
+}
+
+func (p *Program) node(n ASTNode, loop int) {
+	p.nodes = append(p.nodes, n)
+	p.emit(opNode, len(p.nodes)-1, loop, 0)
+}
+
+// jumpUnless, koşul false olduğunda atlayan komutu üretir ve adresini döndürür (hedef sonradan
+// yazılır).
+func (p *Program) jumpUnless(c *condition) int {
+	switch c.op {
+	case "":
+		return p.emit(opIfTruthy, p.operand(c.left), 0, 0)
+	case "is":
+		p.tests = append(p.tests, c)
+		return p.emit(opIfTest, len(p.tests)-1, 0, 0)
+	}
+	return p.emit(compareOps[c.op], p.operand(c.left), 0, p.operand(c.right))
+}
+
+func (p *Program) operand(o Operand) int {
+	op := &vmOperand{Operand: o}
+	if o.Path == nil {
+		op.num = AsInt(o.Value)
+		op.str = AsString(o.Value)
+	}
+	p.operands = append(p.operands, op)
+	return len(p.operands) - 1
+}
+
+func newVMVar(n *VariableNode) *vmVar {
+	v := &vmVar{node: n, safe: n.safe()}
+	for _, f := range n.Filters {
+		v.args = append(v.args, parseFilterArgs(f.Args))
+	}
+	switch {
+	case n.Name != "" && strings.Contains(n.Name, "(") && strings.HasSuffix(n.Name, ")"):
+		// Fonksiyon çağrısı: VariableNode üzerinden çalıştırılır
+	case n.Value != nil:
+		v.literal = true
+	case n.Name != "":
+		v.parts = splitPathWithBrackets(n.Name)
+	}
+	return v
+}
+
+// frame, çalışan bir döngünün durumudur.
+type frame struct {
+	items  []interface{}
+	i      int
+	parent *Context
+	mark   int  // mevcut elemanın çıktısının başladığı offset
+	cont   bool // mevcut eleman continue ile kesildi
+}
+
+// Run, programı verilen context ile çalıştırır.
+func (p *Program) Run(ctx *Context) (string, error) {
+	var sb strings.Builder
+	var scopes []*Context
+	frames := make([]*frame, len(p.loops))
+	pc := 0
+	for pc < len(p.code) {
+		in := p.code[pc]
+		pc++
+		switch in.op {
+		case opText:
+			sb.WriteString(p.texts[in.a])
+		case opVar:
+			out, err := p.vars[in.a].exec(ctx)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(out)
+		case opNode:
+			out, err := p.nodes[in.a].Execute(ctx)
+			if err != nil {
+				lc, ok := err.(*loopControl)
+				if !ok || in.b < 0 {
+					return "", err
+				}
+				// Tree-walking node içinden gelen break/continue en yakın döngüye iletilir
+				sb.WriteString(out)
+				if lc == errBreak {
+					pc = p.loops[in.b].breakAddr
+				} else {
+					frames[in.b].cont = true
+					pc = p.loops[in.b].nextAddr
+				}
+				continue
+			}
+			sb.WriteString(out)
+		case opIfTruthy:
+			if !Truthy(ctx.resolveParts(p.operands[in.a].Path)) {
+				pc = in.b
+			}
+		case opIfGe:
+			if !(p.operands[in.a].asInt(ctx) >= p.operands[in.c].asInt(ctx)) {
+				pc = in.b
+			}
+		case opIfLe:
+			if !(p.operands[in.a].asInt(ctx) <= p.operands[in.c].asInt(ctx)) {
+				pc = in.b
+			}
+		case opIfGt:
+			if !(p.operands[in.a].asInt(ctx) > p.operands[in.c].asInt(ctx)) {
+				pc = in.b
+			}
+		case opIfLt:
+			if !(p.operands[in.a].asInt(ctx) < p.operands[in.c].asInt(ctx)) {
+				pc = in.b
+			}
+		case opIfEq:
+			if p.operands[in.a].asString(ctx) != p.operands[in.c].asString(ctx) {
+				pc = in.b
+			}
+		case opIfNe:
+			if p.operands[in.a].asString(ctx) == p.operands[in.c].asString(ctx) {
+				pc = in.b
+			}
+		case opIfTest:
+			c := p.tests[in.a]
+			if !ctx.Test(c.test, c.negate, c.left, c.args...) {
+				pc = in.b
+			}
+		case opJump:
+			pc = in.a
+		case opPushScope:
+			scopes = append(scopes, ctx)
+			ctx = ctx.NewChild(map[string]interface{}{})
+		case opPopScope:
+			ctx = scopes[len(scopes)-1]
+			scopes = scopes[:len(scopes)-1]
+		case opForBegin:
+			l := p.loops[in.a]
+			items, err := ctx.LoopItems(l.rawColl, l.collection...)
+			if err != nil {
+				return "", err
+			}
+			if l.filter != nil {
+				kept := items[:0]
+				for _, item := range items {
+					if l.filter.eval(ctx.NewChild(map[string]interface{}{l.varName: item})) {
+						kept = append(kept, item)
+					}
+				}
+				items = kept
+			}
+			if len(items) == 0 {
+				pc = in.b
+				continue
+			}
+			f := &frame{items: items, parent: ctx, mark: sb.Len()}
+			frames[in.a] = f
+			ctx = f.enter(l)
+		case opForContinue:
+			frames[in.a].cont = true
+			pc = p.loops[in.a].nextAddr
+		case opForNext:
+			f := frames[in.a]
+			if f.i < len(f.items)-1 {
+				// continue ile çıktı üretmeden kesilen eleman satır sonu eklemez
+				if !f.cont || sb.Len() > f.mark {
+					sb.WriteString("\n")
+				}
+				f.i++
+				f.cont = false
+				f.mark = sb.Len()
+				ctx = f.enter(p.loops[in.a])
+				pc = in.b
+			}
+		case opForEnd:
+			ctx = frames[in.a].parent
+			frames[in.a] = nil
+		}
+	}
+	return sb.String(), nil
+}
+
+// enter, döngünün mevcut elemanı için child context oluşturur.
+func (f *frame) enter(l *vmLoop) *Context {
+	return f.parent.NewChild(map[string]interface{}{l.varName: f.items[f.i], "loop": LoopInfo(f.i, len(f.items))})
+}
+
+// exec, VariableNode.Execute ile aynı çıktıyı önceden çözümlenmiş path ve argümanlarla üretir.
+func (v *vmVar) exec(ctx *Context) (string, error) {
+	if v.parts == nil && !v.literal {
+		return v.node.Execute(ctx)
+	}
+	var val interface{}
+	if v.literal {
+		val = v.node.Value
+		if s, ok := val.(string); ok && strings.Contains(s, "(") {
+			val = ctx.Resolve(s)
+		}
+	} else {
+		val = ctx.resolveParts(v.parts)
+	}
+	for i, filter := range v.node.Filters {
+		val = ctx.ApplyFilter(filter.Name, val, v.args[i]...)
+	}
+	return FormatValue(val, v.safe), nil
+}
+
+// Execute, programı çalıştırır (ASTNode arayüzü).
+func (p *Program) Execute(ctx *Context) (string, error) {
+	return p.Run(ctx)
+}
+
+func (p *Program) ExecuteRaw(ctx *Context) (interface{}, error) {
+	return p.Run(ctx)
+}
@@ -0,0 +1,208 @@
+package hipoengine
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var vmTemplates = []string{
+	`Merhaba {{ user.name|upper }} {{ "<b>" }} {{ 42 }} {{ html|safe }}`,
+	`{{ if user.age >= 18 }}Yetişkin{{ elif user.age > 12 }}Genç{{ else }}Çocuk{{ endif }}!`,
+	`<ul>{{ for item in items }}<li>{{ loop.index }}/{{ loop.length }} {{ item.name }}{{ if loop.last }} son{{ endif }}</li>{{ endfor }}</ul>`,
+	`{{ for item in items }}{{ if item.active }}{{ continue }}{{ endif }}{{ item.name }}{{ endfor }}`,
+	`{{ for item in items }}{{ item.name }}{{ if loop.index == 2 }}{{ break }}{{ endif }}-{{ endfor }}son`,
+	`{{ for item in items if item.active }}{{ for t in item.tags }}{{ t }}{{ if t == "b" }}{{ break }}{{ endif }}{{ endfor }};{{ endfor }}`,
+	`{{ for item in items }}{{ with item.name as n }}{{ n }}{{ break }}{{ endwith }}{{ endfor }}`,
+	`{{ for item in empty }}x{{ endfor }}boş`,
+	`{{ block title }}{{ set x = "blok" }}{{ x }}{{ endblock }}{{ x }}`,
+	`{{ switch user.role }}{{ case "admin" }}Yönetici{{ default }}Ziyaretçi{{ endswitch }}`,
+	`{{ for node in tree recursive }}{{ node.name }}{{ loop(node.children) }}{{ endfor }}`,
+	`{{ macro b(t) }}<b>{{ t }}</b>{{ endmacro }}{{ for item in items }}{{ b(item.name) }}{{ endfor }}`,
+	`{{ if 1 == 1 }}a{{ else }}b{{ endif }}{{ if 2 < 1 }}c{{ elif user.name == "emre" }}d{{ endif }}{{ if user.name is defined }}e{{ endif }}{{ if user.nick != "x" }}f{{ endif }}{{ if user.age <= 15 }}g{{ endif }}`,
+}
+
+func vmData() map[string]interface{} {
+	return map[string]interface{}{
+		"user": map[string]interface{}{"name": "emre", "age": 15, "role": "admin"},
+		"html": "<i>ok</i>",
+		"items": []interface{}{
+			map[string]interface{}{"name": "A", "active": true, "tags": []interface{}{"a", "b", "c"}},
+			map[string]interface{}{"name": "B", "active": false, "tags": []interface{}{}},
+			map[string]interface{}{"name": "C", "active": true, "tags": []interface{}{"b", "c"}},
+		},
+		"empty": []interface{}{},
+		"tree": []interface{}{
+			map[string]interface{}{"name": "kök", "children": []interface{}{
+				map[string]interface{}{"name": "yaprak", "children": ""},
+			}},
+		},
+	}
+}
+
+func TestProgramMatchesExecute(t *testing.T) {
+	e := NewEngine()
+	for _, tpl := range vmTemplates {
+		ast, err := e.newParser(tpl, "").Parse()
+		if err != nil {
+			t.Fatalf("Parse error (%s): %v", tpl, err)
+		}
+		want, err := ast.Execute(NewContext(vmData(), e.funcs, e.filters, e))
+		if err != nil {
+			t.Fatalf("Execute error (%s): %v", tpl, err)
+		}
+		got, err := CompileProgram(ast).Run(NewContext(vmData(), e.funcs, e.filters, e))
+		if err != nil {
+			t.Fatalf("Run error (%s): %v", tpl, err)
+		}
+		if got != want {
+			t.Errorf("%s\nExecute: %q\nVM:      %q", tpl, want, got)
+		}
+	}
+}
+
+func TestProgramCompilesConditions(t *testing.T) {
+	e := NewEngine()
+	ast, err := e.newParser(`{{ if user.age >= 18 }}A{{ endif }}{{ if 1 == 1 }}B{{ else }}C{{ endif }}{{ if 1 == 2 }}D{{ endif }}{{ if x is defined }}E{{ endif }}`, "").Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	prog := CompileProgram(ast)
+	counts := map[opcode]int{}
+	for _, in := range prog.code {
+		counts[in.op]++
+	}
+	if counts[opIfGe] != 1 || counts[opIfTest] != 1 {
+		t.Errorf("Koşullar opcode'a derlenmedi: %v", prog.code)
+	}
+	if counts[opIfEq] != 0 {
+		t.Errorf("Sabit koşullar derleme anında değerlendirilmeli: %v", prog.code)
+	}
+	got, err := prog.Run(NewContext(map[string]interface{}{"user": map[string]interface{}{"age": 20}, "x": nil}, e.funcs, e.filters, e))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "ABE" {
+		t.Errorf("Beklenen 'ABE', gelen: %q", got)
+	}
+}
+
+func TestBytecodeProgramCache(t *testing.T) {
+	e := NewEngine()
+	e.SetBytecodeMode(true)
+	tpl := `{{ for i in items }}{{ i }}{{ endfor }}`
+	for _, want := range []string{"1\n2", "3"} {
+		items := []interface{}{3}
+		if want != "3" {
+			items = []interface{}{1, 2}
+		}
+		got, err := e.Render(tpl, map[string]interface{}{"items": items})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("Beklenen %q, gelen %q", want, got)
+		}
+	}
+	if len(e.programs) != 0 {
+		t.Errorf("Satır içi template'ler cache'lenmemeli, cache: %d", len(e.programs))
+	}
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "page.hipo")
+	os.WriteFile(file, []byte("<template>eski {{ x }}</template>"), 0644)
+	e.AddTemplatePath(dir)
+	if got, _ := e.RenderFile("page.hipo", map[string]interface{}{"x": 1}); got != "eski 1" {
+		t.Errorf("Beklenen 'eski 1', gelen %q", got)
+	}
+	os.WriteFile(file, []byte("<template>yeni {{ x }}</template>"), 0644)
+	later := time.Now().Add(time.Second)
+	os.Chtimes(file, later, later)
+	if got, _ := e.RenderFile("page.hipo", map[string]interface{}{"x": 2}); got != "yeni 2" {
+		t.Errorf("Değişen dosya yeniden derlenmeli, gelen %q", got)
+	}
+	if len(e.programs) != 1 {
+		t.Errorf("Dosya başına tek program tutulmalı, cache: %d", len(e.programs))
+	}
+	prog := e.programs["page.hipo"].prog
+	e.RenderFile("page.hipo", map[string]interface{}{"x": 3})
+	if e.programs["page.hipo"].prog != prog {
+		t.Errorf("Değişmeyen dosya yeniden derlenmemeli")
+	}
+	e.ClearCache()
+	if len(e.programs) != 0 {
+		t.Errorf("ClearCache program cache'ini temizlemeli")
+	}
+}
+
+func TestBytecodeModeRenderFile(t *testing.T) {
+	e := NewEngine()
+	e.AddTemplatePath("testdata/compile")
+	data := map[string]interface{}{"title": "vm", "user": map[string]interface{}{"name": "Emre", "age": 30, "role": "editor"}, "items": vmData()["items"]}
+	want, err := e.RenderFile("page.hipo", data)
+	if err != nil {
+		t.Fatalf("RenderFile error: %v", err)
+	}
+	e.SetBytecodeMode(true)
+	got, err := e.RenderFile("page.hipo", data)
+	if err != nil {
+		t.Fatalf("RenderFile (bytecode) error: %v", err)
+	}
+	if got != want {
+		t.Errorf("Bytecode çıktısı farklı.\nExecute:\n%s\nVM:\n%s", want, got)
+	}
+}
+
+const benchTemplate = `<h1>{{ title|upper }}</h1>
+<ul>{{ for item in items }}<li class="{{ if loop.first }}first{{ endif }}">{{ loop.index }}. {{ item.name|default:"-" }} {{ "sabit" }}</li>{{ endfor }}</ul>
+{{ if user.age >= 18 }}Yetişkin{{ else }}Çocuk{{ endif }}`
+
+func benchSetup(b *testing.B) (*Engine, ASTNode, map[string]interface{}) {
+	e := NewEngine()
+	ast, err := e.newParser(benchTemplate, "").Parse()
+	if err != nil {
+		b.Fatal(err)
+	}
+	items := make([]interface{}, 50)
+	for i := range items {
+		items[i] = map[string]interface{}{"name": "Ürün"}
+	}
+	return e, ast, map[string]interface{}{"title": "liste", "items": items, "user": map[string]interface{}{"age": 20}}
+}
+
+func BenchmarkExecute(b *testing.B) {
+	e, ast, data := benchSetup(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ast.Execute(NewContext(data, e.funcs, e.filters, e)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkProgramRun(b *testing.B) {
+	e, ast, data := benchSetup(b)
+	prog := CompileProgram(ast)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := prog.Run(NewContext(data, e.funcs, e.filters, e)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchRender(b *testing.B, bytecode bool) {
+	e, _, data := benchSetup(b)
+	e.SetBytecodeMode(bytecode)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := e.Render(benchTemplate, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRender(b *testing.B) { benchRender(b, false) }
+
+func BenchmarkRenderBytecode(b *testing.B) { benchRender(b, true) }
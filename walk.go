@@ -0,0 +1,331 @@
+// walk.go
+// AST gezinme (Walk/Visitor) ve template'in kullandığı isimleri çıkaran yardımcılar
+package hipoengine
+
+import (
+	"sort"
+	"strings"
+)
+
+// Visitor, Walk tarafından gezilen her node için Visit'i çağırır. Visit'in döndürdüğü w nil
+// değilse node'un çocukları w ile gezilir, ardından w.Visit(nil) çağrılır.
+type Visitor interface {
+	Visit(node ASTNode) (w Visitor)
+}
+
+// NodeContainer, RegisterTag ile eklenen özel node'ların alt node'larını Walk'a açmak için
+// implement edebileceği arayüzdür.
+type NodeContainer interface {
+	Children() []ASTNode
+}
+
+// Walk, node'dan başlayarak AST'yi derinlik öncelikli gezer.
+func Walk(v Visitor, node ASTNode) {
+	if node == nil {
+		return
+	}
+	if v = v.Visit(node); v == nil {
+		return
+	}
+	for _, child := range Children(node) {
+		Walk(v, child)
+	}
+	v.Visit(nil)
+}
+
+type inspector func(ASTNode) bool
+
+func (f inspector) Visit(node ASTNode) Visitor {
+	if f(node) {
+		return f
+	}
+	return nil
+}
+
+// Inspect, AST'yi gezer ve her node için f'i çağırır; f false dönerse node'un çocukları atlanır.
+// Çocuklar gezildikten sonra f(nil) çağrılır.
+func Inspect(node ASTNode, f func(ASTNode) bool) {
+	Walk(inspector(f), node)
+}
+
+// Children, node'un doğrudan alt node'larını kaynak sırasıyla döndürür. Extends blokları ve
+// component slot'ları isme göre sıralanır.
+func Children(node ASTNode) []ASTNode {
+	var out []ASTNode
+	add := func(nodes ...ASTNode) {
+		for _, n := range nodes {
+			if n != nil {
+				out = append(out, n)
+			}
+		}
+	}
+	switch n := node.(type) {
+	case *ListNode:
+		add(n.Nodes...)
+	case *IfNode:
+		for _, b := range n.Branches {
+			add(b.Body)
+		}
+		add(n.ElseBody)
+	case *ForNode:
+		add(n.Body)
+	case *SwitchNode:
+		for _, c := range n.Cases {
+			add(c.Body)
+		}
+		add(n.Default)
+	case *WithNode:
+		add(n.Body)
+	case *BlockNode:
+		add(n.Body)
+	case *ExtendsNode:
+		for _, name := range sortedKeys(n.Blocks) {
+			add(n.Blocks[name])
+		}
+	case *SetNode:
+		add(n.Value)
+	case *MacroNode:
+		add(n.Body)
+	case *ComponentNode:
+		for _, name := range sortedKeys(n.Slots) {
+			add(n.Slots[name])
+		}
+	case *SlotNode:
+		add(n.Fallback)
+	case NodeContainer:
+		add(n.Children()...)
+	}
+	return out
+}
+
+func sortedKeys(m map[string]ASTNode) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// TemplateInfo, bir template'in dışarıdan beklediği ve referans verdiği isimlerdir. Tüm listeler
+// tekrarsız ve alfabetik sıralıdır.
+type TemplateInfo struct {
+	Variables  []string // context'ten okunan değişken yolları (ör: user.name); for/with/set/macro ile tanımlananlar hariç
+	Functions  []string // çağrılan fonksiyonlar (template içindeki macro'lar hariç)
+	Filters    []string
+	Tests      []string // "is" testleri
+	Includes   []string
+	Components []string
+	Extends    []string
+	Blocks     []string // tanımlanan veya extends ile override edilen bloklar
+	Macros     []string
+}
+
+// Analyze, AST'yi gezerek template'in kullandığı değişken, fonksiyon, filtre, include, extends ve
+// blokları toplar.
+func Analyze(node ASTNode) *TemplateInfo {
+	a := &analyzer{sets: map[string]map[string]bool{}}
+	Walk(&analyzeScope{a: a, names: map[string]bool{}}, node)
+	info := &TemplateInfo{}
+	for field, dst := range map[string]*[]string{
+		"var": &info.Variables, "func": &info.Functions, "filter": &info.Filters, "test": &info.Tests,
+		"include": &info.Includes, "component": &info.Components, "extends": &info.Extends,
+		"block": &info.Blocks, "macro": &info.Macros,
+	} {
+		for name := range a.sets[field] {
+			*dst = append(*dst, name)
+		}
+		sort.Strings(*dst)
+	}
+	return info
+}
+
+// Bu yardımcılar Analyze sonucunun tek bir alanını döndürür.
+
+func Variables(node ASTNode) []string { return Analyze(node).Variables }
+func Functions(node ASTNode) []string { return Analyze(node).Functions }
+func Filters(node ASTNode) []string   { return Analyze(node).Filters }
+func Includes(node ASTNode) []string  { return Analyze(node).Includes }
+func Extends(node ASTNode) []string   { return Analyze(node).Extends }
+func Blocks(node ASTNode) []string    { return Analyze(node).Blocks }
+
+type analyzer struct {
+	sets map[string]map[string]bool
+}
+
+func (a *analyzer) add(kind, name string) {
+	if name == "" {
+		return
+	}
+	if a.sets[kind] == nil {
+		a.sets[kind] = map[string]bool{}
+	}
+	a.sets[kind][name] = true
+}
+
+// analyzeScope, template içinde tanımlanan isimleri (for değişkeni, with alias, set, macro) tutar.
+// Walk her scope açan node için yeni bir analyzeScope ile devam eder.
+type analyzeScope struct {
+	a      *analyzer
+	names  map[string]bool
+	parent *analyzeScope
+}
+
+func (s *analyzeScope) child(names ...string) *analyzeScope {
+	c := &analyzeScope{a: s.a, names: map[string]bool{}, parent: s}
+	for _, n := range names {
+		c.names[n] = true
+	}
+	return c
+}
+
+func (s *analyzeScope) defined(name string) bool {
+	for cur := s; cur != nil; cur = cur.parent {
+		if cur.names[name] {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *analyzeScope) Visit(node ASTNode) Visitor {
+	switch n := node.(type) {
+	case *VariableNode:
+		if n.Name != "" {
+			s.expr(n.Name)
+		} else if str, ok := n.Value.(string); ok && strings.Contains(str, "(") {
+			s.expr(str)
+		}
+		for _, f := range n.Filters {
+			s.a.add("filter", f.Name)
+		}
+	case *IfNode:
+		for _, b := range n.Branches {
+			s.cond(b.Condition)
+		}
+	case *ForNode:
+		s.expr(n.Collection)
+		names := []string{n.VarName, "loop"}
+		c := s.child(names...)
+		if n.Filter != "" {
+			c.cond(n.Filter)
+		}
+		return c
+	case *SwitchNode:
+		s.expr(n.Subject)
+		for _, c := range n.Cases {
+			for _, v := range c.Values {
+				s.expr(v)
+			}
+		}
+	case *WithNode:
+		s.expr(n.Expr)
+		return s.child(n.Alias)
+	case *SetNode:
+		// Değer, değişken tanımlanmadan önce gezilir: {{ set x = x|upper }}
+		Walk(s, n.Value)
+		s.names[n.VarName] = true
+		return nil
+	case *BlockNode:
+		s.a.add("block", n.Name)
+		return s.child()
+	case *ExtendsNode:
+		s.a.add("extends", n.BaseFile)
+		for name := range n.Blocks {
+			s.a.add("block", name)
+		}
+	case *IncludeNode:
+		s.a.add("include", n.File)
+	case *ComponentNode:
+		s.a.add("component", n.File)
+		for _, p := range n.Props {
+			s.expr(p.Expr)
+		}
+	case *MacroNode:
+		s.a.add("macro", n.Name)
+		s.names[n.Name] = true
+		params := []string{n.Name}
+		for _, p := range n.Params {
+			if p.Default != "" {
+				s.expr(p.Default)
+			}
+			params = append(params, p.Name)
+		}
+		return s.child(params...)
+	}
+	return s
+}
+
+// cond, if/for koşulundaki "is" testini ve karşılaştırma operandlarını işler.
+func (s *analyzeScope) cond(expr string) {
+	if left, name, args, _, ok := splitIsExpr(expr); ok {
+		s.a.add("test", name)
+		s.expr(left)
+		for _, arg := range args {
+			s.expr(arg)
+		}
+		return
+	}
+	s.expr(expr)
+}
+
+// exprKeywords, ifadelerde değişken sayılmayan kelimelerdir.
+var exprKeywords = map[string]bool{
+	"true": true, "false": true, "none": true, "nil": true, "True": true, "False": true, "None": true,
+	"and": true, "or": true, "not": true, "in": true, "is": true, "ctx": true,
+}
+
+// expr, ifadedeki değişken yollarını ve fonksiyon çağrılarını toplar. Tırnak içleri atlanır;
+// "a.b[0].c" için "a.b" kaydedilir.
+func (s *analyzeScope) expr(expr string) {
+	i := 0
+	for i < len(expr) {
+		c := expr[i]
+		switch {
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(expr[i+1:], c)
+			if end == -1 {
+				return
+			}
+			i += end + 2
+		case isIdentStart(c) && (i == 0 || !isIdentChar(expr[i-1]) && expr[i-1] != '.' && expr[i-1] != ']'):
+			j := i
+			for j < len(expr) && (isIdentChar(expr[j]) || (expr[j] == '.' && j+1 < len(expr) && isIdentStart(expr[j+1]))) {
+				j++
+			}
+			path := expr[i:j]
+			k := j
+			for k < len(expr) && expr[k] == ' ' {
+				k++
+			}
+			root := path
+			if dot := strings.IndexByte(path, '.'); dot != -1 {
+				root = path[:dot]
+			}
+			switch {
+			case k < len(expr) && expr[k] == '(' && !strings.Contains(path, "."):
+				if !s.defined(path) {
+					s.a.add("func", path)
+				}
+			case exprKeywords[path]:
+			case !s.defined(root):
+				s.a.add("var", path)
+			}
+			i = j
+		case c >= '0' && c <= '9':
+			for i < len(expr) && (isIdentChar(expr[i]) || expr[i] == '.') {
+				i++
+			}
+		default:
+			i++
+		}
+	}
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,117 @@
+package hipoengine
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	tpl := `{{ props title: string }}
+<h1>{{ title|upper }}</h1>
+{{ set greeting = trans("home.hello", user.name) }}{{ greeting }}
+{{ macro badge(text, kind="info") }}<b>{{ text|escape }}</b>{{ endmacro }}
+{{ if user.age >= limits.adult }}{{ badge("yetişkin") }}{{ elif user is divisibleby(3) }}x{{ endif }}
+{{ for item in cart.items if item.price > 0 }}{{ loop.index }} {{ item.name|default:"-" }}{{ with item.meta as m }}{{ m.sku }}{{ endwith }}{{ endfor }}
+{{ switch user.role }}{{ case "admin" }}{{ include "partials/admin.hipo" }}{{ default }}{{ endswitch }}
+{{ component "ui/card.hipo" heading=page.heading }}{{ slot footer }}{{ footer_text }}{{ endslot }}{{ endcomponent }}
+{{ block sidebar }}{{ GetX().name }}{{ endblock }}`
+	ast, err := NewEngine().newParser(tpl, "").Parse()
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	info := Analyze(ast)
+	want := &TemplateInfo{
+		Variables:  []string{"cart.items", "footer_text", "limits.adult", "page.heading", "title", "user", "user.age", "user.name", "user.role"},
+		Functions:  []string{"GetX", "trans"},
+		Filters:    []string{"default", "escape", "upper"},
+		Tests:      []string{"divisibleby"},
+		Includes:   []string{"partials/admin.hipo"},
+		Components: []string{"ui/card.hipo"},
+		Blocks:     []string{"sidebar"},
+		Macros:     []string{"badge"},
+	}
+	if !reflect.DeepEqual(info, want) {
+		t.Errorf("Analyze sonucu beklenenden farklı.\nGot:  %+v\nWant: %+v", info, want)
+	}
+}
+
+func TestAnalyzeExtendsFile(t *testing.T) {
+	e := NewEngine()
+	ast, err := e.ParseFile("testdata/views/child.hipo")
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	if got := Extends(ast); len(got) != 0 {
+		t.Errorf("child.hipo extends içermiyor, got %v", got)
+	}
+	if got, want := Blocks(ast), []string{"content", "title"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Blocks: got %v, want %v", got, want)
+	}
+	if got, want := Includes(ast), []string{"testdata/views/include_example.hipo"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Includes: got %v, want %v", got, want)
+	}
+
+	ast, err = e.newParser(`{{ extends "layouts/base.hipo" }}{{ block title }}{{ page.title }}{{ endblock }}`, "").Parse()
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	info := Analyze(ast)
+	if !reflect.DeepEqual(info.Extends, []string{"layouts/base.hipo"}) || !reflect.DeepEqual(info.Blocks, []string{"title"}) || !reflect.DeepEqual(info.Variables, []string{"page.title"}) {
+		t.Errorf("Extends analizi hatalı: %+v", info)
+	}
+}
+
+type countingVisitor struct {
+	counts map[string]int
+}
+
+func (v *countingVisitor) Visit(node ASTNode) Visitor {
+	if node != nil {
+		v.counts[reflect.TypeOf(node).Elem().Name()]++
+	}
+	return v
+}
+
+func TestWalkVisitsAllNodes(t *testing.T) {
+	tpl := `{{ for x in xs }}{{ if x }}{{ x }}{{ else }}-{{ endif }}{{ endfor }}{{ with a as b }}{{ b }}{{ endwith }}`
+	ast, err := NewEngine().newParser(tpl, "").Parse()
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	v := &countingVisitor{counts: map[string]int{}}
+	Walk(v, ast)
+	if v.counts["ForNode"] != 1 || v.counts["IfNode"] != 1 || v.counts["WithNode"] != 1 || v.counts["VariableNode"] != 2 || v.counts["TextNode"] != 1 {
+		t.Errorf("beklenmeyen node sayıları: %v", v.counts)
+	}
+
+	// Inspect false dönünce alt node'lar atlanır
+	seen := 0
+	Inspect(ast, func(n ASTNode) bool {
+		if n != nil {
+			seen++
+		}
+		_, isFor := n.(*ForNode)
+		return !isFor
+	})
+	if seen != 5 { // ListNode, ForNode, WithNode, ListNode, VariableNode
+		t.Errorf("Inspect %d node gezdi, 5 bekleniyordu", seen)
+	}
+}
+
+func TestStandaloneBlock(t *testing.T) {
+	e := NewEngine()
+	ast, err := e.newParser(`{{ block title }}{{ set t = "Başlık" }}{{ t }}{{ endblock }}|{{ t }}`, "").Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := Blocks(ast); len(got) != 1 || got[0] != "title" {
+		t.Errorf("Blocks: %v", got)
+	}
+	out, err := e.Render(`{{ block title }}{{ set t = "Başlık" }}{{ t }}{{ endblock }}|{{ t }}`, map[string]interface{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "Başlık|" {
+		t.Errorf("Beklenen: 'Başlık|', Gerçek: %q", out)
+	}
+}